@@ -0,0 +1,264 @@
+package ta
+
+import (
+	"fmt"
+	"math"
+)
+
+// TaAdaptive 自适应周期指标的计算结果
+// 说明：
+//
+//	每根 K 线使用各自的有效周期计算指标值，有效周期由波动率度量决定：
+//	波动收缩（震荡）时周期变长，波动扩张（趋势）时周期变短。
+//
+// 字段：
+//   - Values: 每个时间点的指标值
+//   - Periods: 每个时间点使用的有效周期
+//   - MinPeriod: 最小周期
+//   - MaxPeriod: 最大周期
+type TaAdaptive struct {
+	Values    []float64 `json:"values"`
+	Periods   []int     `json:"periods"`
+	MinPeriod int       `json:"min_period"`
+	MaxPeriod int       `json:"max_period"`
+}
+
+// CalculateAdaptive 按逐根 K 线的有效周期组合任意指标
+// 参数：
+//   - periods: 每个时间点的有效周期，通常由 AdaptivePeriodsATR 或 AdaptivePeriodsVR 得到
+//   - fn: 给定周期计算完整指标序列的函数
+//
+// 返回值：
+//   - *TaAdaptive: 计算结果
+//   - error: 任一周期计算失败或序列长度不一致时返回错误
+//
+// 说明/注意事项：
+//
+//	对区间内每个出现过的周期只计算一次完整序列，再逐点取值，
+//	复杂度为 O(n × 不同周期数)。
+//
+// 示例：
+//
+//	periods, _ := AdaptivePeriodsATR(klineData, 14, 100, 7, 28)
+//	adaptive, err := CalculateAdaptive(periods, func(p int) ([]float64, error) {
+//	    rsi, err := CalculateRSI(closes, p)
+//	    if err != nil {
+//	        return nil, err
+//	    }
+//	    return rsi.Values, nil
+//	})
+func CalculateAdaptive(periods []int, fn func(period int) ([]float64, error)) (*TaAdaptive, error) {
+	if len(periods) == 0 {
+		return nil, fmt.Errorf("计算数据不足")
+	}
+
+	length := len(periods)
+	minPeriod, maxPeriod := periods[0], periods[0]
+	for _, p := range periods {
+		if p <= 0 {
+			return nil, fmt.Errorf("周期必须大于0")
+		}
+		if p < minPeriod {
+			minPeriod = p
+		}
+		if p > maxPeriod {
+			maxPeriod = p
+		}
+	}
+
+	series := make(map[int][]float64)
+	values := make([]float64, length)
+	for i, p := range periods {
+		s, ok := series[p]
+		if !ok {
+			var err error
+			s, err = fn(p)
+			if err != nil {
+				return nil, err
+			}
+			if len(s) != length {
+				return nil, fmt.Errorf("周期%d的序列长度(%d)与周期序列长度(%d)不一致", p, len(s), length)
+			}
+			series[p] = s
+		}
+		values[i] = s[i]
+	}
+
+	return &TaAdaptive{
+		Values:    values,
+		Periods:   periods,
+		MinPeriod: minPeriod,
+		MaxPeriod: maxPeriod,
+	}, nil
+}
+
+// checkAdaptiveRange 检查周期范围，最大周期须小于数据长度，使 RSI 等需要 period+1 根 K 线的指标在任一候选周期下都能计算
+func checkAdaptiveRange(length, minPeriod, maxPeriod int) error {
+	if minPeriod <= 0 || maxPeriod < minPeriod {
+		return fmt.Errorf("周期范围无效: %d-%d", minPeriod, maxPeriod)
+	}
+	if maxPeriod >= length {
+		return fmt.Errorf("计算数据不足: 最大周期%d, 数据长度%d", maxPeriod, length)
+	}
+	return nil
+}
+
+// scalePeriod 将 [0,1] 的波动强度映射为周期，强度越高周期越短
+func scalePeriod(strength float64, minPeriod, maxPeriod int) int {
+	strength = math.Max(0, math.Min(1, strength))
+	return maxPeriod - int(math.Round(strength*float64(maxPeriod-minPeriod)))
+}
+
+// AdaptivePeriodsATR 以 ATR 在回看窗口内的百分位决定有效周期
+// 参数：
+//   - klineData: K 线数据
+//   - atrPeriod: ATR 周期
+//   - lookback: 计算百分位的回看窗口
+//   - minPeriod: 最小周期（ATR 处于窗口最高位时）
+//   - maxPeriod: 最大周期（ATR 处于窗口最低位时，预热期也使用该值），须小于数据长度
+//
+// 返回值：
+//   - []int: 每个时间点的有效周期
+//   - error: 参数无效或数据不足时返回错误
+func AdaptivePeriodsATR(klineData KlineDatas, atrPeriod, lookback, minPeriod, maxPeriod int) ([]int, error) {
+	if err := checkAdaptiveRange(len(klineData), minPeriod, maxPeriod); err != nil {
+		return nil, err
+	}
+	atr, err := CalculateATR(klineData, atrPeriod)
+	if err != nil {
+		return nil, err
+	}
+
+	length := len(klineData)
+	periods := make([]int, length)
+	for i := range periods {
+		periods[i] = maxPeriod
+	}
+	for i := atrPeriod + lookback - 1; i < length; i++ {
+		var below int
+		for j := i - lookback + 1; j <= i; j++ {
+			if atr.Values[j] <= atr.Values[i] {
+				below++
+			}
+		}
+		periods[i] = scalePeriod(float64(below-1)/math.Max(1, float64(lookback-1)), minPeriod, maxPeriod)
+	}
+	return periods, nil
+}
+
+// AdaptivePeriodsVR 以波动比率（VolatilityRatio）决定有效周期
+// 参数：
+//   - klineData: K 线数据
+//   - shortPeriod: 波动比率的短周期
+//   - longPeriod: 波动比率的长周期
+//   - minPeriod: 最小周期（比率 ≥ 1.5 时）
+//   - maxPeriod: 最大周期（比率 ≤ 0.5 时，预热期也使用该值），须小于数据长度
+//
+// 返回值：
+//   - []int: 每个时间点的有效周期
+//   - error: 参数无效或数据不足时返回错误
+func AdaptivePeriodsVR(klineData KlineDatas, shortPeriod, longPeriod, minPeriod, maxPeriod int) ([]int, error) {
+	if err := checkAdaptiveRange(len(klineData), minPeriod, maxPeriod); err != nil {
+		return nil, err
+	}
+	vr, err := CalculateVolatilityRatio(klineData, shortPeriod, longPeriod)
+	if err != nil {
+		return nil, err
+	}
+
+	periods := make([]int, len(klineData))
+	for i := range periods {
+		if i < longPeriod {
+			periods[i] = maxPeriod
+			continue
+		}
+		periods[i] = scalePeriod(vr.Values[i]-0.5, minPeriod, maxPeriod)
+	}
+	return periods, nil
+}
+
+// AdaptiveRSI 计算以 ATR 百分位自适应周期的 RSI
+// 参数：
+//   - minPeriod: 最小周期
+//   - maxPeriod: 最大周期
+//   - source: 数据源，如 "close"
+//
+// 返回值：
+//   - *TaAdaptive: 计算结果
+//   - error: 计算过程中的错误
+//
+// 说明/注意事项：
+//
+//	ATR 周期取 14，百分位回看窗口取 100。
+func (k *KlineDatas) AdaptiveRSI(minPeriod, maxPeriod int, source string) (*TaAdaptive, error) {
+	prices, err := k.ExtractSlice(source)
+	if err != nil {
+		return nil, err
+	}
+	periods, err := AdaptivePeriodsATR(*k, 14, 100, minPeriod, maxPeriod)
+	if err != nil {
+		return nil, err
+	}
+	return CalculateAdaptive(periods, func(period int) ([]float64, error) {
+		rsi, err := CalculateRSI(prices, period)
+		if err != nil {
+			return nil, err
+		}
+		return rsi.Values, nil
+	})
+}
+
+// AdaptiveBoll 计算以 ATR 百分位自适应周期的布林带
+// 参数：
+//   - minPeriod: 最小周期
+//   - maxPeriod: 最大周期
+//   - stdDev: 标准差倍数
+//   - source: 数据源，如 "close"
+//
+// 返回值：
+//   - *TaBoll: 各轨道逐点取自对应周期的布林带，Period 为最新一根使用的有效周期
+//   - []int: 每个时间点使用的有效周期
+//   - error: 计算过程中的错误
+func (k *KlineDatas) AdaptiveBoll(minPeriod, maxPeriod int, stdDev float64, source string) (*TaBoll, []int, error) {
+	prices, err := k.ExtractSlice(source)
+	if err != nil {
+		return nil, nil, err
+	}
+	periods, err := AdaptivePeriodsATR(*k, 14, 100, minPeriod, maxPeriod)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	bolls := make(map[int]*TaBoll)
+	length := len(prices)
+	slices := preallocateSlices(length, 3)
+	upper, mid, lower := slices[0], slices[1], slices[2]
+	for i, p := range periods {
+		b, ok := bolls[p]
+		if !ok {
+			b, err = CalculateBoll(prices, p, stdDev)
+			if err != nil {
+				return nil, nil, err
+			}
+			bolls[p] = b
+		}
+		upper[i], mid[i], lower[i] = b.Upper[i], b.Mid[i], b.Lower[i]
+	}
+	return &TaBoll{Upper: upper, Mid: mid, Lower: lower, Period: periods[length-1], StdDev: stdDev}, periods, nil
+}
+
+// Value 获取最新的自适应指标值
+func (t *TaAdaptive) Value() float64 {
+	return t.Values[len(t.Values)-1]
+}
+
+// Period 获取最新的有效周期
+func (t *TaAdaptive) Period() int {
+	return t.Periods[len(t.Periods)-1]
+}
+
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
@@ -48,7 +48,7 @@ type TaADX struct {
 //	}
 //	fmt.Printf("ADX: %v, +DI: %v, -DI: %v\n", adx.ADX[len(adx.ADX)-1], adx.PlusDI[len(adx.PlusDI)-1], adx.MinusDI[len(adx.MinusDI)-1])
 func CalculateADX(klineData KlineDatas, period int) (*TaADX, error) {
-	if len(klineData) < period {
+	if len(klineData) <= period {
 		return nil, fmt.Errorf("计算数据不足")
 	}
 
@@ -143,6 +143,19 @@ func (k *KlineDatas) ADX(period int) (*TaADX, error) {
 	return CalculateADX(*k, period)
 }
 
+// ADX_ 计算并返回最新的 ADX、+DI 和 -DI，数据不足时返回 0
+func (k *KlineDatas) ADX_(period int) (adx, plusDI, minusDI float64) {
+	_k, err := k.Keep(quickKeep("adx", period))
+	if err != nil {
+		_k = *k
+	}
+	result, err := _k.ADX(period)
+	if err != nil {
+		return 0, 0, 0
+	}
+	return result.Value()
+}
+
 // Value 获取最新的ADX、+DI和-DI值
 // 返回值：
 //   - adx: 最新的ADX值
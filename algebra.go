@@ -0,0 +1,130 @@
+package ta
+
+import (
+	"math"
+)
+
+// zipSeries 按末尾对齐两条序列并逐元素计算，结果长度为两者中较短的长度
+func zipSeries(a, b []float64, fn func(x, y float64) float64) []float64 {
+	size := len(a)
+	if len(b) < size {
+		size = len(b)
+	}
+	offsetA, offsetB := len(a)-size, len(b)-size
+	out := make([]float64, size)
+	for i := range out {
+		out[i] = fn(a[offsetA+i], b[offsetB+i])
+	}
+	return out
+}
+
+// mapSeries 逐元素计算单条序列
+func mapSeries(a []float64, fn func(x float64) float64) []float64 {
+	out := make([]float64, len(a))
+	for i, v := range a {
+		out[i] = fn(v)
+	}
+	return out
+}
+
+// Add 逐元素相加
+// 参数：
+//   - a, b: 两条序列
+//
+// 返回值：
+//   - []float64: a[i] + b[i]
+//
+// 说明/注意事项：
+//
+//	本文件中的二元运算均按末尾（最新 K 线）对齐，长度不同时结果长度为较短序列的长度。
+//	任一元素为 NaN 时结果为 NaN。
+//
+// 示例：
+//
+//	spread := Sub(fastEMA.Values, slowEMA.Values)
+//	blend := Scale(Add(rsi.Values, Scale(wr.Values, -1)), 0.5)
+func Add(a, b []float64) []float64 {
+	return zipSeries(a, b, func(x, y float64) float64 { return x + y })
+}
+
+// Sub 逐元素相减，返回 a[i] - b[i]
+func Sub(a, b []float64) []float64 {
+	return zipSeries(a, b, func(x, y float64) float64 { return x - y })
+}
+
+// Mul 逐元素相乘，返回 a[i] * b[i]
+func Mul(a, b []float64) []float64 {
+	return zipSeries(a, b, func(x, y float64) float64 { return x * y })
+}
+
+// Div 逐元素相除，返回 a[i] / b[i]，除数为 0 时结果为 NaN
+func Div(a, b []float64) []float64 {
+	return zipSeries(a, b, func(x, y float64) float64 {
+		if y == 0 {
+			return math.NaN()
+		}
+		return x / y
+	})
+}
+
+// Min 逐元素取较小值，任一元素为 NaN 时结果为 NaN
+func Min(a, b []float64) []float64 {
+	return zipSeries(a, b, math.Min)
+}
+
+// Max 逐元素取较大值，任一元素为 NaN 时结果为 NaN
+func Max(a, b []float64) []float64 {
+	return zipSeries(a, b, math.Max)
+}
+
+// Abs 逐元素取绝对值
+func Abs(a []float64) []float64 {
+	return mapSeries(a, math.Abs)
+}
+
+// Scale 逐元素乘以常数
+// 参数：
+//   - a: 输入序列
+//   - factor: 乘数
+//
+// 返回值：
+//   - []float64: a[i] * factor
+func Scale(a []float64, factor float64) []float64 {
+	return mapSeries(a, func(x float64) float64 { return x * factor })
+}
+
+// Offset 逐元素加上常数，返回 a[i] + value
+func Offset(a []float64, value float64) []float64 {
+	return mapSeries(a, func(x float64) float64 { return x + value })
+}
+
+// FillNaN 将序列中的 NaN 替换为指定值
+// 参数：
+//   - a: 输入序列
+//   - value: 替换值，如 0；传入 NaN 时改为沿用前一个有效值（开头的 NaN 保持不变）
+//
+// 返回值：
+//   - []float64: 替换后的新序列
+func FillNaN(a []float64, value float64) []float64 {
+	out := make([]float64, len(a))
+	forward := math.IsNaN(value)
+	last := math.NaN()
+	for i, v := range a {
+		switch {
+		case !math.IsNaN(v):
+			out[i] = v
+			last = v
+		case forward:
+			out[i] = last
+		default:
+			out[i] = value
+		}
+	}
+	return out
+}
+
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
@@ -0,0 +1,213 @@
+package ta
+
+import (
+	"fmt"
+	"math"
+)
+
+// 组合权重分配方式
+const (
+	// AllocEqual 等权重
+	AllocEqual = iota
+	// AllocInverseVol 按波动率倒数分配
+	AllocInverseVol
+	// AllocRiskParity 风险平价（各策略风险贡献相等，考虑相关性）
+	AllocRiskParity
+)
+
+// TaAllocation 多策略组合的计算结果
+// 字段：
+//   - Equity: 组合净值曲线，起点为 1
+//   - Returns: 组合每期收益率
+//   - Weights: 每期期初各策略的实际权重，Weights[i][s]
+//   - Method: 权重分配方式
+//   - Lookback: 估计波动率与协方差的回看窗口
+//   - Rebalance: 再平衡间隔（K 线数量）
+type TaAllocation struct {
+	Equity    []float64   `json:"equity"`
+	Returns   []float64   `json:"returns"`
+	Weights   [][]float64 `json:"weights"`
+	Method    int         `json:"method"`
+	Lookback  int         `json:"lookback"`
+	Rebalance int         `json:"rebalance"`
+}
+
+// CalculateAllocation 组合多条策略净值曲线并定期再平衡
+// 参数：
+//   - equities: 各策略的净值曲线，equities[s][i]，各序列等长且时间对齐
+//   - method: 权重分配方式，AllocEqual、AllocInverseVol 或 AllocRiskParity
+//   - lookback: 估计波动率与协方差的回看窗口
+//   - rebalance: 再平衡间隔（K 线数量）
+//
+// 返回值：
+//   - *TaAllocation: 组合结果
+//   - error: 参数无效或数据不足时返回错误
+//
+// 说明/注意事项：
+//
+//	回看窗口不足时使用等权重。权重只使用再平衡时刻之前的收益估计，不含未来数据。
+//	两次再平衡之间权重随各策略净值漂移。
+//
+// 示例：
+//
+//	alloc, err := CalculateAllocation([][]float64{trendEquity, meanRevEquity}, AllocRiskParity, 60, 20)
+func CalculateAllocation(equities [][]float64, method, lookback, rebalance int) (*TaAllocation, error) {
+	count := len(equities)
+	if count == 0 {
+		return nil, fmt.Errorf("策略数量不能为0")
+	}
+	length := len(equities[0])
+	for _, e := range equities {
+		if len(e) != length {
+			return nil, fmt.Errorf("输入数据长度不一致")
+		}
+	}
+	if length < 2 {
+		return nil, fmt.Errorf("计算数据不足")
+	}
+	if lookback < 2 || rebalance <= 0 {
+		return nil, fmt.Errorf("回看窗口必须不小于2且再平衡间隔必须大于0")
+	}
+	if method != AllocEqual && method != AllocInverseVol && method != AllocRiskParity {
+		return nil, fmt.Errorf("无效的权重分配方式: %d", method)
+	}
+
+	returns := make([][]float64, count)
+	for s, e := range equities {
+		returns[s] = SimpleReturns(e)
+	}
+
+	result := &TaAllocation{
+		Equity:    make([]float64, length),
+		Returns:   make([]float64, length),
+		Weights:   make([][]float64, length),
+		Method:    method,
+		Lookback:  lookback,
+		Rebalance: rebalance,
+	}
+	result.Equity[0] = 1
+
+	weights := equalWeights(count)
+	result.Weights[0] = append([]float64(nil), weights...)
+	for i := 1; i < length; i++ {
+		if (i-1)%rebalance == 0 {
+			weights = targetWeights(returns, i-1, lookback, method)
+		}
+		result.Weights[i] = append([]float64(nil), weights...)
+
+		var portfolio float64
+		for s := range weights {
+			portfolio += weights[s] * returns[s][i]
+		}
+		result.Returns[i] = portfolio
+		result.Equity[i] = result.Equity[i-1] * (1 + portfolio)
+
+		// 权重随净值漂移
+		if 1+portfolio != 0 {
+			for s := range weights {
+				weights[s] = weights[s] * (1 + returns[s][i]) / (1 + portfolio)
+			}
+		}
+	}
+	return result, nil
+}
+
+func equalWeights(count int) []float64 {
+	w := make([]float64, count)
+	for s := range w {
+		w[s] = 1 / float64(count)
+	}
+	return w
+}
+
+// targetWeights 使用 (end-lookback, end] 区间的收益估计目标权重
+func targetWeights(returns [][]float64, end, lookback, method int) []float64 {
+	count := len(returns)
+	if method == AllocEqual || end < lookback {
+		return equalWeights(count)
+	}
+
+	cov := make([][]float64, count)
+	means := make([]float64, count)
+	for s := range returns {
+		for t := end - lookback + 1; t <= end; t++ {
+			means[s] += returns[s][t]
+		}
+		means[s] /= float64(lookback)
+	}
+	for a := range returns {
+		cov[a] = make([]float64, count)
+		for b := range returns {
+			var sum float64
+			for t := end - lookback + 1; t <= end; t++ {
+				sum += (returns[a][t] - means[a]) * (returns[b][t] - means[b])
+			}
+			cov[a][b] = sum / float64(lookback-1)
+		}
+	}
+
+	w := make([]float64, count)
+	for s := range w {
+		if cov[s][s] > 0 {
+			w[s] = 1 / math.Sqrt(cov[s][s])
+		}
+	}
+	if !normalizeWeights(w) {
+		return equalWeights(count)
+	}
+	if method == AllocInverseVol {
+		return w
+	}
+
+	// 风险平价：不动点迭代使 w_s × (Σw)_s 相等
+	for iter := 0; iter < 100; iter++ {
+		next := make([]float64, count)
+		for s := range w {
+			var marginal float64
+			for b := range w {
+				marginal += cov[s][b] * w[b]
+			}
+			if marginal > 0 {
+				next[s] = math.Sqrt(w[s] / marginal)
+			}
+		}
+		if !normalizeWeights(next) {
+			break
+		}
+		var change float64
+		for s := range w {
+			change += math.Abs(next[s] - w[s])
+		}
+		w = next
+		if change < 1e-10 {
+			break
+		}
+	}
+	return w
+}
+
+// normalizeWeights 将权重归一化为总和 1，总和为 0 时返回 false
+func normalizeWeights(w []float64) bool {
+	var sum float64
+	for _, v := range w {
+		sum += v
+	}
+	if sum <= 0 {
+		return false
+	}
+	for s := range w {
+		w[s] /= sum
+	}
+	return true
+}
+
+// Value 返回组合最新净值
+func (t *TaAllocation) Value() float64 {
+	return t.Equity[len(t.Equity)-1]
+}
+
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
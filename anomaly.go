@@ -0,0 +1,209 @@
+package ta
+
+import (
+	"fmt"
+	"math"
+)
+
+// AnomalyEvent 一次异常读数
+// 字段：
+//   - Index: 读数在流中的序号，从 0 开始，被忽略的 NaN 读数同样计入
+//   - Value: 异常读数
+//   - Mean: 判定时的基准均值
+//   - Std: 判定时的基准标准差
+//   - Score: 偏离的标准差倍数，正数为向上偏离
+type AnomalyEvent struct {
+	Index int     `json:"index"`
+	Value float64 `json:"value"`
+	Mean  float64 `json:"mean"`
+	Std   float64 `json:"std"`
+	Score float64 `json:"score"`
+}
+
+// AnomalyDetector 指标流的在线异常检测
+// 说明：
+//
+//	每个新读数与此前的基准比较，偏离超过 Threshold 个标准差即判定为异常。
+//	基准为最近 Window 个读数的均值和标准差（滚动 Z 分数），
+//	或在 Alpha > 0 时使用 EWMA 均值和方差（EWMA 控制图）。
+//	当前读数在判定之后才并入基准，因此单个尖峰不会掩盖自身。
+//
+// 字段：
+//   - Window: 滚动窗口长度，也是开始判定前需要的最少读数
+//   - Alpha: EWMA 平滑系数，取值 (0, 1)，为 0 时使用滚动窗口
+//   - Threshold: 判定阈值（标准差倍数），如 5 表示 5σ
+//   - OnAnomaly: 检测到异常时的回调，可用于推送告警
+type AnomalyDetector struct {
+	Window    int
+	Alpha     float64
+	Threshold float64
+	OnAnomaly func(event AnomalyEvent)
+
+	// index 为已输入的读数数量（含 NaN），count 为并入基准的读数数量
+	index    int
+	count    int
+	buf      []float64
+	mean     float64
+	variance float64
+}
+
+// NewAnomalyDetector 创建滚动 Z 分数异常检测器
+// 参数：
+//   - window: 滚动窗口长度，至少为 2
+//   - threshold: 判定阈值（标准差倍数）
+//
+// 返回值：
+//   - *AnomalyDetector: 检测器
+//   - error: 参数无效时返回错误
+//
+// 示例：
+//
+//	detector, err := NewAnomalyDetector(100, 5)
+//	detector.OnAnomaly = func(e AnomalyEvent) { log.Printf("ATR 异常: %.1fσ", e.Score) }
+//	detector.Update(atr.Value())
+func NewAnomalyDetector(window int, threshold float64) (*AnomalyDetector, error) {
+	if window < 2 {
+		return nil, fmt.Errorf("窗口长度必须不小于2")
+	}
+	if threshold <= 0 {
+		return nil, fmt.Errorf("判定阈值必须大于0")
+	}
+	return &AnomalyDetector{
+		Window:    window,
+		Threshold: threshold,
+		buf:       make([]float64, 0, window),
+	}, nil
+}
+
+// NewEWMADetector 创建 EWMA 控制图异常检测器
+// 参数：
+//   - alpha: EWMA 平滑系数，取值 (0, 1)，越小基准越平稳
+//   - warmup: 开始判定前需要的最少读数
+//   - threshold: 判定阈值（标准差倍数）
+//
+// 返回值：
+//   - *AnomalyDetector: 检测器
+//   - error: 参数无效时返回错误
+func NewEWMADetector(alpha float64, warmup int, threshold float64) (*AnomalyDetector, error) {
+	if alpha <= 0 || alpha >= 1 {
+		return nil, fmt.Errorf("平滑系数必须在(0, 1)之间")
+	}
+	if warmup < 2 {
+		return nil, fmt.Errorf("预热数量必须不小于2")
+	}
+	if threshold <= 0 {
+		return nil, fmt.Errorf("判定阈值必须大于0")
+	}
+	return &AnomalyDetector{
+		Window:    warmup,
+		Alpha:     alpha,
+		Threshold: threshold,
+	}, nil
+}
+
+// Update 输入一个新读数
+// 参数：
+//   - value: 新读数，NaN 会被忽略
+//
+// 返回值：
+//   - AnomalyEvent: 本次读数的判定信息，预热期内 Score 为 0
+//   - bool: 是否为异常，为 true 时同时调用 OnAnomaly
+func (d *AnomalyDetector) Update(value float64) (AnomalyEvent, bool) {
+	event := AnomalyEvent{Index: d.index, Value: value}
+	d.index++
+	if math.IsNaN(value) {
+		return event, false
+	}
+
+	mean, std := d.baseline()
+	isAnomaly := false
+	if d.count >= d.Window {
+		event.Mean, event.Std = mean, std
+		switch {
+		case std > 0:
+			event.Score = (value - mean) / std
+		case value != mean:
+			event.Score = math.Copysign(math.Inf(1), value-mean)
+		}
+		isAnomaly = math.Abs(event.Score) >= d.Threshold
+	}
+
+	d.absorb(value)
+	if isAnomaly && d.OnAnomaly != nil {
+		d.OnAnomaly(event)
+	}
+	return event, isAnomaly
+}
+
+// baseline 返回当前基准的均值和标准差
+func (d *AnomalyDetector) baseline() (float64, float64) {
+	if d.Alpha > 0 {
+		return d.mean, math.Sqrt(d.variance)
+	}
+	n := len(d.buf)
+	if n < 2 {
+		return 0, 0
+	}
+	var mean float64
+	for _, v := range d.buf {
+		mean += v
+	}
+	mean /= float64(n)
+	var variance float64
+	for _, v := range d.buf {
+		variance += (v - mean) * (v - mean)
+	}
+	return mean, math.Sqrt(variance / float64(n-1))
+}
+
+// absorb 将读数并入基准
+func (d *AnomalyDetector) absorb(value float64) {
+	d.count++
+	if d.Alpha > 0 {
+		if d.count == 1 {
+			d.mean = value
+			return
+		}
+		diff := value - d.mean
+		d.mean += d.Alpha * diff
+		d.variance = (1 - d.Alpha) * (d.variance + d.Alpha*diff*diff)
+		return
+	}
+	if len(d.buf) == d.Window {
+		d.buf = append(d.buf[:0], d.buf[1:]...)
+	}
+	d.buf = append(d.buf, value)
+}
+
+// DetectAnomalies 对整条序列逐点运行异常检测
+// 参数：
+//   - series: 指标序列
+//   - window: 滚动窗口长度
+//   - threshold: 判定阈值（标准差倍数）
+//
+// 返回值：
+//   - []AnomalyEvent: 所有异常读数，Index 为序列下标
+//   - error: 参数无效时返回错误
+//
+// 说明/注意事项：
+//
+//	指标预热期的 0 值会进入基准，建议先截去预热期。
+func DetectAnomalies(series []float64, window int, threshold float64) ([]AnomalyEvent, error) {
+	d, err := NewAnomalyDetector(window, threshold)
+	if err != nil {
+		return nil, err
+	}
+	var events []AnomalyEvent
+	for _, v := range series {
+		if event, ok := d.Update(v); ok {
+			events = append(events, event)
+		}
+	}
+	return events, nil
+}
+
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
@@ -0,0 +1,60 @@
+package ta
+
+import (
+	"math"
+	"testing"
+)
+
+func TestDetectAnomalies(t *testing.T) {
+	series := make([]float64, 20)
+	for i := range series {
+		series[i] = float64(i%2) + 1
+	}
+	series[15] = 100
+
+	tests := []struct {
+		name   string
+		nanAt  []int
+		wantAt int
+	}{
+		{"没有 NaN", nil, 15},
+		{"尖峰之前有 NaN", []int{3}, 15},
+		{"多个 NaN", []int{2, 7, 12}, 15},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			input := append([]float64(nil), series...)
+			for _, i := range tt.nanAt {
+				input[i] = math.NaN()
+			}
+			events, err := DetectAnomalies(input, 5, 5)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(events) != 1 {
+				t.Fatalf("检测到 %d 个异常, want 1", len(events))
+			}
+			if events[0].Index != tt.wantAt || events[0].Value != 100 {
+				t.Errorf("异常 = {Index: %d, Value: %v}, want {Index: %d, Value: 100}", events[0].Index, events[0].Value, tt.wantAt)
+			}
+		})
+	}
+}
+
+func TestAnomalyDetectorRestoreIndex(t *testing.T) {
+	d, err := NewAnomalyDetector(3, 5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, v := range []float64{1, 2, math.NaN(), 1, 2} {
+		d.Update(v)
+	}
+	restored, err := RestoreAnomalyDetector(d.State())
+	if err != nil {
+		t.Fatal(err)
+	}
+	event, ok := restored.Update(100)
+	if !ok || event.Index != 5 {
+		t.Errorf("Update(100) = {Index: %d}, %v, want {Index: 5}, true", event.Index, ok)
+	}
+}
@@ -0,0 +1,136 @@
+package ta
+
+import (
+	"fmt"
+)
+
+// TaAroon 阿隆指标的计算结果
+// 说明：
+//
+//	阿隆指标衡量距离最近一次 period 周期新高/新低已经过去了多少根 K 线，用于判断趋势的“年龄”。
+//
+// 字段：
+//   - Up: 阿隆上线，100 × (period - 距最高价的K线数) / period
+//   - Down: 阿隆下线，100 × (period - 距最低价的K线数) / period
+//   - Oscillator: 阿隆振荡器，Up - Down，范围 -100 到 100
+//   - Period: 计算周期
+type TaAroon struct {
+	Up         []float64 `json:"up"`
+	Down       []float64 `json:"down"`
+	Oscillator []float64 `json:"oscillator"`
+	Period     int       `json:"period"`
+}
+
+// CalculateAroon 计算阿隆指标
+// 参数：
+//   - high: 最高价序列
+//   - low: 最低价序列
+//   - period: 计算周期，窗口包含当前K线在内共 period+1 根
+//
+// 返回值：
+//   - *TaAroon: 阿隆指标结果，前 period 个位置为 0
+//   - error: 数据不足时返回错误
+//
+// 说明/注意事项：
+//
+//	窗口内出现相同的最高（低）价时取最近的一根。
+//
+// 示例：
+//
+//	aroon, err := CalculateAroon(high, low, 25)
+//	if err != nil {
+//	    // 处理错误
+//	}
+//	up, down, osc := aroon.Value()
+func CalculateAroon(high, low []float64, period int) (*TaAroon, error) {
+	if period <= 0 {
+		return nil, fmt.Errorf("周期必须大于0")
+	}
+	if len(high) != len(low) {
+		return nil, fmt.Errorf("输入数据长度不一致")
+	}
+	if len(high) <= period {
+		return nil, fmt.Errorf("计算数据不足")
+	}
+
+	length := len(high)
+
+	slices := preallocateSlices(length, 3)
+	up, down, osc := slices[0], slices[1], slices[2]
+
+	for i := period; i < length; i++ {
+		highest, lowest := i, i
+		for j := i - 1; j >= i-period; j-- {
+			if high[j] > high[highest] {
+				highest = j
+			}
+			if low[j] < low[lowest] {
+				lowest = j
+			}
+		}
+		up[i] = 100 * float64(period-(i-highest)) / float64(period)
+		down[i] = 100 * float64(period-(i-lowest)) / float64(period)
+		osc[i] = up[i] - down[i]
+	}
+
+	return &TaAroon{
+		Up:         up,
+		Down:       down,
+		Oscillator: osc,
+		Period:     period,
+	}, nil
+}
+
+// Aroon 从 KlineDatas 中提取最高价和最低价并计算阿隆指标
+// 参数：
+//   - period: 计算周期
+//
+// 返回值：
+//   - *TaAroon: 阿隆指标结果
+//   - error: 数据不足时返回错误
+func (k *KlineDatas) Aroon(period int) (*TaAroon, error) {
+	high, err := k.ExtractSlice("high")
+	if err != nil {
+		return nil, err
+	}
+	low, err := k.ExtractSlice("low")
+	if err != nil {
+		return nil, err
+	}
+	return CalculateAroon(high, low, period)
+}
+
+// Aroon_ 计算并返回最新的阿隆上线、下线和振荡器值，数据不足时返回 0
+func (k *KlineDatas) Aroon_(period int) (up, down, oscillator float64) {
+	_k, err := k.Keep(quickKeep("aroon", period))
+	if err != nil {
+		_k = *k
+	}
+	aroon, err := _k.Aroon(period)
+	if err != nil {
+		return 0, 0, 0
+	}
+	return aroon.Value()
+}
+
+// Value 返回最新的阿隆上线、下线和振荡器值
+func (t *TaAroon) Value() (up, down, oscillator float64) {
+	lastIndex := len(t.Up) - 1
+	return t.Up[lastIndex], t.Down[lastIndex], t.Oscillator[lastIndex]
+}
+
+// IsBullishCross 判断最新一根K线阿隆上线是否上穿下线
+func (t *TaAroon) IsBullishCross() bool {
+	return CrossOver(t.Up, t.Down, len(t.Up)-1)
+}
+
+// IsBearishCross 判断最新一根K线阿隆上线是否下穿下线
+func (t *TaAroon) IsBearishCross() bool {
+	return CrossUnder(t.Up, t.Down, len(t.Up)-1)
+}
+
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
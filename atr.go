@@ -18,6 +18,10 @@ type TaATR struct {
 	Values    []float64 `json:"values"`
 	Period    int       `json:"period"`
 	TrueRange []float64 `json:"true_range"`
+
+	prevClose float64
+	// ready 表示增量状态已由 CalculateATR 或 RestoreATR 初始化
+	ready bool
 }
 
 // CalculateATR 计算给定 K 线数据的平均真实波动范围（ATR）
@@ -31,7 +35,7 @@ type TaATR struct {
 //
 // 说明/注意事项：
 //
-//	计算 ATR 时，需要至少 period 个 K 线数据。
+//	计算 ATR 时，需要至少 period+1 个 K 线数据（第一根没有前收盘价，不产生真实波动范围）。
 //	真实波动范围（TR）的计算基于当前时间点的最高价、最低价和上一个时间点的收盘价。
 //	初始 ATR 值为前 period 个 TR 的平均值，后续 ATR 值使用平滑公式计算。
 //
@@ -43,7 +47,7 @@ type TaATR struct {
 //	    log.Fatal(err)
 //	}
 func CalculateATR(klineData KlineDatas, period int) (*TaATR, error) {
-	if len(klineData) < period {
+	if len(klineData) <= period {
 		return nil, fmt.Errorf("计算数据不足")
 	}
 
@@ -77,6 +81,8 @@ func CalculateATR(klineData KlineDatas, period int) (*TaATR, error) {
 		Values:    atr,
 		Period:    period,
 		TrueRange: trueRange,
+		prevClose: klineData[length-1].Close,
+		ready:     true,
 	}, nil
 }
 
@@ -103,6 +109,36 @@ func (k *KlineDatas) ATR(period int) (*TaATR, error) {
 	return CalculateATR(*k, period)
 }
 
+// ATR_ 计算并返回最新的 ATR 值，数据不足时返回 0
+func (k *KlineDatas) ATR_(period int) float64 {
+	_k, err := k.Keep(quickKeep("atr", period))
+	if err != nil {
+		_k = *k
+	}
+	atr, err := _k.ATR(period)
+	if err != nil {
+		return 0
+	}
+	return atr.Value()
+}
+
+// Update 追加一根新 K 线并增量计算最新的 ATR，结果同时追加到 Values 末尾
+// 说明/注意事项：
+//
+//	前一根收盘价不在导出字段中，只有 CalculateATR 或 RestoreATR 得到的结果可以增量更新，
+//	从 JSON 解码或手工构造的 TaATR 调用时返回错误。
+func (t *TaATR) Update(high, low, close float64) (float64, error) {
+	if !t.ready {
+		return 0, fmt.Errorf("ATR 增量状态未初始化，请使用 CalculateATR 或 RestoreATR")
+	}
+	trueRange := math.Max(high-low, math.Max(math.Abs(high-t.prevClose), math.Abs(low-t.prevClose)))
+	value := (t.Value()*(float64(t.Period)-1) + trueRange) / float64(t.Period)
+	t.prevClose = close
+	t.Values = append(t.Values, value)
+	t.TrueRange = append(t.TrueRange, trueRange)
+	return value, nil
+}
+
 // Value 返回 TaATR 结构体中最新的 ATR 值
 // 返回值：
 //   - float64: 最新的 ATR 值
@@ -147,3 +183,36 @@ func (t *TaATR) Percent(currentPrice float64) float64 {
 	}
 	return t.Value() / currentPrice
 }
+
+// GetVolatilityRatio 计算最新 ATR 相对近期 ATR 均值的比率
+// 参数：
+//   - lookback: 计算均值的 ATR 数量，传 0 时使用 Period
+//
+// 返回值：
+//   - float64: 最新 ATR / 最近 lookback 个有效 ATR 的均值，大于 1 表示波动放大；有效数据不足或均值为 0 时返回 0
+//
+// 说明/注意事项：
+//
+//	预热期的 0 值不参与均值计算。
+//
+// 示例：
+//
+//	ratio := atr.GetVolatilityRatio(50)
+func (t *TaATR) GetVolatilityRatio(lookback int) float64 {
+	if lookback <= 0 {
+		lookback = t.Period
+	}
+	lastIndex := len(t.Values) - 1
+	start := lastIndex - lookback + 1
+	if start < t.Period || start < 0 {
+		return 0
+	}
+	var sum float64
+	for _, v := range t.Values[start:] {
+		sum += v
+	}
+	if sum == 0 {
+		return 0
+	}
+	return t.Values[lastIndex] / (sum / float64(lookback))
+}
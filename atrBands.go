@@ -0,0 +1,195 @@
+package ta
+
+import (
+	"fmt"
+)
+
+// ATR 通道中轨的均线类型
+const (
+	ATRBandsSMA = iota // 简单移动平均，即 STARC 通道
+	ATRBandsEMA        // 指数移动平均，即 Keltner 通道的 ATR 版本
+)
+
+// TaATRBands ATR 通道（STARC/Keltner）的计算结果
+// 说明：
+//
+//	中轨为收盘价的均线，上下轨为中轨 ± Multiplier × ATR，通道宽度随真实波动范围变化。
+//	第一个有效值的下标为 max(Period-1, ATRPeriod)，此前的位置为 0。
+//
+// 字段：
+//   - Upper: 上轨
+//   - Mid: 中轨
+//   - Lower: 下轨
+//   - ATR: 计算所用的 ATR 结果
+//   - Period: 中轨均线周期
+//   - Multiplier: ATR 倍数
+//   - Mode: 中轨均线类型，ATRBandsSMA 或 ATRBandsEMA
+type TaATRBands struct {
+	Upper      []float64 `json:"upper"`
+	Mid        []float64 `json:"mid"`
+	Lower      []float64 `json:"lower"`
+	ATR        *TaATR    `json:"atr"`
+	Period     int       `json:"period"`
+	Multiplier float64   `json:"multiplier"`
+	Mode       int       `json:"mode"`
+}
+
+// CalculateATRBands 计算 ATR 通道
+// 参数：
+//   - klineData: K 线数据
+//   - period: 中轨均线周期，STARC 常用 6，Keltner 常用 20
+//   - atrPeriod: ATR 周期，STARC 常用 15，Keltner 常用 10
+//   - multiplier: ATR 倍数，常用 2
+//   - mode: 中轨均线类型，ATRBandsSMA 或 ATRBandsEMA
+//
+// 返回值：
+//   - *TaATRBands: ATR 通道结果
+//   - error: 参数无效或数据不足时返回错误
+//
+// 说明/注意事项：
+//
+//	与布林带相比，通道宽度由 ATR 而非收盘价标准差决定，对跳空和长影线更敏感，对单边趋势中的收盘价偏离不敏感。
+//
+// 示例：
+//
+//	starc, err := CalculateATRBands(klineData, 6, 15, 2, ATRBandsSMA)
+//	if err != nil {
+//	    // 处理错误
+//	}
+//	if starc.IsBreakoutUp(closes) {
+//	    // 收盘价上穿上轨
+//	}
+func CalculateATRBands(klineData KlineDatas, period, atrPeriod int, multiplier float64, mode int) (*TaATRBands, error) {
+	if period <= 0 || atrPeriod <= 0 {
+		return nil, fmt.Errorf("周期必须大于0")
+	}
+	if multiplier <= 0 {
+		return nil, fmt.Errorf("ATR倍数必须大于0")
+	}
+	length := len(klineData)
+	if length < period || length <= atrPeriod {
+		return nil, fmt.Errorf("计算数据不足")
+	}
+
+	closes, err := klineData.ExtractSlice("close")
+	if err != nil {
+		return nil, err
+	}
+	var mid []float64
+	switch mode {
+	case ATRBandsSMA:
+		sma, err := CalculateSMA(closes, period)
+		if err != nil {
+			return nil, err
+		}
+		mid = sma.Values
+	case ATRBandsEMA:
+		ema, err := CalculateEMA(closes, period)
+		if err != nil {
+			return nil, err
+		}
+		mid = ema.Values
+	default:
+		return nil, fmt.Errorf("未知的中轨类型: %d", mode)
+	}
+	atr, err := CalculateATR(klineData, atrPeriod)
+	if err != nil {
+		return nil, err
+	}
+
+	slices := preallocateSlices(length, 3)
+	upper, midLine, lower := slices[0], slices[1], slices[2]
+	start := period - 1
+	if atrPeriod > start {
+		start = atrPeriod
+	}
+	for i := start; i < length; i++ {
+		band := atr.Values[i] * multiplier
+		midLine[i] = mid[i]
+		upper[i] = mid[i] + band
+		lower[i] = mid[i] - band
+	}
+
+	return &TaATRBands{
+		Upper:      upper,
+		Mid:        midLine,
+		Lower:      lower,
+		ATR:        atr,
+		Period:     period,
+		Multiplier: multiplier,
+		Mode:       mode,
+	}, nil
+}
+
+// ATRBands 为 KlineDatas 计算 ATR 通道，参数含义同 CalculateATRBands
+func (k *KlineDatas) ATRBands(period, atrPeriod int, multiplier float64, mode int) (*TaATRBands, error) {
+	return CalculateATRBands(*k, period, atrPeriod, multiplier, mode)
+}
+
+// ATRBands_ 计算并返回最新的 ATR 通道上轨、中轨和下轨（SMA 中轨），数据不足时返回 0
+func (k *KlineDatas) ATRBands_(period, atrPeriod int, multiplier float64) (upper, mid, lower float64) {
+	_k, err := k.Keep(quickKeep("atrbands", period, atrPeriod))
+	if err != nil {
+		_k = *k
+	}
+	bands, err := _k.ATRBands(period, atrPeriod, multiplier, ATRBandsSMA)
+	if err != nil {
+		return 0, 0, 0
+	}
+	return bands.Value()
+}
+
+// Value 返回 ATR 通道的最后一个值
+// 返回值：
+//   - upper: 上轨的最后一个值
+//   - mid: 中轨的最后一个值
+//   - lower: 下轨的最后一个值
+func (t *TaATRBands) Value() (upper, mid, lower float64) {
+	lastIndex := len(t.Upper) - 1
+	return t.Upper[lastIndex], t.Mid[lastIndex], t.Lower[lastIndex]
+}
+
+// BandwidthAt 返回指定位置的带宽，即 (上轨 − 下轨) / 中轨 × 100，中轨为 0 时返回 0
+func (t *TaATRBands) BandwidthAt(index int) float64 {
+	if t.Mid[index] == 0 {
+		return 0
+	}
+	return (t.Upper[index] - t.Lower[index]) / t.Mid[index] * 100
+}
+
+// PercentB 返回价格在最新通道中的位置，0 为下轨、1 为上轨，通道无效时返回 0.5
+func (t *TaATRBands) PercentB(price float64) float64 {
+	upper, _, lower := t.Value()
+	if upper <= lower {
+		return 0.5
+	}
+	return (price - lower) / (upper - lower)
+}
+
+// IsBreakoutUp 判断最新一根K线的价格是否向上突破上轨（前一根不高于上轨，当前高于上轨）
+// 参数：
+//   - prices: 用于判断的价格序列，通常为收盘价，与通道等长
+func (t *TaATRBands) IsBreakoutUp(prices []float64) bool {
+	lastIndex := len(t.Upper) - 1
+	if len(prices) != len(t.Upper) || lastIndex < 1 || t.Mid[lastIndex-1] == 0 {
+		return false
+	}
+	return prices[lastIndex-1] <= t.Upper[lastIndex-1] && prices[lastIndex] > t.Upper[lastIndex]
+}
+
+// IsBreakoutDown 判断最新一根K线的价格是否向下突破下轨（前一根不低于下轨，当前低于下轨）
+// 参数：
+//   - prices: 用于判断的价格序列，通常为收盘价，与通道等长
+func (t *TaATRBands) IsBreakoutDown(prices []float64) bool {
+	lastIndex := len(t.Lower) - 1
+	if len(prices) != len(t.Lower) || lastIndex < 1 || t.Mid[lastIndex-1] == 0 {
+		return false
+	}
+	return prices[lastIndex-1] >= t.Lower[lastIndex-1] && prices[lastIndex] < t.Lower[lastIndex]
+}
+
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
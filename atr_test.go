@@ -0,0 +1,46 @@
+package ta
+
+import (
+	"math"
+	"testing"
+)
+
+func TestTaATRGetVolatilityRatio(t *testing.T) {
+	// 周期 2，真实波幅依次为 2、2、2、6，ATR 为 [0, 0, 2, 2, 4]
+	klineData := KlineDatas{
+		{High: 10, Low: 8, Close: 9},
+		{High: 11, Low: 9, Close: 10},
+		{High: 12, Low: 10, Close: 11},
+		{High: 13, Low: 11, Close: 12},
+		{High: 18, Low: 12, Close: 17},
+	}
+	atr, err := CalculateATR(klineData, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		lookback int
+		want     float64
+	}{
+		{"默认使用周期", 0, 4.0 / 3},
+		{"全部有效值", 3, 1.5},
+		{"包含预热期", 4, 0},
+		{"超过数据长度", 10, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := atr.GetVolatilityRatio(tt.lookback); math.Abs(got-tt.want) > 1e-12 {
+				t.Errorf("GetVolatilityRatio(%d) = %v, want %v", tt.lookback, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTaATRGetVolatilityRatioZeroMean(t *testing.T) {
+	atr := &TaATR{Values: []float64{0, 0, 0, 0}, Period: 1}
+	if got := atr.GetVolatilityRatio(2); got != 0 {
+		t.Errorf("GetVolatilityRatio(2) = %v, want 0", got)
+	}
+}
@@ -3,6 +3,7 @@ package ta
 import (
 	"fmt"
 	"math"
+	"sort"
 )
 
 // TaBoll 表示布林带指标的计算结果
@@ -14,10 +15,14 @@ import (
 //   - Upper: 布林带上轨的值数组
 //   - Mid: 布林带中轨的值数组
 //   - Lower: 布林带下轨的值数组
+//   - Period: 计算周期，自适应布林带为最新一根使用的有效周期
+//   - StdDev: 标准差倍数
 type TaBoll struct {
-	Upper []float64 `json:"upper"`
-	Mid   []float64 `json:"mid"`
-	Lower []float64 `json:"lower"`
+	Upper  []float64 `json:"upper"`
+	Mid    []float64 `json:"mid"`
+	Lower  []float64 `json:"lower"`
+	Period int       `json:"period"`
+	StdDev float64   `json:"std_dev"`
 }
 
 // CalculateBoll 计算布林带指标
@@ -84,9 +89,11 @@ func CalculateBoll(prices []float64, period int, stdDev float64) (*TaBoll, error
 	}
 
 	return &TaBoll{
-		Upper: upper,
-		Mid:   mid,
-		Lower: lower,
+		Upper:  upper,
+		Mid:    mid,
+		Lower:  lower,
+		Period: period,
+		StdDev: stdDev,
 	}, nil
 }
 
@@ -111,6 +118,19 @@ func (k *KlineDatas) Boll(period int, stdDev float64, source string) (*TaBoll, e
 	return CalculateBoll(prices, period, stdDev)
 }
 
+// Boll_ 计算并返回最新的布林带上轨、中轨和下轨，数据不足时返回 0
+func (k *KlineDatas) Boll_(period int, stdDev float64, source string) (upper, mid, lower float64) {
+	_k, err := k.Keep(quickKeep("boll", period))
+	if err != nil {
+		_k = *k
+	}
+	boll, err := _k.Boll(period, stdDev, source)
+	if err != nil {
+		return 0, 0, 0
+	}
+	return boll.Value()
+}
+
 // Value 返回布林带指标的最后一个值
 // 返回值：
 //   - upper: 布林带上轨的最后一个值
@@ -121,6 +141,104 @@ func (t *TaBoll) Value() (upper, mid, lower float64) {
 	return t.Upper[lastIndex], t.Mid[lastIndex], t.Lower[lastIndex]
 }
 
+// BollBreakoutConfig 布林带突破判断的阈值
+// 字段：
+//   - Lookback: 计算带宽百分位的历史窗口
+//   - SqueezePercentile: 带宽百分位不高于该值（0-100）时视为收窄
+//   - WalkBars: 沿轨运行需要连续的 K 线数量
+//   - WalkZone: 沿轨区域，%B 不低于 WalkZone 视为贴近上轨，不高于 1-WalkZone 视为贴近下轨
+type BollBreakoutConfig struct {
+	Lookback          int     `json:"lookback"`
+	SqueezePercentile float64 `json:"squeeze_percentile"`
+	WalkBars          int     `json:"walk_bars"`
+	WalkZone          float64 `json:"walk_zone"`
+}
+
+// DefaultBollBreakout 默认的布林带突破判断阈值
+var DefaultBollBreakout = BollBreakoutConfig{
+	Lookback:          120,
+	SqueezePercentile: 20,
+	WalkBars:          3,
+	WalkZone:          0.8,
+}
+
+// BandwidthAt 返回指定位置的带宽，即 (上轨 − 下轨) / 中轨 × 100，中轨为 0 时返回 0
+func (t *TaBoll) BandwidthAt(index int) float64 {
+	if t.Mid[index] == 0 {
+		return 0
+	}
+	return (t.Upper[index] - t.Lower[index]) / t.Mid[index] * 100
+}
+
+// BandwidthPercentile 返回最新带宽在最近 lookback 个带宽中的百分位排名
+// 参数：
+//   - lookback: 历史窗口长度，包含最新一根
+//
+// 返回值：
+//   - float64: 百分位排名（0-100），越低表示带宽越窄；有效数据不足时返回 NaN
+//
+// 说明/注意事项：
+//
+//	预热期（中轨为 0）的位置不参与排名，可用于区分收敛与发散的市场状态。
+func (t *TaBoll) BandwidthPercentile(lookback int) float64 {
+	lastIndex := len(t.Mid) - 1
+	if lookback <= 0 || lastIndex < 0 || t.Mid[lastIndex] == 0 {
+		return math.NaN()
+	}
+	var widths []float64
+	for i := lastIndex; i >= 0 && i > lastIndex-lookback; i-- {
+		if t.Mid[i] != 0 {
+			widths = append(widths, t.BandwidthAt(i))
+		}
+	}
+	sort.Float64s(widths)
+	return percentileRank(widths, t.BandwidthAt(lastIndex))
+}
+
+// IsBreakoutPossible 判断是否具备布林带突破条件：带宽收窄且价格开始沿某一条轨道运行
+// 参数：
+//   - prices: 计算布林带所用的价格序列，与布林带等长
+//   - config: 判断阈值，可使用 DefaultBollBreakout
+//
+// 返回值：
+//   - bool: 是否具备突破条件
+//   - int: 可能的突破方向，1 向上，-1 向下，0 不具备条件
+//
+// 示例：
+//
+//	ok, direction := boll.IsBreakoutPossible(closes, DefaultBollBreakout)
+func (t *TaBoll) IsBreakoutPossible(prices []float64, config BollBreakoutConfig) (bool, int) {
+	lastIndex := len(t.Mid) - 1
+	if len(prices) != len(t.Mid) || config.WalkBars <= 0 || lastIndex-config.WalkBars+1 < 0 {
+		return false, 0
+	}
+	if p := t.BandwidthPercentile(config.Lookback); math.IsNaN(p) || p > config.SqueezePercentile {
+		return false, 0
+	}
+
+	up, down := true, true
+	for i := lastIndex - config.WalkBars + 1; i <= lastIndex; i++ {
+		width := t.Upper[i] - t.Lower[i]
+		if t.Mid[i] == 0 || width <= 0 {
+			return false, 0
+		}
+		percentB := (prices[i] - t.Lower[i]) / width
+		if percentB < config.WalkZone {
+			up = false
+		}
+		if percentB > 1-config.WalkZone {
+			down = false
+		}
+	}
+	switch {
+	case up:
+		return true, 1
+	case down:
+		return true, -1
+	}
+	return false, 0
+}
+
 // ----------------------------------------------------------------------------
 // ----------------------------------------------------------------------------
 // ----------------------------------------------------------------------------
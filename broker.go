@@ -0,0 +1,244 @@
+package ta
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sync"
+)
+
+// 订单类型
+const (
+	OrderMarket = iota // 市价单，立即按参考价成交
+	OrderLimit         // 限价单，价格触及限价时成交
+)
+
+// Order 提交给券商接口的订单
+// 字段：
+//   - Symbol: 交易对
+//   - Type: 订单类型，OrderMarket 或 OrderLimit
+//   - Quantity: 下单数量，正数为买入，负数为卖出
+//   - Price: 限价单的限价；市价单为参考价，模拟盘按其成交，实盘接口可忽略
+//   - Time: 下单时间（毫秒）
+type Order struct {
+	Symbol   string  `json:"symbol"`
+	Type     int     `json:"type"`
+	Quantity float64 `json:"quantity"`
+	Price    float64 `json:"price"`
+	Time     int64   `json:"time"`
+}
+
+// OrderStatus 下单结果
+// 字段：
+//   - ID: 订单编号，用于撤单
+//   - Filled: 已成交数量，带方向，未成交的限价单为 0
+//   - Price: 成交均价，未成交时为 0
+//   - Fee: 手续费
+type OrderStatus struct {
+	ID     string  `json:"id"`
+	Filled float64 `json:"filled"`
+	Price  float64 `json:"price"`
+	Fee    float64 `json:"fee"`
+}
+
+// Broker 券商接口，由交易所适配器或 PaperBroker 实现
+// 说明：
+//
+//	PlaceOrder 返回时已成交的部分由 OrderStatus.Filled 给出；限价单之后的成交由实现自行通知，
+//	PaperBroker 通过 Mark 返回。Positions 返回各交易对的持仓数量，正数为多头、负数为空头，空仓的交易对可省略。
+type Broker interface {
+	PlaceOrder(ctx context.Context, order Order) (OrderStatus, error)
+	CancelOrder(ctx context.Context, id string) error
+	Positions(ctx context.Context) (map[string]float64, error)
+}
+
+// PaperBroker 模拟盘券商，每个交易对使用一个 PaperTrader 记账
+// 说明：
+//
+//	市价单按 Order.Price 立即成交，Price 为 0 时使用该交易对最新的盯市价格；
+//	限价单挂起，由 Mark 在 K 线最高/最低价触及限价时成交，跳空越过限价时按开盘价成交。
+//	成交价同样按 Costs 的点差与滑点向不利方向调整。方法可在多个协程中调用。
+//
+// 字段：
+//   - Costs: 交易成本
+//   - InitialEquity: 初始权益，所有交易对共用
+type PaperBroker struct {
+	Costs         TradingCosts
+	InitialEquity float64
+
+	mu      sync.Mutex
+	traders map[string]*PaperTrader
+	orders  []PendingOrder
+	nextID  int
+}
+
+// PendingOrder 模拟盘挂起的限价单
+type PendingOrder struct {
+	ID    string `json:"id"`
+	Order Order  `json:"order"`
+}
+
+// NewPaperBroker 创建模拟盘券商
+// 参数：
+//   - equity: 初始权益
+//   - costs: 交易成本
+//
+// 返回值：
+//   - *PaperBroker: 空仓的模拟盘券商
+//   - error: 初始权益非正时返回错误
+//
+// 示例：
+//
+//	broker, err := NewPaperBroker(10000, TradingCosts{FeeRate: 0.0004})
+//	status, err := broker.PlaceOrder(ctx, Order{Symbol: "BTCUSDT", Type: OrderMarket, Quantity: 0.1, Price: close})
+func NewPaperBroker(equity float64, costs TradingCosts) (*PaperBroker, error) {
+	if equity <= 0 {
+		return nil, fmt.Errorf("初始权益必须大于0")
+	}
+	return &PaperBroker{
+		Costs:         costs,
+		InitialEquity: equity,
+		traders:       make(map[string]*PaperTrader),
+	}, nil
+}
+
+// trader 返回交易对的记账器，不存在时创建，调用方需持有锁
+func (b *PaperBroker) trader(symbol string) *PaperTrader {
+	p, ok := b.traders[symbol]
+	if !ok {
+		p = &PaperTrader{Costs: b.Costs, InitialEquity: b.InitialEquity}
+		b.traders[symbol] = p
+	}
+	return p
+}
+
+// fill 按价格成交一笔数量，调用方需持有锁
+func (b *PaperBroker) fill(order Order, price float64) (OrderStatus, error) {
+	p := b.trader(order.Symbol)
+	fees := p.Fees
+	if err := p.Target(p.Position+order.Quantity, price); err != nil {
+		return OrderStatus{}, err
+	}
+	return OrderStatus{Filled: order.Quantity, Price: p.fillPrice(order.Quantity, price), Fee: p.Fees - fees}, nil
+}
+
+// PlaceOrder 下单，市价单立即成交，限价单挂起
+// 返回值：
+//   - OrderStatus: 市价单的成交结果；限价单 Filled 为 0
+//   - error: 数量为 0、订单类型未知或没有可用的成交价格时返回错误
+func (b *PaperBroker) PlaceOrder(_ context.Context, order Order) (OrderStatus, error) {
+	if order.Quantity == 0 || math.IsNaN(order.Quantity) || math.IsInf(order.Quantity, 0) {
+		return OrderStatus{}, fmt.Errorf("下单数量无效: %v", order.Quantity)
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.nextID++
+	id := fmt.Sprintf("paper-%d", b.nextID)
+
+	switch order.Type {
+	case OrderMarket:
+		price := order.Price
+		if price <= 0 {
+			price = b.trader(order.Symbol).Price
+		}
+		if price <= 0 {
+			return OrderStatus{}, fmt.Errorf("%s 没有可用的成交价格", order.Symbol)
+		}
+		status, err := b.fill(order, price)
+		status.ID = id
+		return status, err
+	case OrderLimit:
+		if order.Price <= 0 {
+			return OrderStatus{}, fmt.Errorf("限价必须大于0")
+		}
+		b.orders = append(b.orders, PendingOrder{ID: id, Order: order})
+		return OrderStatus{ID: id}, nil
+	default:
+		return OrderStatus{}, fmt.Errorf("未知的订单类型: %d", order.Type)
+	}
+}
+
+// CancelOrder 撤销挂起的限价单，订单不存在或已成交时返回错误
+func (b *PaperBroker) CancelOrder(_ context.Context, id string) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for i, o := range b.orders {
+		if o.ID == id {
+			b.orders = append(b.orders[:i], b.orders[i+1:]...)
+			return nil
+		}
+	}
+	return fmt.Errorf("订单不存在: %s", id)
+}
+
+// Positions 返回各交易对的非零持仓数量
+func (b *PaperBroker) Positions(context.Context) (map[string]float64, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	positions := make(map[string]float64)
+	for symbol, p := range b.traders {
+		if p.Position != 0 {
+			positions[symbol] = p.Position
+		}
+	}
+	return positions, nil
+}
+
+// Mark 用一根 K 线撮合该交易对挂起的限价单并按收盘价盯市
+// 参数：
+//   - symbol: 交易对
+//   - kline: 最新 K 线，可以是未收盘 K 线的更新
+//
+// 返回值：
+//   - []Fill: 本次成交的限价单，按下单顺序
+func (b *PaperBroker) Mark(symbol string, kline *KlineData) []Fill {
+	if kline == nil {
+		return nil
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	var fills []Fill
+	remaining := b.orders[:0]
+	for _, o := range b.orders {
+		order := o.Order
+		price, ok := 0.0, false
+		switch {
+		case order.Symbol != symbol:
+		case order.Quantity > 0 && kline.Low <= order.Price:
+			price, ok = math.Min(order.Price, kline.Open), true
+		case order.Quantity < 0 && kline.High >= order.Price:
+			price, ok = math.Max(order.Price, kline.Open), true
+		}
+		if !ok {
+			remaining = append(remaining, o)
+			continue
+		}
+		status, err := b.fill(order, price)
+		if err != nil {
+			remaining = append(remaining, o)
+			continue
+		}
+		fills = append(fills, Fill{Symbol: symbol, Time: kline.StartTime, Quantity: status.Filled, Price: status.Price, Fee: status.Fee})
+	}
+	b.orders = remaining
+	b.trader(symbol).Mark(kline.Close)
+	return fills
+}
+
+// Equity 返回总权益：初始权益 + 各交易对的盈亏之和
+func (b *PaperBroker) Equity() float64 {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	equity := b.InitialEquity
+	for _, p := range b.traders {
+		equity += p.Equity() - p.InitialEquity
+	}
+	return equity
+}
+
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
@@ -0,0 +1,92 @@
+package ta
+
+import (
+	"context"
+	"math"
+	"testing"
+)
+
+func TestPaperBrokerMarketAndLimit(t *testing.T) {
+	ctx := context.Background()
+	broker, err := NewPaperBroker(1000, TradingCosts{FeeRate: 0.001})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	status, err := broker.PlaceOrder(ctx, Order{Symbol: "BTCUSDT", Type: OrderMarket, Quantity: 2, Price: 100})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if status.Filled != 2 || status.Price != 100 || math.Abs(status.Fee-0.2) > 1e-12 {
+		t.Errorf("市价单 = %+v", status)
+	}
+
+	// 限价卖单在最高价触及 110 时成交，另一笔买单撤销后不再成交
+	sell, _ := broker.PlaceOrder(ctx, Order{Symbol: "BTCUSDT", Type: OrderLimit, Quantity: -1, Price: 110})
+	buy, _ := broker.PlaceOrder(ctx, Order{Symbol: "BTCUSDT", Type: OrderLimit, Quantity: 1, Price: 90})
+	if sell.Filled != 0 || sell.ID == buy.ID {
+		t.Errorf("限价单 = %+v %+v", sell, buy)
+	}
+	if err := broker.CancelOrder(ctx, buy.ID); err != nil {
+		t.Fatal(err)
+	}
+	if err := broker.CancelOrder(ctx, buy.ID); err == nil {
+		t.Error("重复撤单应返回错误")
+	}
+
+	if fills := broker.Mark("BTCUSDT", &KlineData{StartTime: 1, Open: 100, High: 105, Low: 80, Close: 104}); len(fills) != 0 {
+		t.Errorf("未触及限价时成交了 %v", fills)
+	}
+	fills := broker.Mark("BTCUSDT", &KlineData{StartTime: 2, Open: 104, High: 112, Low: 80, Close: 108})
+	if len(fills) != 1 || fills[0].Quantity != -1 || fills[0].Price != 110 || fills[0].Time != 2 {
+		t.Fatalf("限价成交 = %+v", fills)
+	}
+
+	positions, _ := broker.Positions(ctx)
+	if positions["BTCUSDT"] != 1 {
+		t.Errorf("持仓 = %v, want 1", positions)
+	}
+	// 已实现 10，未实现 1×(108−100)，手续费 0.2 + 0.11
+	if want := 1000 + 10 + 8 - 0.31; math.Abs(broker.Equity()-want) > 1e-9 {
+		t.Errorf("Equity = %v, want %v", broker.Equity(), want)
+	}
+}
+
+func TestPaperBrokerLimitGap(t *testing.T) {
+	ctx := context.Background()
+	broker, _ := NewPaperBroker(1000, TradingCosts{})
+	if _, err := broker.PlaceOrder(ctx, Order{Symbol: "ETHUSDT", Type: OrderLimit, Quantity: 1, Price: 50}); err != nil {
+		t.Fatal(err)
+	}
+	// 其他交易对的 K 线不撮合
+	if fills := broker.Mark("BTCUSDT", &KlineData{Open: 40, High: 40, Low: 40, Close: 40}); len(fills) != 0 {
+		t.Errorf("其他交易对成交了 %v", fills)
+	}
+	// 跳空低开越过限价时按开盘价成交
+	fills := broker.Mark("ETHUSDT", &KlineData{Open: 45, High: 47, Low: 44, Close: 46})
+	if len(fills) != 1 || fills[0].Price != 45 {
+		t.Errorf("跳空成交 = %+v, want 价格 45", fills)
+	}
+}
+
+func TestPaperBrokerInvalidOrder(t *testing.T) {
+	ctx := context.Background()
+	broker, _ := NewPaperBroker(1000, TradingCosts{})
+	tests := []struct {
+		name  string
+		order Order
+	}{
+		{"数量为0", Order{Symbol: "BTCUSDT", Quantity: 0, Price: 100}},
+		{"数量为NaN", Order{Symbol: "BTCUSDT", Quantity: math.NaN(), Price: 100}},
+		{"市价单无价格", Order{Symbol: "BTCUSDT", Quantity: 1}},
+		{"限价非正", Order{Symbol: "BTCUSDT", Type: OrderLimit, Quantity: 1}},
+		{"未知类型", Order{Symbol: "BTCUSDT", Type: 9, Quantity: 1, Price: 100}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := broker.PlaceOrder(ctx, tt.order); err == nil {
+				t.Error("应返回错误")
+			}
+		})
+	}
+}
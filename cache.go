@@ -0,0 +1,196 @@
+package ta
+
+import (
+	"container/list"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// Cache 指标计算结果缓存
+// 说明：
+//
+//	以输入序列哈希和参数作为键，缓存 Calculate* 系列函数的计算结果。
+//	内存中使用 LRU 淘汰策略，可选地将结果以 JSON 形式持久化到磁盘目录。
+//	适用于参数寻优等需要反复计算相同 EMA 等中间结果的场景。
+//
+// 字段：
+//   - Capacity: 内存中最多保留的结果数量
+//   - Dir: 磁盘缓存目录，为空时不使用磁盘缓存
+type Cache struct {
+	Capacity int
+	Dir      string
+
+	mu    sync.Mutex
+	order *list.List
+	items map[string]*list.Element
+}
+
+type cacheEntry struct {
+	key   string
+	value interface{}
+}
+
+// NewCache 创建指标结果缓存
+// 参数：
+//   - capacity: 内存中最多保留的结果数量，必须大于0
+//   - dir: 磁盘缓存目录，为空时仅使用内存缓存
+//
+// 返回值：
+//   - *Cache: 缓存实例
+//   - error: 参数无效或创建目录失败时返回错误
+//
+// 示例：
+//
+//	cache, err := NewCache(1024, "")
+func NewCache(capacity int, dir string) (*Cache, error) {
+	if capacity <= 0 {
+		return nil, fmt.Errorf("缓存容量必须大于0")
+	}
+	if dir != "" {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return nil, fmt.Errorf("创建缓存目录失败: %v", err)
+		}
+	}
+	return &Cache{
+		Capacity: capacity,
+		Dir:      dir,
+		order:    list.New(),
+		items:    make(map[string]*list.Element),
+	}, nil
+}
+
+// CacheKey 根据指标名称、输入序列和参数生成缓存键
+// 参数：
+//   - name: 指标名称，如 "ema"
+//   - prices: 输入序列
+//   - params: 计算参数，按调用顺序传入
+//
+// 返回值：
+//   - string: 十六进制编码的 SHA-256 摘要
+//
+// 示例：
+//
+//	key := CacheKey("ema", prices, 20)
+func CacheKey(name string, prices []float64, params ...interface{}) string {
+	h := sha256.New()
+	h.Write([]byte(name))
+	var buf [8]byte
+	for _, p := range prices {
+		binary.LittleEndian.PutUint64(buf[:], math.Float64bits(p))
+		h.Write(buf[:])
+	}
+	for _, p := range params {
+		fmt.Fprintf(h, "|%v", p)
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// Len 返回内存中缓存的结果数量
+func (c *Cache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.order.Len()
+}
+
+// Clear 清空内存缓存，磁盘缓存不受影响
+func (c *Cache) Clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.order.Init()
+	c.items = make(map[string]*list.Element)
+}
+
+func (c *Cache) get(key string) (interface{}, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if e, ok := c.items[key]; ok {
+		c.order.MoveToFront(e)
+		return e.Value.(*cacheEntry).value, true
+	}
+	return nil, false
+}
+
+func (c *Cache) put(key string, value interface{}) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if e, ok := c.items[key]; ok {
+		e.Value.(*cacheEntry).value = value
+		c.order.MoveToFront(e)
+		return
+	}
+	c.items[key] = c.order.PushFront(&cacheEntry{key: key, value: value})
+	for c.order.Len() > c.Capacity {
+		last := c.order.Back()
+		c.order.Remove(last)
+		delete(c.items, last.Value.(*cacheEntry).key)
+	}
+}
+
+func (c *Cache) path(key string) string {
+	return filepath.Join(c.Dir, key+".json")
+}
+
+// Cached 从缓存中获取结果，未命中时调用 fn 计算并写入缓存
+// 参数：
+//   - c: 缓存实例，为 nil 时直接调用 fn
+//   - key: 缓存键，通常由 CacheKey 生成
+//   - fn: 实际的计算函数
+//
+// 返回值：
+//   - T: 计算结果
+//   - error: 计算过程中的错误，出错的结果不会被缓存
+//
+// 说明/注意事项：
+//
+//	命中内存缓存时返回的是同一个结果对象，调用方不应修改其中的切片。
+//	磁盘缓存读写失败不会影响计算，只会退化为重新计算。
+//
+// 示例：
+//
+//	ema, err := Cached(cache, CacheKey("ema", prices, 20), func() (*TaEMA, error) {
+//	    return CalculateEMA(prices, 20)
+//	})
+func Cached[T any](c *Cache, key string, fn func() (T, error)) (T, error) {
+	if c == nil {
+		return fn()
+	}
+	if v, ok := c.get(key); ok {
+		if result, ok := v.(T); ok {
+			return result, nil
+		}
+	}
+	if c.Dir != "" {
+		if data, err := os.ReadFile(c.path(key)); err == nil {
+			var result T
+			if err := json.Unmarshal(data, &result); err == nil {
+				c.put(key, result)
+				return result, nil
+			}
+		}
+	}
+
+	result, err := fn()
+	if err != nil {
+		return result, err
+	}
+	c.put(key, result)
+	if c.Dir != "" {
+		if data, err := json.Marshal(result); err == nil {
+			_ = os.WriteFile(c.path(key), data, 0o644)
+		}
+	}
+	return result, nil
+}
+
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
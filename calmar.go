@@ -0,0 +1,163 @@
+package ta
+
+import (
+	"fmt"
+	"math"
+)
+
+// TaCalmar 滚动 Calmar 比率（收益 / 最大回撤）的计算结果
+// 字段：
+//   - Values: Calmar 比率，窗口内最大回撤为 0 时为 0，前 Period 个位置为 0
+//   - Return: 窗口收益率，PeriodsPerYear > 0 时为年化收益率，如 0.35 表示 35%
+//   - MaxDrawdown: 窗口内最大回撤比例，≥ 0，如 0.2 表示 20%
+//   - Period: 窗口包含的收益期数
+//   - PeriodsPerYear: 年化因子
+type TaCalmar struct {
+	Values         []float64 `json:"values"`
+	Return         []float64 `json:"return"`
+	MaxDrawdown    []float64 `json:"max_drawdown"`
+	Period         int       `json:"period"`
+	PeriodsPerYear float64   `json:"periods_per_year"`
+}
+
+// maxDrawdown 返回序列的最大回撤比例
+func maxDrawdown(values []float64) float64 {
+	var peak, dd float64
+	for _, v := range values {
+		peak = math.Max(peak, v)
+		if peak > 0 {
+			dd = math.Max(dd, 1-v/peak)
+		}
+	}
+	return dd
+}
+
+// annualizedReturn 返回从 first 到 last 经过 periods 期的收益率，periodsPerYear > 0 时按复利年化
+func annualizedReturn(first, last float64, periods int, periodsPerYear float64) float64 {
+	if first <= 0 || last <= 0 || periods <= 0 {
+		return 0
+	}
+	if periodsPerYear <= 0 {
+		return last/first - 1
+	}
+	return math.Pow(last/first, periodsPerYear/float64(periods)) - 1
+}
+
+// CalculateCalmar 计算滚动 Calmar 比率
+// 参数：
+//   - prices: 价格或回测净值序列，必须为正
+//   - period: 窗口包含的收益期数，如日线 252
+//   - periodsPerYear: 年化因子，如日线加密货币 365、股票 252；传 0 时不年化
+//
+// 返回值：
+//   - *TaCalmar: Calmar 比率、窗口收益率与最大回撤
+//   - error: 参数无效或数据不足时返回错误
+//
+// 说明/注意事项：
+//
+//	Calmar 比率 = 窗口收益率 / 窗口内最大回撤，同时衡量收益与承受的最大痛苦。
+//	可作为指标比较不同策略，也可作为状态过滤器：比率低于阈值时暂停趋势策略，见 IsFavorable。
+//
+// 示例：
+//
+//	calmar, err := CalculateCalmar(equity, 90, 365)
+//	if err != nil {
+//	    // 处理错误
+//	}
+//	if !calmar.IsFavorable(1) {
+//	    // 最近 90 天收益不足以覆盖回撤，降低仓位
+//	}
+func CalculateCalmar(prices []float64, period int, periodsPerYear float64) (*TaCalmar, error) {
+	if period <= 0 {
+		return nil, fmt.Errorf("周期必须大于0")
+	}
+	if periodsPerYear < 0 {
+		return nil, fmt.Errorf("年化因子不能为负数")
+	}
+	if len(prices) <= period {
+		return nil, fmt.Errorf("计算数据不足")
+	}
+
+	length := len(prices)
+	slices := preallocateSlices(length, 3)
+	values, returns, drawdowns := slices[0], slices[1], slices[2]
+	for i := period; i < length; i++ {
+		returns[i] = annualizedReturn(prices[i-period], prices[i], period, periodsPerYear)
+		drawdowns[i] = maxDrawdown(prices[i-period : i+1])
+		if drawdowns[i] > 0 {
+			values[i] = returns[i] / drawdowns[i]
+		}
+	}
+
+	return &TaCalmar{
+		Values:         values,
+		Return:         returns,
+		MaxDrawdown:    drawdowns,
+		Period:         period,
+		PeriodsPerYear: periodsPerYear,
+	}, nil
+}
+
+// MARRatio 计算整条净值曲线的 MAR 比率（自起点以来的年化收益 / 最大回撤）
+// 参数：
+//   - equity: 回测净值或价格序列，必须为正
+//   - periodsPerYear: 年化因子，传 0 时使用总收益率
+//
+// 返回值：
+//   - float64: MAR 比率，数据不足或没有回撤时返回 0
+func MARRatio(equity []float64, periodsPerYear float64) float64 {
+	if len(equity) < 2 {
+		return 0
+	}
+	dd := maxDrawdown(equity)
+	if dd == 0 {
+		return 0
+	}
+	return annualizedReturn(equity[0], equity[len(equity)-1], len(equity)-1, periodsPerYear) / dd
+}
+
+// Calmar 从 KlineDatas 中提取数据并计算滚动 Calmar 比率
+// 参数：
+//   - period: 窗口包含的收益期数
+//   - periodsPerYear: 年化因子，传 0 时不年化
+//   - source: 数据源，如 "close"
+func (k *KlineDatas) Calmar(period int, periodsPerYear float64, source string) (*TaCalmar, error) {
+	prices, err := k.ExtractSlice(source)
+	if err != nil {
+		return nil, err
+	}
+	return CalculateCalmar(prices, period, periodsPerYear)
+}
+
+// Calmar_ 计算并返回最新的 Calmar 比率，数据不足时返回 0
+func (k *KlineDatas) Calmar_(period int, periodsPerYear float64, source string) float64 {
+	_k, err := k.Keep(quickKeep("calmar", period))
+	if err != nil {
+		_k = *k
+	}
+	calmar, err := _k.Calmar(period, periodsPerYear, source)
+	if err != nil {
+		return 0
+	}
+	return calmar.Value()
+}
+
+// Value 返回最新的 Calmar 比率
+func (t *TaCalmar) Value() float64 {
+	return t.Values[len(t.Values)-1]
+}
+
+// IsFavorable 判断最新窗口是否处于有利状态：收益为正，且 Calmar 比率不低于 threshold 或窗口内没有回撤
+func (t *TaCalmar) IsFavorable(threshold float64) bool {
+	lastIndex := len(t.Values) - 1
+	if lastIndex < t.Period || t.Return[lastIndex] <= 0 {
+		return false
+	}
+	return t.MaxDrawdown[lastIndex] == 0 || t.Values[lastIndex] >= threshold
+}
+
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
@@ -0,0 +1,184 @@
+package ta
+
+import (
+	"fmt"
+	"math"
+)
+
+// TaCandleStats K 线实体与影线统计的计算结果
+// 字段：
+//   - Body: 实体比例，|收盘价 − 开盘价| / 振幅
+//   - UpperWick: 上影线比例，(最高价 − max(开盘价, 收盘价)) / 振幅
+//   - LowerWick: 下影线比例，(min(开盘价, 收盘价) − 最低价) / 振幅
+//   - ClosePosition: 收盘价在振幅中的位置，(收盘价 − 最低价) / 振幅，0 为收在最低、1 为收在最高
+//   - AvgBody/AvgUpperWick/AvgLowerWick/AvgClosePosition: 以上各项最近 Period 根的简单平均
+//   - Period: 平均周期
+//
+// 说明：
+//
+//	振幅为 0（一字线）时实体与影线比例为 0，收盘位置为 0.5。
+//	逐根比例没有预热期；平均值的预热期为 Period-1，前 Period-1 个位置为 0。
+type TaCandleStats struct {
+	Body             []float64 `json:"body"`
+	UpperWick        []float64 `json:"upper_wick"`
+	LowerWick        []float64 `json:"lower_wick"`
+	ClosePosition    []float64 `json:"close_position"`
+	AvgBody          []float64 `json:"avg_body"`
+	AvgUpperWick     []float64 `json:"avg_upper_wick"`
+	AvgLowerWick     []float64 `json:"avg_lower_wick"`
+	AvgClosePosition []float64 `json:"avg_close_position"`
+	Period           int       `json:"period"`
+}
+
+// CalculateCandleStats 计算 K 线实体与影线统计
+// 参数：
+//   - klineData: K 线数据
+//   - period: 平均周期
+//
+// 返回值：
+//   - *TaCandleStats: 计算结果
+//   - error: 参数无效或数据不足时返回错误
+//
+// 说明/注意事项：
+//
+//	各项均为振幅的比例，不受价格水平影响，可直接用作规则策略的条件或机器学习特征。
+//
+// 示例：
+//
+//	cs, err := CalculateCandleStats(klineData, 20)
+//	if err != nil {
+//	    // 处理错误
+//	}
+//	if cs.IsHammer(2) {
+//	    // 长下影线、收盘靠近高点
+//	}
+func CalculateCandleStats(klineData KlineDatas, period int) (*TaCandleStats, error) {
+	if period <= 0 {
+		return nil, fmt.Errorf("周期必须大于0")
+	}
+	length := len(klineData)
+	if length < period {
+		return nil, fmt.Errorf("计算数据不足")
+	}
+
+	slices := preallocateSlices(length, 8)
+	result := &TaCandleStats{
+		Body:             slices[0],
+		UpperWick:        slices[1],
+		LowerWick:        slices[2],
+		ClosePosition:    slices[3],
+		AvgBody:          slices[4],
+		AvgUpperWick:     slices[5],
+		AvgLowerWick:     slices[6],
+		AvgClosePosition: slices[7],
+		Period:           period,
+	}
+
+	for i, kline := range klineData {
+		r := kline.High - kline.Low
+		if r <= 0 {
+			result.ClosePosition[i] = 0.5
+			continue
+		}
+		result.Body[i] = math.Abs(kline.Close-kline.Open) / r
+		result.UpperWick[i] = (kline.High - math.Max(kline.Open, kline.Close)) / r
+		result.LowerWick[i] = (math.Min(kline.Open, kline.Close) - kline.Low) / r
+		result.ClosePosition[i] = (kline.Close - kline.Low) / r
+	}
+
+	for _, pair := range [][2][]float64{
+		{result.Body, result.AvgBody},
+		{result.UpperWick, result.AvgUpperWick},
+		{result.LowerWick, result.AvgLowerWick},
+		{result.ClosePosition, result.AvgClosePosition},
+	} {
+		source, avg := pair[0], pair[1]
+		var sum float64
+		for i := 0; i < length; i++ {
+			sum += source[i]
+			if i >= period {
+				sum -= source[i-period]
+			}
+			if i >= period-1 {
+				avg[i] = sum / float64(period)
+			}
+		}
+	}
+
+	return result, nil
+}
+
+// CandleStats 计算 K 线数据的实体与影线统计
+// 参数：
+//   - period: 平均周期
+//
+// 返回值：
+//   - *TaCandleStats: 计算结果
+//   - error: 计算过程中的错误
+func (k *KlineDatas) CandleStats(period int) (*TaCandleStats, error) {
+	return CalculateCandleStats(*k, period)
+}
+
+// CandleStats_ 计算并返回最新一根 K 线的实体比例、上影线比例、下影线比例和收盘位置，数据不足时返回 0
+func (k *KlineDatas) CandleStats_(period int) (body, upperWick, lowerWick, closePosition float64) {
+	_k, err := k.Keep(quickKeep("candlestats", period))
+	if err != nil {
+		_k = *k
+	}
+	cs, err := _k.CandleStats(period)
+	if err != nil {
+		return 0, 0, 0, 0
+	}
+	return cs.Value()
+}
+
+// Value 返回最新一根 K 线的实体比例、上影线比例、下影线比例和收盘位置
+func (t *TaCandleStats) Value() (body, upperWick, lowerWick, closePosition float64) {
+	lastIndex := len(t.Body) - 1
+	return t.Body[lastIndex], t.UpperWick[lastIndex], t.LowerWick[lastIndex], t.ClosePosition[lastIndex]
+}
+
+// IsHammer 判断最新一根K线是否为锤子线形态：下影线至少为实体的 ratio 倍、上影线不超过实体、收盘位于振幅上半部分
+func (t *TaCandleStats) IsHammer(ratio float64) bool {
+	body, upper, lower, closePosition := t.Value()
+	return body > 0 && lower >= ratio*body && upper <= body && closePosition >= 0.5
+}
+
+// IsShootingStar 判断最新一根K线是否为射击之星形态：上影线至少为实体的 ratio 倍、下影线不超过实体、收盘位于振幅下半部分
+func (t *TaCandleStats) IsShootingStar(ratio float64) bool {
+	body, upper, lower, closePosition := t.Value()
+	return body > 0 && upper >= ratio*body && lower <= body && closePosition <= 0.5
+}
+
+// IsWideBody 判断最新一根K线的实体比例是否超过近期平均的 mult 倍
+func (t *TaCandleStats) IsWideBody(mult float64) bool {
+	lastIndex := len(t.Body) - 1
+	avg := t.AvgBody[lastIndex]
+	return avg > 0 && t.Body[lastIndex] > mult*avg
+}
+
+// FeatureNames 返回 Features 各列的名称
+func (t *TaCandleStats) FeatureNames() []string {
+	return []string{
+		"body", "upper_wick", "lower_wick", "close_position",
+		"avg_body", "avg_upper_wick", "avg_lower_wick", "avg_close_position",
+	}
+}
+
+// Features 将统计结果转换为特征矩阵，rows[i] 为第 i 根 K 线的特征，列顺序见 FeatureNames
+func (t *TaCandleStats) Features() [][]float64 {
+	rows := make([][]float64, len(t.Body))
+	for i := range rows {
+		rows[i] = []float64{
+			t.Body[i], t.UpperWick[i], t.LowerWick[i], t.ClosePosition[i],
+			t.AvgBody[i], t.AvgUpperWick[i], t.AvgLowerWick[i], t.AvgClosePosition[i],
+		}
+	}
+	return rows
+}
+
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
@@ -95,6 +95,19 @@ func (k *KlineDatas) CCI(period int) (*TaCCI, error) {
 	return CalculateCCI(*k, period)
 }
 
+// CCI_ 计算并返回最新的 CCI 值，数据不足时返回 0
+func (k *KlineDatas) CCI_(period int) float64 {
+	_k, err := k.Keep(quickKeep("cci", period))
+	if err != nil {
+		_k = *k
+	}
+	cci, err := _k.CCI(period)
+	if err != nil {
+		return 0
+	}
+	return cci.Value()
+}
+
 // Value 获取 TaCCI 结构体中最后一个 CCI 值
 // 返回值：
 //   - float64: 最后一个 CCI 值
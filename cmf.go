@@ -119,6 +119,19 @@ func (k *KlineDatas) CMF(period int, source string) (*TaCMF, error) {
 	return CalculateCMF(high, low, close, volume, period)
 }
 
+// CMF_ 计算并返回最新的 CMF 值，数据不足时返回 0
+func (k *KlineDatas) CMF_(period int, source string) float64 {
+	_k, err := k.Keep(quickKeep("cmf", period))
+	if err != nil {
+		_k = *k
+	}
+	cmf, err := _k.CMF(period, source)
+	if err != nil {
+		return 0
+	}
+	return cmf.Value()
+}
+
 // Value 获取 TaCMF 结构体中最后一个 CMF 值
 // 返回值：
 //   - float64: 最后一个 CMF 值
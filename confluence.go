@@ -0,0 +1,132 @@
+package ta
+
+import (
+	"fmt"
+	"math"
+	"sort"
+)
+
+// ConfluenceLevel 参与共振判断的一个价格位
+// 字段：
+//   - Name: 价格位来源，如 "vwap"、"boll_upper"、"swing_high"；同名价格位视为同一来源，只计一次
+//   - Price: 价格
+//   - Weight: 权重，0 视为 1
+type ConfluenceLevel struct {
+	Name   string  `json:"name"`
+	Price  float64 `json:"price"`
+	Weight float64 `json:"weight"`
+}
+
+// TaConfluence 多指标价格位共振的判断结果
+// 字段：
+//   - Price: 被检查的价格
+//   - Tolerance: 容差（价格单位），由容差百分比换算得到
+//   - Count: 容差范围内的独立来源数量
+//   - Score: 命中来源的权重之和
+//   - Matched: 命中的价格位，每个来源取距离最近的一个，按距离由近到远排列
+type TaConfluence struct {
+	Price     float64           `json:"price"`
+	Tolerance float64           `json:"tolerance"`
+	Count     int               `json:"count"`
+	Score     float64           `json:"score"`
+	Matched   []ConfluenceLevel `json:"matched"`
+}
+
+// Confluence 统计有多少个独立的价格位落在价格附近
+// 参数：
+//   - price: 被检查的价格，如当前价或计划入场价
+//   - tolerancePct: 容差百分比，如 0.3 表示 ±0.3%
+//   - levels: 各指标给出的价格位，可由 TaBoll、TaAnchoredVWAP、TaStructure 的 ConfluenceLevels 得到
+//
+// 返回值：
+//   - *TaConfluence: 共振结果
+//   - error: 价格或容差无效时返回错误
+//
+// 说明/注意事项：
+//
+//	同一来源的多个价格位（如多个摆动高点）只按最近的一个计分，避免同类价格位重复放大分数。
+//	价格为 0 或 NaN 的价格位（如预热期）会被忽略。
+//
+// 示例：
+//
+//	levels := append(boll.ConfluenceLevels(), vwap.ConfluenceLevels()...)
+//	levels = append(levels, ms.ConfluenceLevels(5)...)
+//	c, err := Confluence(price, 0.3, levels...)
+//	if c.Count >= 3 {
+//	    // 至少三个独立价格位汇聚在当前价附近
+//	}
+func Confluence(price, tolerancePct float64, levels ...ConfluenceLevel) (*TaConfluence, error) {
+	if price <= 0 || math.IsNaN(price) {
+		return nil, fmt.Errorf("价格必须大于0")
+	}
+	if tolerancePct < 0 {
+		return nil, fmt.Errorf("容差不能为负数")
+	}
+	tolerance := price * tolerancePct / 100
+
+	best := make(map[string]ConfluenceLevel)
+	for _, level := range levels {
+		if level.Price == 0 || math.IsNaN(level.Price) || math.Abs(level.Price-price) > tolerance {
+			continue
+		}
+		if current, ok := best[level.Name]; !ok || math.Abs(level.Price-price) < math.Abs(current.Price-price) {
+			best[level.Name] = level
+		}
+	}
+
+	result := &TaConfluence{Price: price, Tolerance: tolerance}
+	for _, level := range best {
+		if level.Weight == 0 {
+			level.Weight = 1
+		}
+		result.Matched = append(result.Matched, level)
+		result.Score += level.Weight
+	}
+	result.Count = len(result.Matched)
+	sort.Slice(result.Matched, func(i, j int) bool {
+		di, dj := math.Abs(result.Matched[i].Price-price), math.Abs(result.Matched[j].Price-price)
+		if di != dj {
+			return di < dj
+		}
+		return result.Matched[i].Name < result.Matched[j].Name
+	})
+	return result, nil
+}
+
+// ConfluenceLevels 返回最新的布林带上轨、中轨和下轨价格位
+func (t *TaBoll) ConfluenceLevels() []ConfluenceLevel {
+	upper, mid, lower := t.Value()
+	return []ConfluenceLevel{
+		{Name: "boll_upper", Price: upper},
+		{Name: "boll_mid", Price: mid},
+		{Name: "boll_lower", Price: lower},
+	}
+}
+
+// ConfluenceLevels 返回最新的锚定 VWAP 价格位
+func (t *TaAnchoredVWAP) ConfluenceLevels() []ConfluenceLevel {
+	return []ConfluenceLevel{{Name: "vwap", Price: t.Value()}}
+}
+
+// ConfluenceLevels 返回最近 n 个已确认摆动点的价格位，名称为 "swing_high" 或 "swing_low"，n 不大于 0 时返回全部摆动点
+func (t *TaStructure) ConfluenceLevels(n int) []ConfluenceLevel {
+	start := len(t.Points) - n
+	if n <= 0 || start < 0 {
+		start = 0
+	}
+	levels := make([]ConfluenceLevel, 0, len(t.Points)-start)
+	for _, p := range t.Points[start:] {
+		name := "swing_low"
+		if p.IsHigh {
+			name = "swing_high"
+		}
+		levels = append(levels, ConfluenceLevel{Name: name, Price: p.Price})
+	}
+	return levels
+}
+
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
@@ -0,0 +1,40 @@
+package ta
+
+import (
+	"testing"
+)
+
+func TestTaStructureConfluenceLevels(t *testing.T) {
+	structure := &TaStructure{Points: []StructurePoint{
+		{Price: 10, IsHigh: true},
+		{Price: 8},
+		{Price: 12, IsHigh: true},
+	}}
+
+	tests := []struct {
+		name   string
+		n      int
+		prices []float64
+	}{
+		{"最近两个", 2, []float64{8, 12}},
+		{"超过数量", 5, []float64{10, 8, 12}},
+		{"零表示全部", 0, []float64{10, 8, 12}},
+		{"负数表示全部", -1, []float64{10, 8, 12}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			levels := structure.ConfluenceLevels(tt.n)
+			if len(levels) != len(tt.prices) {
+				t.Fatalf("ConfluenceLevels(%d) 返回 %d 个价格位, want %d", tt.n, len(levels), len(tt.prices))
+			}
+			for i, price := range tt.prices {
+				if levels[i].Price != price {
+					t.Errorf("价格位 %d = %v, want %v", i, levels[i].Price, price)
+				}
+			}
+		})
+	}
+	if levels := structure.ConfluenceLevels(1); levels[0].Name != "swing_high" {
+		t.Errorf("名称 = %q, want swing_high", levels[0].Name)
+	}
+}
@@ -0,0 +1,151 @@
+package ta
+
+import (
+	"fmt"
+)
+
+// TaCoppock 估波曲线（Coppock Curve）的计算结果
+// 说明：
+//
+//	估波曲线 = WMA(ROC(longPeriod) + ROC(shortPeriod), wmaPeriod)，ROC 为百分比变动率。
+//	原始参数针对月线（14、11、10），多用于周线、月线等长周期的底部买入信号。
+//
+// 字段：
+//   - Values: 估波曲线，前 longPeriod+wmaPeriod-1 个位置为 0
+//   - LongPeriod: 长周期 ROC 的间隔
+//   - ShortPeriod: 短周期 ROC 的间隔
+//   - WMAPeriod: 加权移动平均的周期
+type TaCoppock struct {
+	Values      []float64 `json:"values"`
+	LongPeriod  int       `json:"long_period"`
+	ShortPeriod int       `json:"short_period"`
+	WMAPeriod   int       `json:"wma_period"`
+}
+
+// CalculateCoppock 计算估波曲线
+// 参数：
+//   - prices: 价格序列
+//   - longPeriod: 长周期 ROC 的间隔，如 14
+//   - shortPeriod: 短周期 ROC 的间隔，如 11
+//   - wmaPeriod: 加权移动平均的周期，如 10
+//
+// 返回值：
+//   - *TaCoppock: 估波曲线结果
+//   - error: 参数无效或数据不足时返回错误
+//
+// 示例：
+//
+//	coppock, err := CalculateCoppock(closes, 14, 11, 10)
+//	if err != nil {
+//	    // 处理错误
+//	}
+//	if coppock.IsBuySignal() {
+//	    // 估波曲线在 0 轴下方拐头向上
+//	}
+func CalculateCoppock(prices []float64, longPeriod, shortPeriod, wmaPeriod int) (*TaCoppock, error) {
+	if longPeriod <= 0 || shortPeriod <= 0 || wmaPeriod <= 0 {
+		return nil, fmt.Errorf("周期必须大于0")
+	}
+	if shortPeriod > longPeriod {
+		return nil, fmt.Errorf("短周期不能大于长周期")
+	}
+	if len(prices) < longPeriod+wmaPeriod {
+		return nil, fmt.Errorf("计算数据不足")
+	}
+
+	longROC, err := CalculateROC(prices, longPeriod, ROCPercent)
+	if err != nil {
+		return nil, err
+	}
+	shortROC, err := CalculateROC(prices, shortPeriod, ROCPercent)
+	if err != nil {
+		return nil, err
+	}
+
+	length := len(prices)
+	sum := make([]float64, length)
+	for i := longPeriod; i < length; i++ {
+		sum[i] = longROC.Values[i] + shortROC.Values[i]
+	}
+
+	values := make([]float64, length)
+	weights := float64(wmaPeriod*(wmaPeriod+1)) / 2
+	for i := longPeriod + wmaPeriod - 1; i < length; i++ {
+		var weighted float64
+		for j := 0; j < wmaPeriod; j++ {
+			weighted += sum[i-j] * float64(wmaPeriod-j)
+		}
+		values[i] = weighted / weights
+	}
+
+	return &TaCoppock{
+		Values:      values,
+		LongPeriod:  longPeriod,
+		ShortPeriod: shortPeriod,
+		WMAPeriod:   wmaPeriod,
+	}, nil
+}
+
+// Coppock 从 KlineDatas 中提取数据并计算估波曲线
+// 参数：
+//   - longPeriod: 长周期 ROC 的间隔
+//   - shortPeriod: 短周期 ROC 的间隔
+//   - wmaPeriod: 加权移动平均的周期
+//   - source: 数据源，如 "close"
+//
+// 返回值：
+//   - *TaCoppock: 估波曲线结果
+//   - error: 提取数据或计算过程中的错误
+func (k *KlineDatas) Coppock(longPeriod, shortPeriod, wmaPeriod int, source string) (*TaCoppock, error) {
+	prices, err := k.ExtractSlice(source)
+	if err != nil {
+		return nil, err
+	}
+	return CalculateCoppock(prices, longPeriod, shortPeriod, wmaPeriod)
+}
+
+// Coppock_ 计算并返回最新的估波曲线值，数据不足时返回 0
+func (k *KlineDatas) Coppock_(longPeriod, shortPeriod, wmaPeriod int, source string) float64 {
+	_k, err := k.Keep(quickKeep("coppock", longPeriod, shortPeriod, wmaPeriod))
+	if err != nil {
+		_k = *k
+	}
+	coppock, err := _k.Coppock(longPeriod, shortPeriod, wmaPeriod, source)
+	if err != nil {
+		return 0
+	}
+	return coppock.Value()
+}
+
+// Value 返回最新的估波曲线值
+func (t *TaCoppock) Value() float64 {
+	return t.Values[len(t.Values)-1]
+}
+
+// IsBuySignal 判断最新一根K线是否出现买入信号：曲线在 0 轴下方由下降转为上升
+// 说明/注意事项：
+//
+//	这是估波曲线的经典用法，信号出现在 0 轴穿越之前；需要更保守的确认时使用 IsCrossAboveZero。
+func (t *TaCoppock) IsBuySignal() bool {
+	lastIndex := len(t.Values) - 1
+	if lastIndex-2 < t.LongPeriod+t.WMAPeriod-1 {
+		return false
+	}
+	current, previous, before := t.Values[lastIndex], t.Values[lastIndex-1], t.Values[lastIndex-2]
+	return current < 0 && previous < before && current > previous
+}
+
+// IsCrossAboveZero 判断最新一根K线估波曲线是否上穿 0 轴（前一根 ≤ 0，当前 > 0）
+func (t *TaCoppock) IsCrossAboveZero() bool {
+	lastIndex := len(t.Values) - 1
+	if lastIndex-1 < t.LongPeriod+t.WMAPeriod-1 {
+		return false
+	}
+	return t.Values[lastIndex-1] <= 0 && t.Values[lastIndex] > 0
+}
+
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
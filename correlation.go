@@ -0,0 +1,236 @@
+package ta
+
+import (
+	"fmt"
+	"math"
+)
+
+// TaCorrelationMerge 层次聚类中的一次合并
+// 字段：
+//   - Left: 被合并的第一个簇编号，小于序列数量的编号为单个序列，其余为第 (编号-序列数量) 次合并产生的簇
+//   - Right: 被合并的第二个簇编号
+//   - Distance: 合并时两簇的平均距离（1 - 相关系数）
+//   - Size: 合并后簇内的序列数量
+type TaCorrelationMerge struct {
+	Left     int     `json:"left"`
+	Right    int     `json:"right"`
+	Distance float64 `json:"distance"`
+	Size     int     `json:"size"`
+}
+
+// TaCorrelation 多条序列的相关系数矩阵与层次聚类结果
+// 字段：
+//   - Names: 序列名称
+//   - Matrix: 皮尔逊相关系数矩阵，Matrix[a][b]
+//   - Merges: 平均连接层次聚类的合并过程，按距离从小到大
+//   - Order: 聚类树的叶子顺序，按此顺序排列矩阵可使相关的序列相邻
+type TaCorrelation struct {
+	Names  []string             `json:"names"`
+	Matrix [][]float64          `json:"matrix"`
+	Merges []TaCorrelationMerge `json:"merges"`
+	Order  []int                `json:"order"`
+}
+
+// CalculateCorrelation 计算多条指标序列或策略收益序列的相关系数矩阵并进行层次聚类
+// 参数：
+//   - series: 各条序列，series[s][i]，各序列等长且时间对齐
+//   - names: 序列名称，与 series 等长
+//
+// 返回值：
+//   - *TaCorrelation: 相关系数矩阵与聚类结果
+//   - error: 参数无效或数据不足时返回错误
+//
+// 说明/注意事项：
+//
+//	每对序列只使用两者均不为 NaN 的位置计算相关系数，有效点少于 3 个或任一方差为 0 时相关系数记为 0。
+//	指标的预热期请先截去或置为 NaN，避免开头的 0 值影响结果。
+//	聚类距离为 1 - 相关系数，负相关的序列距离最远。
+//
+// 示例：
+//
+//	corr, err := CalculateCorrelation([][]float64{rsi, cci, williamsR}, []string{"rsi", "cci", "wr"})
+//	groups := corr.Clusters(0.3)
+func CalculateCorrelation(series [][]float64, names []string) (*TaCorrelation, error) {
+	count := len(series)
+	if count == 0 {
+		return nil, fmt.Errorf("序列数量不能为0")
+	}
+	if len(names) != count {
+		return nil, fmt.Errorf("序列名称数量与序列数量不一致")
+	}
+	length := len(series[0])
+	for _, s := range series {
+		if len(s) != length {
+			return nil, fmt.Errorf("输入数据长度不一致")
+		}
+	}
+	if length < 3 {
+		return nil, fmt.Errorf("计算数据不足")
+	}
+
+	matrix := make([][]float64, count)
+	for a := range matrix {
+		matrix[a] = make([]float64, count)
+		matrix[a][a] = 1
+	}
+	for a := 0; a < count; a++ {
+		for b := a + 1; b < count; b++ {
+			rho := pairCorrelation(series[a], series[b])
+			matrix[a][b] = rho
+			matrix[b][a] = rho
+		}
+	}
+
+	result := &TaCorrelation{
+		Names:  names,
+		Matrix: matrix,
+	}
+	result.Merges, result.Order = averageLinkage(matrix)
+	return result, nil
+}
+
+// pairCorrelation 计算两条序列在共同有效位置上的皮尔逊相关系数
+func pairCorrelation(x, y []float64) float64 {
+	var n, sumX, sumY float64
+	for i := range x {
+		if math.IsNaN(x[i]) || math.IsNaN(y[i]) {
+			continue
+		}
+		n++
+		sumX += x[i]
+		sumY += y[i]
+	}
+	if n < 3 {
+		return 0
+	}
+	meanX, meanY := sumX/n, sumY/n
+	var cov, varX, varY float64
+	for i := range x {
+		if math.IsNaN(x[i]) || math.IsNaN(y[i]) {
+			continue
+		}
+		dx, dy := x[i]-meanX, y[i]-meanY
+		cov += dx * dy
+		varX += dx * dx
+		varY += dy * dy
+	}
+	if varX == 0 || varY == 0 {
+		return 0
+	}
+	return cov / math.Sqrt(varX*varY)
+}
+
+// averageLinkage 以 1 - 相关系数为距离进行平均连接层次聚类，返回合并过程与叶子顺序
+func averageLinkage(matrix [][]float64) ([]TaCorrelationMerge, []int) {
+	count := len(matrix)
+	// members[c] 为簇 c 包含的序列，按叶子顺序排列；nil 表示簇已被合并
+	members := make([][]int, count, 2*count-1)
+	for s := range members {
+		members[s] = []int{s}
+	}
+	merges := make([]TaCorrelationMerge, 0, count-1)
+
+	for len(merges) < count-1 {
+		left, right := -1, -1
+		best := math.Inf(1)
+		for a := range members {
+			if members[a] == nil {
+				continue
+			}
+			for b := a + 1; b < len(members); b++ {
+				if members[b] == nil {
+					continue
+				}
+				var sum float64
+				for _, i := range members[a] {
+					for _, j := range members[b] {
+						sum += 1 - matrix[i][j]
+					}
+				}
+				d := sum / float64(len(members[a])*len(members[b]))
+				if d < best {
+					best, left, right = d, a, b
+				}
+			}
+		}
+		merged := append(append([]int(nil), members[left]...), members[right]...)
+		merges = append(merges, TaCorrelationMerge{
+			Left:     left,
+			Right:    right,
+			Distance: best,
+			Size:     len(merged),
+		})
+		members[left], members[right] = nil, nil
+		members = append(members, merged)
+	}
+	return merges, members[len(members)-1]
+}
+
+// Clusters 按距离阈值切分聚类树
+// 参数：
+//   - maxDistance: 簇内平均距离上限（1 - 相关系数），例如 0.3 表示平均相关系数不低于 0.7 的序列归为一组
+//
+// 返回值：
+//   - [][]int: 各组包含的序列下标，按聚类树叶子顺序排列
+//
+// 说明/注意事项：
+//
+//	从每组中只选一条序列组合信号，可以降低信号之间的冗余。
+func (t *TaCorrelation) Clusters(maxDistance float64) [][]int {
+	count := len(t.Matrix)
+	members := make([][]int, count, 2*count-1)
+	for s := range members {
+		members[s] = []int{s}
+	}
+	for _, m := range t.Merges {
+		if m.Distance > maxDistance {
+			break
+		}
+		members = append(members, append(append([]int(nil), members[m.Left]...), members[m.Right]...))
+		members[m.Left], members[m.Right] = nil, nil
+	}
+
+	// 簇在叶子顺序中是连续的，以首个序列定位各组
+	first := make(map[int][]int)
+	for _, group := range members {
+		if len(group) > 0 {
+			first[group[0]] = group
+		}
+	}
+	var groups [][]int
+	for _, s := range t.Order {
+		if group, ok := first[s]; ok {
+			groups = append(groups, group)
+		}
+	}
+	return groups
+}
+
+// Value 返回两条序列之间的相关系数
+// 参数：
+//   - a: 第一条序列的名称
+//   - b: 第二条序列的名称
+//
+// 返回值：
+//   - float64: 相关系数，名称不存在时返回 NaN
+func (t *TaCorrelation) Value(a, b string) float64 {
+	ia, ib := -1, -1
+	for s, name := range t.Names {
+		if name == a {
+			ia = s
+		}
+		if name == b {
+			ib = s
+		}
+	}
+	if ia < 0 || ib < 0 {
+		return math.NaN()
+	}
+	return t.Matrix[ia][ib]
+}
+
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
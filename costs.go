@@ -0,0 +1,102 @@
+package ta
+
+// TradingCosts 交易成本参数，均以价格的比例表示（0.001 表示 0.1%）
+// 字段：
+//   - FeeRate: 单边手续费率
+//   - Spread: 买卖价差（完整往返只支付一次）
+//   - Slippage: 单边滑点
+type TradingCosts struct {
+	FeeRate  float64 `json:"fee_rate"`
+	Spread   float64 `json:"spread"`
+	Slippage float64 `json:"slippage"`
+}
+
+// RoundTrip 返回一次完整开平仓的总成本比例
+func (c TradingCosts) RoundTrip() float64 {
+	return 2*c.FeeRate + c.Spread + 2*c.Slippage
+}
+
+// Decide 根据预期收益决定是否交易
+// 参数：
+//   - expectedReturn: 预期收益比例，正数看多，负数看空
+//   - minEdge: 扣除成本后要求的最小优势
+//
+// 返回值：
+//   - int: 1 做多，-1 做空，0 不交易
+//
+// 示例：
+//
+//	costs := TradingCosts{FeeRate: 0.0004, Spread: 0.0002, Slippage: 0.0003}
+//	side := costs.Decide(0.004, 0.001) // 0.004 - 0.0016 >= 0.001，返回 1
+func (c TradingCosts) Decide(expectedReturn, minEdge float64) int {
+	cost := c.RoundTrip()
+	switch {
+	case expectedReturn-cost >= minEdge && expectedReturn > 0:
+		return 1
+	case -expectedReturn-cost >= minEdge && expectedReturn < 0:
+		return -1
+	}
+	return 0
+}
+
+// DecideProbability 根据上涨概率和盈亏幅度决定是否交易
+// 参数：
+//   - pUp: 上涨概率，取值 [0, 1]
+//   - winReturn: 判断正确时的收益比例（如止盈距离）
+//   - lossReturn: 判断错误时的亏损比例（如止损距离），传正数
+//   - minEdge: 扣除成本后要求的最小期望收益
+//
+// 返回值：
+//   - int: 1 做多，-1 做空，0 不交易
+//   - float64: 所选方向扣除成本后的期望收益，不交易时为两个方向中较大者
+//
+// 说明/注意事项：
+//
+//	做多期望 = pUp×win − (1−pUp)×loss − 成本；做空期望 = (1−pUp)×win − pUp×loss − 成本。
+func (c TradingCosts) DecideProbability(pUp, winReturn, lossReturn, minEdge float64) (int, float64) {
+	cost := c.RoundTrip()
+	longEV := pUp*winReturn - (1-pUp)*lossReturn - cost
+	shortEV := (1-pUp)*winReturn - pUp*lossReturn - cost
+	switch {
+	case longEV >= shortEV && longEV >= minEdge:
+		return 1, longEV
+	case shortEV > longEV && shortEV >= minEdge:
+		return -1, shortEV
+	}
+	return 0, max(longEV, shortEV)
+}
+
+// FilterSignals 对预期收益序列逐点应用成本过滤
+// 参数：
+//   - expectedReturns: 预期收益序列
+//   - minEdge: 扣除成本后要求的最小优势
+//
+// 返回值：
+//   - []int: 与输入等长的交易方向序列（1/-1/0）
+func (c TradingCosts) FilterSignals(expectedReturns []float64, minEdge float64) []int {
+	out := make([]int, len(expectedReturns))
+	for i, r := range expectedReturns {
+		out[i] = c.Decide(r, minEdge)
+	}
+	return out
+}
+
+// BreakevenProbability 返回在给定盈亏比和成本下做多不亏损所需的最低上涨概率
+// 参数：
+//   - winReturn: 判断正确时的收益比例
+//   - lossReturn: 判断错误时的亏损比例，传正数
+//
+// 返回值：
+//   - float64: 盈亏平衡概率，盈亏幅度之和为 0 时返回 1
+func (c TradingCosts) BreakevenProbability(winReturn, lossReturn float64) float64 {
+	if winReturn+lossReturn <= 0 {
+		return 1
+	}
+	return (lossReturn + c.RoundTrip()) / (winReturn + lossReturn)
+}
+
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
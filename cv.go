@@ -0,0 +1,110 @@
+package ta
+
+import (
+	"fmt"
+)
+
+// CVFold 交叉验证的一折
+// 字段：
+//   - Train: 训练集样本索引（已清洗和禁运）
+//   - Test: 测试集样本索引
+type CVFold struct {
+	Train []int `json:"train"`
+	Test  []int `json:"test"`
+}
+
+// LabelEnds 生成固定持有期标签的结束位置
+// 参数：
+//   - n: 样本数量
+//   - horizon: 标签前瞻的 K 线数量
+//
+// 返回值：
+//   - []int: 第 i 个样本的标签覆盖 [i, i+horizon]，超出末尾时截断为 n-1
+func LabelEnds(n, horizon int) []int {
+	ends := make([]int, n)
+	for i := range ends {
+		ends[i] = i + horizon
+		if ends[i] > n-1 {
+			ends[i] = n - 1
+		}
+	}
+	return ends
+}
+
+// PurgedKFold 生成带清洗（purge）和禁运（embargo）的时间序列 K 折划分
+// 参数：
+//   - labelEnds: 每个样本标签的结束位置，可由 LabelEnds 生成，三重屏障标签可传入实际触碰位置
+//   - folds: 折数
+//   - embargo: 测试集之后额外剔除的样本数量
+//
+// 返回值：
+//   - []CVFold: 各折的训练集与测试集索引
+//   - error: 参数无效时返回错误
+//
+// 说明/注意事项：
+//
+//	测试集按时间顺序连续切分。设测试集覆盖的时间区间为 [a, t1]（t1 为测试样本标签的最远结束位置），
+//	训练集中标签区间 [i, labelEnds[i]] 与之重叠的样本会被清洗，
+//	t1 之后 embargo 个样本也会被剔除，以避免序列相关导致的信息泄漏。
+//
+// 示例：
+//
+//	folds, err := PurgedKFold(LabelEnds(len(klineData), 10), 5, 20)
+//	for _, fold := range folds {
+//	    // 使用 fold.Train 训练，fold.Test 评估
+//	}
+func PurgedKFold(labelEnds []int, folds, embargo int) ([]CVFold, error) {
+	n := len(labelEnds)
+	if folds < 2 {
+		return nil, fmt.Errorf("折数必须不小于2")
+	}
+	if n < folds {
+		return nil, fmt.Errorf("样本数量(%d)小于折数(%d)", n, folds)
+	}
+	if embargo < 0 {
+		return nil, fmt.Errorf("禁运数量不能为负数")
+	}
+	for i, end := range labelEnds {
+		if end < i {
+			return nil, fmt.Errorf("第%d个样本的标签结束位置早于样本本身", i+1)
+		}
+	}
+
+	result := make([]CVFold, folds)
+	for f := 0; f < folds; f++ {
+		start := f * n / folds
+		stop := (f + 1) * n / folds
+
+		testEnd := start
+		test := make([]int, 0, stop-start)
+		for i := start; i < stop; i++ {
+			test = append(test, i)
+			if labelEnds[i] > testEnd {
+				testEnd = labelEnds[i]
+			}
+		}
+		embargoEnd := testEnd + embargo
+
+		train := make([]int, 0, n-len(test))
+		for i := 0; i < n; i++ {
+			if i >= start && i < stop {
+				continue
+			}
+			if i < start && labelEnds[i] >= start {
+				continue
+			}
+			if i >= stop && i <= embargoEnd {
+				continue
+			}
+			train = append(train, i)
+		}
+		result[f] = CVFold{Train: train, Test: test}
+	}
+	return result, nil
+}
+
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
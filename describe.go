@@ -0,0 +1,355 @@
+package ta
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+)
+
+// IndicatorParam 指标参数的元数据
+// 字段：
+//   - Name: 参数名，与 GetPreset 返回的键一致，如 "period"、"std_dev"
+//   - Min: 最小值（含）
+//   - Max: 最大值（含），0 表示不限
+//   - Default: 常用默认值
+//   - Integer: 是否必须为整数
+type IndicatorParam struct {
+	Name    string  `json:"name"`
+	Min     float64 `json:"min"`
+	Max     float64 `json:"max"`
+	Default float64 `json:"default"`
+	Integer bool    `json:"integer"`
+}
+
+// IndicatorInfo 指标的机器可读说明
+// 字段：
+//   - Name: 指标名称（小写），与 WarmupLength、Snapshot 使用的名称一致
+//   - Title: 指标全称
+//   - Params: 参数列表，顺序与对应的计算函数一致
+//   - Outputs: 输出序列名称，与结构体中的字段对应
+//   - Source: 是否基于单一价格序列（可指定 "close"、"hlc3" 等数据源）
+//   - Warmup: 预热长度公式，实际数值见 WarmupLength
+type IndicatorInfo struct {
+	Name    string           `json:"name"`
+	Title   string           `json:"title"`
+	Params  []IndicatorParam `json:"params"`
+	Outputs []string         `json:"outputs"`
+	Source  bool             `json:"source"`
+	Warmup  string           `json:"warmup"`
+
+	check func(args []float64) error
+}
+
+// periodParam 返回最小值为 minimum 的整数周期参数
+func periodParam(name string, minimum, def float64) IndicatorParam {
+	return IndicatorParam{Name: name, Min: minimum, Default: def, Integer: true}
+}
+
+// indicatorInfos 指标名称到说明的映射
+var indicatorInfos = map[string]IndicatorInfo{
+	"sma": {
+		Title: "Simple Moving Average", Source: true, Warmup: "period-1",
+		Params: []IndicatorParam{periodParam("period", 1, 20)}, Outputs: []string{"values"},
+	},
+	"ema": {
+		Title: "Exponential Moving Average", Source: true, Warmup: "period-1",
+		Params: []IndicatorParam{periodParam("period", 1, 20)}, Outputs: []string{"values"},
+	},
+	"rma": {
+		Title: "Running Moving Average", Source: true, Warmup: "period-1",
+		Params: []IndicatorParam{periodParam("period", 1, 14)}, Outputs: []string{"values"},
+	},
+	"rsi": {
+		Title: "Relative Strength Index", Source: true, Warmup: "period",
+		Params: []IndicatorParam{periodParam("period", 1, 14)}, Outputs: []string{"values"},
+	},
+	"atr": {
+		Title: "Average True Range", Warmup: "period",
+		Params: []IndicatorParam{periodParam("period", 1, 14)}, Outputs: []string{"values", "true_range"},
+	},
+	"cci": {
+		Title: "Commodity Channel Index", Warmup: "period-1",
+		Params: []IndicatorParam{periodParam("period", 1, 20)}, Outputs: []string{"values"},
+	},
+	"wr": {
+		Title: "Williams %R", Warmup: "period-1",
+		Params: []IndicatorParam{periodParam("period", 1, 14)}, Outputs: []string{"values"},
+	},
+	"obv": {
+		Title: "On Balance Volume", Source: true, Warmup: "1",
+		Outputs: []string{"values"},
+	},
+	"adx": {
+		Title: "Average Directional Index", Warmup: "2*period",
+		Params: []IndicatorParam{periodParam("period", 1, 14)}, Outputs: []string{"adx", "plus_di", "minus_di"},
+	},
+	"aroon": {
+		Title: "Aroon", Warmup: "period",
+		Params: []IndicatorParam{periodParam("period", 1, 25)}, Outputs: []string{"up", "down", "oscillator"},
+	},
+	"boll": {
+		Title: "Bollinger Bands", Source: true, Warmup: "period-1",
+		Params: []IndicatorParam{
+			periodParam("period", 2, 20),
+			{Name: "std_dev", Min: 0, Default: 2},
+		},
+		Outputs: []string{"upper", "mid", "lower"},
+	},
+	"atrbands": {
+		Title: "ATR Bands (STARC)", Warmup: "max(period-1, atr_period)",
+		Params: []IndicatorParam{
+			periodParam("period", 1, 6),
+			periodParam("atr_period", 1, 15),
+			{Name: "multiplier", Min: 0, Default: 2},
+		},
+		Outputs: []string{"upper", "mid", "lower"},
+	},
+	"macd": {
+		Title: "Moving Average Convergence Divergence", Source: true, Warmup: "long_period+signal_period-2",
+		Params: []IndicatorParam{
+			periodParam("short_period", 1, 12),
+			periodParam("long_period", 2, 26),
+			periodParam("signal_period", 1, 9),
+		},
+		Outputs: []string{"macd", "dif", "dea"},
+		check: func(args []float64) error {
+			if args[0] >= args[1] {
+				return fmt.Errorf("short_period 必须小于 long_period")
+			}
+			return nil
+		},
+	},
+	"kdj": {
+		Title: "KDJ", Warmup: "rsv_period-1",
+		Params: []IndicatorParam{
+			periodParam("rsv_period", 1, 9),
+			periodParam("k_period", 1, 3),
+			periodParam("d_period", 1, 3),
+		},
+		Outputs: []string{"k", "d", "j"},
+	},
+	"supertrend": {
+		Title: "SuperTrend", Warmup: "period",
+		Params: []IndicatorParam{
+			periodParam("period", 1, 10),
+			{Name: "multiplier", Min: 0, Default: 3},
+		},
+		Outputs: []string{"upper", "lower", "is_up_trend"},
+	},
+	"stochrsi": {
+		Title: "Stochastic RSI", Source: true, Warmup: "rsi_period+stoch_period+k_period+d_period-3",
+		Params: []IndicatorParam{
+			periodParam("rsi_period", 1, 14),
+			periodParam("stoch_period", 1, 14),
+			periodParam("k_period", 1, 3),
+			periodParam("d_period", 1, 3),
+		},
+		Outputs: []string{"k", "d"},
+	},
+	"stoch": {
+		Title: "Stochastic Oscillator", Warmup: "k_period+slowing+d_period-3",
+		Params: []IndicatorParam{
+			periodParam("k_period", 1, 14),
+			periodParam("slowing", 1, 3),
+			periodParam("d_period", 1, 3),
+		},
+		Outputs: []string{"k", "d"},
+	},
+	"elderray": {
+		Title: "Elder Ray Index", Warmup: "period-1",
+		Params:  []IndicatorParam{periodParam("period", 1, 13)},
+		Outputs: []string{"bull_power", "bear_power"},
+	},
+	"t3": {
+		Title: "Tillson T3", Source: true, Warmup: "6*(period-1)",
+		Params: []IndicatorParam{
+			periodParam("period", 1, 5),
+			{Name: "vfact", Min: 0, Max: 1, Default: 0.7},
+		},
+		Outputs: []string{"values"},
+	},
+	"coppock": {
+		Title: "Coppock Curve", Source: true, Warmup: "long_period+wma_period-1",
+		Params: []IndicatorParam{
+			periodParam("long_period", 1, 14),
+			periodParam("short_period", 1, 11),
+			periodParam("wma_period", 1, 10),
+		},
+		Outputs: []string{"values"},
+		check: func(args []float64) error {
+			if args[1] > args[0] {
+				return fmt.Errorf("short_period 不能大于 long_period")
+			}
+			return nil
+		},
+	},
+	"momentum": {
+		Title: "Momentum", Source: true, Warmup: "period+smooth-1",
+		Params: []IndicatorParam{
+			periodParam("period", 1, 10),
+			periodParam("smooth", 0, 0),
+		},
+		Outputs: []string{"values"},
+	},
+	"roc": {
+		Title: "Rate of Change", Source: true, Warmup: "period",
+		Params: []IndicatorParam{periodParam("period", 1, 12)}, Outputs: []string{"values"},
+	},
+	"ulcer": {
+		Title: "Ulcer Index", Source: true, Warmup: "2*(period-1)",
+		Params: []IndicatorParam{periodParam("period", 1, 14)}, Outputs: []string{"values", "drawdown"},
+	},
+	"cmf": {
+		Title: "Chaikin Money Flow", Warmup: "period-1",
+		Params: []IndicatorParam{periodParam("period", 1, 20)}, Outputs: []string{"values"},
+	},
+	"linreg": {
+		Title: "Linear Regression", Source: true, Warmup: "period-1",
+		Params: []IndicatorParam{
+			periodParam("period", 3, 50),
+			{Name: "k", Min: 0, Default: 2},
+		},
+		Outputs: []string{"values", "slope", "r2", "std_err", "upper", "lower"},
+	},
+	"forceindex": {
+		Title: "Force Index", Warmup: "period",
+		Params:  []IndicatorParam{periodParam("period", 1, 13)},
+		Outputs: []string{"values"},
+	},
+	"candlestats": {
+		Title: "Candle Body/Wick Statistics", Warmup: "period-1",
+		Params: []IndicatorParam{periodParam("period", 1, 20)},
+		Outputs: []string{
+			"body", "upper_wick", "lower_wick", "close_position",
+			"avg_body", "avg_upper_wick", "avg_lower_wick", "avg_close_position",
+		},
+	},
+	"calmar": {
+		Title: "Rolling Calmar Ratio", Source: true, Warmup: "period",
+		Params: []IndicatorParam{
+			periodParam("period", 1, 252),
+			{Name: "periods_per_year", Min: 0, Default: 365},
+		},
+		Outputs: []string{"values", "return", "max_drawdown"},
+	},
+	"savitzkygolay": {
+		Title: "Savitzky-Golay Smoother", Source: true, Warmup: "window-1",
+		Params: []IndicatorParam{
+			periodParam("window", 2, 21),
+			{Name: "degree", Min: 0, Max: 4, Default: 2, Integer: true},
+		},
+		Outputs: []string{"values", "velocity", "acceleration"},
+		check:   checkLocalRegression,
+	},
+	"loess": {
+		Title: "LOESS Smoother", Source: true, Warmup: "window-1",
+		Params: []IndicatorParam{
+			periodParam("window", 2, 30),
+			{Name: "degree", Min: 0, Max: 4, Default: 2, Integer: true},
+		},
+		Outputs: []string{"values", "velocity", "acceleration"},
+		check:   checkLocalRegression,
+	},
+}
+
+// checkLocalRegression 校验局部多项式回归的窗口长度大于多项式次数
+func checkLocalRegression(args []float64) error {
+	if args[0] <= args[1] {
+		return fmt.Errorf("window 必须大于 degree")
+	}
+	return nil
+}
+
+// Describe 返回指标的参数、输出和预热说明
+// 参数：
+//   - indicator: 指标名称，如 "rsi"、"macd"，不区分大小写
+//
+// 返回值：
+//   - IndicatorInfo: 指标说明，Params 与 Outputs 为副本，可自由修改
+//   - error: 指标不存在时返回错误
+//
+// 说明/注意事项：
+//
+//	可用于命令行帮助、服务接口的参数说明，以及在计算之前用 Validate 拒绝无效参数。
+//
+// 示例：
+//
+//	info, err := Describe("boll")
+//	if err := info.Validate(20, 2); err != nil {
+//	    // 处理无效参数
+//	}
+func Describe(indicator string) (IndicatorInfo, error) {
+	name := strings.ToLower(indicator)
+	info, ok := indicatorInfos[name]
+	if !ok {
+		return IndicatorInfo{}, fmt.Errorf("未知指标: %s", indicator)
+	}
+	info.Name = name
+	info.Params = append([]IndicatorParam(nil), info.Params...)
+	info.Outputs = append([]string(nil), info.Outputs...)
+	return info, nil
+}
+
+// Indicators 返回所有可描述的指标名称（按字母排序）
+func Indicators() []string {
+	names := make([]string, 0, len(indicatorInfos))
+	for name := range indicatorInfos {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Validate 校验参数的数量、类型和取值范围
+// 参数：
+//   - args: 参数值，顺序与 Params 一致
+//
+// 返回值：
+//   - error: 第一个不合法的参数，全部合法时返回 nil
+func (info IndicatorInfo) Validate(args ...float64) error {
+	if len(args) != len(info.Params) {
+		return fmt.Errorf("指标 %s 需要%d个参数，实际为%d个", info.Name, len(info.Params), len(args))
+	}
+	for i, p := range info.Params {
+		if err := p.Validate(args[i]); err != nil {
+			return fmt.Errorf("指标 %s: %v", info.Name, err)
+		}
+	}
+	if info.check != nil {
+		if err := info.check(args); err != nil {
+			return fmt.Errorf("指标 %s: %v", info.Name, err)
+		}
+	}
+	return nil
+}
+
+// WarmupLength 按参数返回预热 K 线数量，含义同 WarmupLength 函数
+func (info IndicatorInfo) WarmupLength(args ...float64) int {
+	periods := make([]int, len(args))
+	for i, a := range args {
+		periods[i] = int(a)
+	}
+	return WarmupLength(info.Name, periods...)
+}
+
+// Validate 校验单个参数值
+func (p IndicatorParam) Validate(v float64) error {
+	switch {
+	case math.IsNaN(v) || math.IsInf(v, 0):
+		return fmt.Errorf("参数 %s 无效: %v", p.Name, v)
+	case p.Integer && v != math.Trunc(v):
+		return fmt.Errorf("参数 %s 必须是整数，实际为 %v", p.Name, v)
+	case v < p.Min:
+		return fmt.Errorf("参数 %s 必须不小于 %v，实际为 %v", p.Name, p.Min, v)
+	case p.Max != 0 && v > p.Max:
+		return fmt.Errorf("参数 %s 必须不大于 %v，实际为 %v", p.Name, p.Max, v)
+	}
+	return nil
+}
+
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
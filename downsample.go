@@ -0,0 +1,191 @@
+package ta
+
+import (
+	"fmt"
+	"math"
+)
+
+// LTTB 使用最大三角形三桶算法（Largest-Triangle-Three-Buckets）对序列降采样
+// 参数：
+//   - values: 输入序列，以下标作为横坐标
+//   - threshold: 降采样后的点数，至少为 3
+//
+// 返回值：
+//   - []int: 保留点的下标，升序，包含首尾两点；输入点数不超过 threshold 时返回全部下标
+//   - error: threshold 无效时返回错误
+//
+// 说明/注意事项：
+//
+//	适合指标曲线的图表导出，在限制点数的同时保留峰谷形状。
+//	同一组下标可用于 SelectIndices 选取其他对齐的序列，使多条曲线的横坐标一致。
+//
+// 示例：
+//
+//	idx, err := LTTB(rsi.Values, 2000)
+//	points := SelectIndices(rsi.Values, idx)
+func LTTB(values []float64, threshold int) ([]int, error) {
+	if threshold < 3 {
+		return nil, fmt.Errorf("降采样点数必须不小于3")
+	}
+	length := len(values)
+	if length <= threshold {
+		out := make([]int, length)
+		for i := range out {
+			out[i] = i
+		}
+		return out, nil
+	}
+
+	out := make([]int, 0, threshold)
+	out = append(out, 0)
+	bucketSize := float64(length-2) / float64(threshold-2)
+	a := 0
+	for b := 0; b < threshold-2; b++ {
+		start := int(float64(b)*bucketSize) + 1
+		end := int(float64(b+1)*bucketSize) + 1
+
+		// 下一个桶的平均点作为三角形的第三个顶点
+		nextStart, nextEnd := end, int(float64(b+2)*bucketSize)+1
+		if nextEnd > length {
+			nextEnd = length
+		}
+		var avgX, avgY float64
+		for i := nextStart; i < nextEnd; i++ {
+			avgX += float64(i)
+			avgY += values[i]
+		}
+		count := float64(nextEnd - nextStart)
+		avgX /= count
+		avgY /= count
+
+		best, bestArea := start, -1.0
+		for i := start; i < end; i++ {
+			area := math.Abs((float64(a)-avgX)*(values[i]-values[a]) - (float64(a)-float64(i))*(avgY-values[a]))
+			if area > bestArea {
+				best, bestArea = i, area
+			}
+		}
+		out = append(out, best)
+		a = best
+	}
+	return append(out, length-1), nil
+}
+
+// MinMaxDownsample 将序列等分为若干桶，每桶保留最小值和最大值所在的点
+// 参数：
+//   - values: 输入序列
+//   - buckets: 桶数量，结果最多 2×buckets 个点
+//
+// 返回值：
+//   - []int: 保留点的下标，升序；输入点数不超过 2×buckets 时返回全部下标
+//   - error: 桶数量无效时返回错误
+//
+// 说明/注意事项：
+//
+//	比 LTTB 更快，且保证每个区间的极值可见，适合成交量、波动率等尖峰明显的序列。
+func MinMaxDownsample(values []float64, buckets int) ([]int, error) {
+	if buckets <= 0 {
+		return nil, fmt.Errorf("桶数量必须大于0")
+	}
+	length := len(values)
+	if length <= 2*buckets {
+		out := make([]int, length)
+		for i := range out {
+			out[i] = i
+		}
+		return out, nil
+	}
+
+	out := make([]int, 0, 2*buckets)
+	for b := 0; b < buckets; b++ {
+		start, end := b*length/buckets, (b+1)*length/buckets
+		lowest, highest := start, start
+		for i := start + 1; i < end; i++ {
+			if values[i] < values[lowest] {
+				lowest = i
+			}
+			if values[i] > values[highest] {
+				highest = i
+			}
+		}
+		switch {
+		case lowest == highest:
+			out = append(out, lowest)
+		case lowest < highest:
+			out = append(out, lowest, highest)
+		default:
+			out = append(out, highest, lowest)
+		}
+	}
+	return out, nil
+}
+
+// SelectIndices 按下标选取序列中的点
+// 参数：
+//   - series: 输入序列
+//   - indices: 下标列表，通常来自 LTTB 或 MinMaxDownsample
+//
+// 返回值：
+//   - []float64: 选取的点，越界的下标被忽略
+func SelectIndices(series []float64, indices []int) []float64 {
+	out := make([]float64, 0, len(indices))
+	for _, i := range indices {
+		if i >= 0 && i < len(series) {
+			out = append(out, series[i])
+		}
+	}
+	return out
+}
+
+// Downsample 将 K 线按数量等分合并，使结果不超过 maxBars 根
+// 参数：
+//   - maxBars: 结果的最大 K 线数量
+//
+// 返回值：
+//   - KlineDatas: 合并后的 K 线，开盘价取桶内第一根，收盘价取最后一根，最高/最低取极值，成交量求和
+//   - error: maxBars 无效或没有数据时返回错误
+//
+// 说明/注意事项：
+//
+//	与 Resample 按时间分桶不同，这里按数量分桶，用于长历史的图表导出；
+//	每根合并后的 K 线保留桶内的真实最高价和最低价，不会丢失影线。
+func (k *KlineDatas) Downsample(maxBars int) (KlineDatas, error) {
+	if maxBars <= 0 {
+		return nil, fmt.Errorf("K线数量必须大于0")
+	}
+	length := len(*k)
+	if length == 0 {
+		return nil, fmt.Errorf("没有K线数据")
+	}
+	if length <= maxBars {
+		out := make(KlineDatas, length)
+		copy(out, *k)
+		return out, nil
+	}
+
+	out := make(KlineDatas, 0, maxBars)
+	for b := 0; b < maxBars; b++ {
+		start, end := b*length/maxBars, (b+1)*length/maxBars
+		first := (*k)[start]
+		bar := &KlineData{
+			StartTime: first.StartTime,
+			Open:      first.Open,
+			High:      first.High,
+			Low:       first.Low,
+			Close:     (*k)[end-1].Close,
+		}
+		for _, kline := range (*k)[start:end] {
+			bar.High = math.Max(bar.High, kline.High)
+			bar.Low = math.Min(bar.Low, kline.Low)
+			bar.Volume += kline.Volume
+		}
+		out = append(out, bar)
+	}
+	return out, nil
+}
+
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
@@ -0,0 +1,229 @@
+package ta
+
+import (
+	"fmt"
+	"math"
+	"sort"
+)
+
+// DriftResult 单个特征的分布漂移检测结果
+// 字段：
+//   - Column: 特征列名
+//   - PSI: 群体稳定性指数
+//   - KS: 两样本 KS 统计量
+//   - PValue: KS 检验的近似 p 值
+//   - Drifted: 是否判定为漂移
+type DriftResult struct {
+	Column  string  `json:"column"`
+	PSI     float64 `json:"psi"`
+	KS      float64 `json:"ks"`
+	PValue  float64 `json:"p_value"`
+	Drifted bool    `json:"drifted"`
+}
+
+// DriftMonitor 线上特征分布漂移监控
+// 说明：
+//
+//	保存训练时的特征分布，将线上特征与之比较，PSI 或 KS 检验任一超过阈值即判定为漂移。
+//
+// 字段：
+//   - Names: 特征列名
+//   - Bins: PSI 分箱数量，默认 10
+//   - PSIThreshold: PSI 阈值，默认 0.25（0.1-0.25 为轻微漂移）
+//   - Alpha: KS 检验的显著性水平，默认 0.01
+//   - OnDrift: 检测到漂移时的回调，可用于触发重新训练
+type DriftMonitor struct {
+	Names        []string
+	Bins         int
+	PSIThreshold float64
+	Alpha        float64
+	OnDrift      func(results []DriftResult)
+
+	reference [][]float64
+}
+
+// NewDriftMonitor 以训练特征矩阵创建漂移监控
+// 参数：
+//   - reference: 训练特征矩阵，reference[i][j] 为第 i 个样本的第 j 个特征
+//   - names: 特征列名
+//
+// 返回值：
+//   - *DriftMonitor: 使用默认阈值的监控实例
+//   - error: 数据为空或维度不一致时返回错误
+func NewDriftMonitor(reference [][]float64, names []string) (*DriftMonitor, error) {
+	columns, err := featureColumns(reference, len(names))
+	if err != nil {
+		return nil, err
+	}
+	return &DriftMonitor{
+		Names:        names,
+		Bins:         10,
+		PSIThreshold: 0.25,
+		Alpha:        0.01,
+		reference:    columns,
+	}, nil
+}
+
+// Check 将线上特征矩阵与训练分布比较
+// 参数：
+//   - live: 线上特征矩阵，列顺序与训练时一致
+//
+// 返回值：
+//   - []DriftResult: 每个特征的检测结果
+//   - error: 维度不一致或数据不足时返回错误
+//
+// 说明/注意事项：
+//
+//	存在漂移的特征时会调用 OnDrift 回调。
+func (m *DriftMonitor) Check(live [][]float64) ([]DriftResult, error) {
+	columns, err := featureColumns(live, len(m.Names))
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]DriftResult, len(m.Names))
+	drifted := false
+	for j, name := range m.Names {
+		psi, err := PSI(m.reference[j], columns[j], m.Bins)
+		if err != nil {
+			return nil, fmt.Errorf("特征 %s: %v", name, err)
+		}
+		ks, p, err := KSTest(m.reference[j], columns[j])
+		if err != nil {
+			return nil, fmt.Errorf("特征 %s: %v", name, err)
+		}
+		results[j] = DriftResult{
+			Column:  name,
+			PSI:     psi,
+			KS:      ks,
+			PValue:  p,
+			Drifted: psi > m.PSIThreshold || p < m.Alpha,
+		}
+		drifted = drifted || results[j].Drifted
+	}
+	if drifted && m.OnDrift != nil {
+		m.OnDrift(results)
+	}
+	return results, nil
+}
+
+// featureColumns 将行优先的特征矩阵转换为列
+func featureColumns(rows [][]float64, width int) ([][]float64, error) {
+	if len(rows) == 0 {
+		return nil, fmt.Errorf("计算数据不足")
+	}
+	columns := preallocateSlices(len(rows), width)
+	for i, row := range rows {
+		if len(row) < width {
+			return nil, fmt.Errorf("第%d行特征数量不足%d个", i+1, width)
+		}
+		for j := 0; j < width; j++ {
+			columns[j][i] = row[j]
+		}
+	}
+	return columns, nil
+}
+
+// PSI 计算群体稳定性指数（Population Stability Index）
+// 参数：
+//   - expected: 基准分布样本（如训练集）
+//   - actual: 待比较分布样本（如线上数据）
+//   - bins: 分箱数量，按基准样本的分位数切分
+//
+// 返回值：
+//   - float64: PSI 值，< 0.1 稳定，0.1-0.25 轻微漂移，> 0.25 显著漂移
+//   - error: 数据为空或分箱数量无效时返回错误
+func PSI(expected, actual []float64, bins int) (float64, error) {
+	if len(expected) == 0 || len(actual) == 0 {
+		return 0, fmt.Errorf("计算数据不足")
+	}
+	if bins < 2 {
+		return 0, fmt.Errorf("分箱数量必须不小于2")
+	}
+
+	sorted := append([]float64(nil), expected...)
+	sort.Float64s(sorted)
+	edges := make([]float64, bins-1)
+	for b := 1; b < bins; b++ {
+		edges[b-1] = sorted[b*len(sorted)/bins]
+	}
+
+	count := func(data []float64) []float64 {
+		hist := make([]float64, bins)
+		for _, v := range data {
+			hist[sort.SearchFloat64s(edges, v)]++
+		}
+		for b := range hist {
+			// 避免空箱导致对数发散
+			hist[b] = math.Max(hist[b]/float64(len(data)), 1e-4)
+		}
+		return hist
+	}
+
+	e, a := count(expected), count(actual)
+	var psi float64
+	for b := 0; b < bins; b++ {
+		psi += (a[b] - e[b]) * math.Log(a[b]/e[b])
+	}
+	return psi, nil
+}
+
+// KSTest 两样本 Kolmogorov-Smirnov 检验
+// 参数：
+//   - a, b: 两组样本
+//
+// 返回值：
+//   - float64: KS 统计量 D（两个经验分布函数的最大差距）
+//   - float64: 渐近 p 值
+//   - error: 数据为空时返回错误
+func KSTest(a, b []float64) (float64, float64, error) {
+	if len(a) == 0 || len(b) == 0 {
+		return 0, 0, fmt.Errorf("计算数据不足")
+	}
+	x := append([]float64(nil), a...)
+	y := append([]float64(nil), b...)
+	sort.Float64s(x)
+	sort.Float64s(y)
+
+	n, m := float64(len(x)), float64(len(y))
+	var i, j int
+	var d float64
+	for i < len(x) && j < len(y) {
+		v := math.Min(x[i], y[j])
+		for i < len(x) && x[i] <= v {
+			i++
+		}
+		for j < len(y) && y[j] <= v {
+			j++
+		}
+		d = math.Max(d, math.Abs(float64(i)/n-float64(j)/m))
+	}
+
+	en := math.Sqrt(n * m / (n + m))
+	lambda := (en + 0.12 + 0.11/en) * d
+	return d, ksProbability(lambda), nil
+}
+
+// ksProbability Kolmogorov 分布的上尾概率
+func ksProbability(lambda float64) float64 {
+	if lambda < 1e-3 {
+		return 1
+	}
+	var sum float64
+	sign := 1.0
+	for k := 1; k <= 100; k++ {
+		term := sign * 2 * math.Exp(-2*float64(k*k)*lambda*lambda)
+		sum += term
+		if math.Abs(term) < 1e-10 {
+			break
+		}
+		sign = -sign
+	}
+	return math.Max(0, math.Min(1, sum))
+}
+
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
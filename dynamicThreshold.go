@@ -0,0 +1,123 @@
+package ta
+
+import (
+	"fmt"
+	"math"
+	"sort"
+)
+
+// TaDynamicThresholds 振荡指标的动态超买/超卖阈值
+// 字段：
+//   - Upper: 超买阈值序列，即此前 Lookback 个值的 UpperPct 百分位，历史不足的位置为 0
+//   - Lower: 超卖阈值序列，即此前 Lookback 个值的 LowerPct 百分位，历史不足的位置为 0
+//   - Lookback: 计算百分位的历史长度
+//   - UpperPct: 超买百分位，取值 0 到 100，如 90
+//   - LowerPct: 超卖百分位，取值 0 到 100，如 10
+type TaDynamicThresholds struct {
+	Upper    []float64 `json:"upper"`
+	Lower    []float64 `json:"lower"`
+	Lookback int       `json:"lookback"`
+	UpperPct float64   `json:"upper_pct"`
+	LowerPct float64   `json:"lower_pct"`
+}
+
+// CalculateDynamicThresholds 以振荡指标自身历史的滚动百分位作为动态超买/超卖阈值
+// 参数：
+//   - values: 振荡指标序列，如 RSI
+//   - start: 第一个有效值的下标，通常为指标的 WarmupLength，之前的预热值不参与统计
+//   - lookback: 计算百分位的历史长度，如 500
+//   - upperPct: 超买百分位，取值 0 到 100，如 90
+//   - lowerPct: 超卖百分位，取值 0 到 100，如 10
+//
+// 返回值：
+//   - *TaDynamicThresholds: 与输入等长的阈值序列
+//   - error: 参数无效或数据不足时返回错误
+//
+// 说明/注意事项：
+//
+//	第 i 个位置的阈值只使用 values[i-lookback, i) 计算，不含当前值，可直接与 values[i] 比较而不引入未来数据。
+//	不同交易对的振荡指标分布差异较大，动态阈值可替代固定的 70/30。
+//	NaN 与 ±Inf 不参与统计，窗口内没有有限值时该位置的阈值为 NaN。
+//
+// 示例：
+//
+//	rsi, _ := klineData.RSI(14, "close")
+//	th, err := CalculateDynamicThresholds(rsi.Values, WarmupLength("rsi", 14), 500, 90, 10)
+//	if th.IsOverbought(rsi.Value()) {
+//	    // 高于自身历史 90% 的读数
+//	}
+func CalculateDynamicThresholds(values []float64, start, lookback int, upperPct, lowerPct float64) (*TaDynamicThresholds, error) {
+	if lookback <= 0 {
+		return nil, fmt.Errorf("历史长度必须大于0")
+	}
+	if start < 0 {
+		return nil, fmt.Errorf("起始下标不能为负数")
+	}
+	if upperPct < 0 || upperPct > 100 || lowerPct < 0 || lowerPct > 100 || lowerPct > upperPct {
+		return nil, fmt.Errorf("百分位必须在0到100之间且下限不大于上限")
+	}
+	length := len(values)
+	if length <= start+lookback {
+		return nil, fmt.Errorf("计算数据不足")
+	}
+
+	slices := preallocateSlices(length, 2)
+	upper, lower := slices[0], slices[1]
+
+	window := make([]float64, 0, lookback+1)
+	for i := start; i < length; i++ {
+		if i-start >= lookback {
+			upper[i] = percentileSorted(window, upperPct/100)
+			lower[i] = percentileSorted(window, lowerPct/100)
+			if old := values[i-lookback]; !math.IsNaN(old) && !math.IsInf(old, 0) {
+				j := sort.SearchFloat64s(window, old)
+				window = append(window[:j], window[j+1:]...)
+			}
+		}
+		v := values[i]
+		if math.IsNaN(v) || math.IsInf(v, 0) {
+			continue
+		}
+		j := sort.SearchFloat64s(window, v)
+		window = append(window, 0)
+		copy(window[j+1:], window[j:])
+		window[j] = v
+	}
+
+	return &TaDynamicThresholds{
+		Upper:    upper,
+		Lower:    lower,
+		Lookback: lookback,
+		UpperPct: upperPct,
+		LowerPct: lowerPct,
+	}, nil
+}
+
+// DynamicThresholds 以 RSI 自身历史的滚动百分位计算动态超买/超卖阈值，参数含义同 CalculateDynamicThresholds
+func (t *TaRSI) DynamicThresholds(lookback int, upperPct, lowerPct float64) (*TaDynamicThresholds, error) {
+	return CalculateDynamicThresholds(t.Values, t.Period, lookback, upperPct, lowerPct)
+}
+
+// Value 返回最新的超买和超卖阈值
+func (t *TaDynamicThresholds) Value() (upper, lower float64) {
+	lastIndex := len(t.Upper) - 1
+	return t.Upper[lastIndex], t.Lower[lastIndex]
+}
+
+// IsOverbought 判断 value 是否高于最新的超买阈值
+func (t *TaDynamicThresholds) IsOverbought(value float64) bool {
+	upper, _ := t.Value()
+	return value > upper
+}
+
+// IsOversold 判断 value 是否低于最新的超卖阈值
+func (t *TaDynamicThresholds) IsOversold(value float64) bool {
+	_, lower := t.Value()
+	return value < lower
+}
+
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
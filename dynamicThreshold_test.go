@@ -0,0 +1,57 @@
+package ta
+
+import (
+	"math"
+	"testing"
+)
+
+func TestCalculateDynamicThresholds(t *testing.T) {
+	values := []float64{1, 2, 3, 4, 5, 6}
+	th, err := CalculateDynamicThresholds(values, 0, 3, 100, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 第 i 个位置只使用前 3 个值，最大值与最小值即 100 与 0 百分位
+	wantUpper := []float64{0, 0, 0, 3, 4, 5}
+	wantLower := []float64{0, 0, 0, 1, 2, 3}
+	for i := range values {
+		if th.Upper[i] != wantUpper[i] || th.Lower[i] != wantLower[i] {
+			t.Errorf("位置 %d = (%v, %v), want (%v, %v)", i, th.Upper[i], th.Lower[i], wantUpper[i], wantLower[i])
+		}
+	}
+}
+
+func TestCalculateDynamicThresholdsNonFinite(t *testing.T) {
+	values := []float64{1, 2, math.NaN(), 3, math.Inf(1), 4, 5, 6}
+	th, err := CalculateDynamicThresholds(values, 0, 3, 100, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		index        int
+		upper, lower float64
+	}{
+		{3, 2, 1}, // 窗口 [1, 2, NaN]
+		{4, 3, 2}, // 窗口 [2, NaN, 3]
+		{5, 3, 3}, // 窗口 [NaN, 3, +Inf]
+		{6, 4, 3}, // 窗口 [3, +Inf, 4]
+		{7, 5, 4}, // 窗口 [+Inf, 4, 5]
+	}
+	for _, tt := range tests {
+		if th.Upper[tt.index] != tt.upper || th.Lower[tt.index] != tt.lower {
+			t.Errorf("位置 %d = (%v, %v), want (%v, %v)", tt.index, th.Upper[tt.index], th.Lower[tt.index], tt.upper, tt.lower)
+		}
+	}
+}
+
+func TestCalculateDynamicThresholdsAllNaN(t *testing.T) {
+	nan := math.NaN()
+	th, err := CalculateDynamicThresholds([]float64{nan, nan, nan, 1}, 0, 3, 90, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if upper, lower := th.Value(); !math.IsNaN(upper) || !math.IsNaN(lower) {
+		t.Errorf("Value() = (%v, %v), want NaN", upper, lower)
+	}
+}
@@ -0,0 +1,248 @@
+package ta
+
+import (
+	"fmt"
+	"math"
+)
+
+// TaFilter Ehlers 数字滤波器的计算结果
+// 说明：
+//
+//	包含超级平滑器（SuperSmoother）、巴特沃斯（Butterworth）低通滤波，
+//	以及高通、带通滤波器。相比多次叠加 EMA，这些滤波器在相同平滑度下滞后更小。
+//
+// 字段：
+//   - Values: 滤波后的序列
+//   - Period: 截止周期
+type TaFilter struct {
+	Values []float64 `json:"values"`
+	Period int       `json:"period"`
+}
+
+func checkFilterInput(prices []float64, period, minPeriod int) error {
+	if period < minPeriod {
+		return fmt.Errorf("周期必须不小于%d", minPeriod)
+	}
+	if len(prices) < 3 {
+		return fmt.Errorf("计算数据不足")
+	}
+	return nil
+}
+
+// CalculateSuperSmoother 计算 Ehlers 二阶超级平滑器
+// 参数：
+//   - prices: 价格序列
+//   - period: 截止周期，必须不小于2
+//
+// 返回值：
+//   - *TaFilter: 计算结果
+//   - error: 参数无效或数据不足时返回错误
+//
+// 说明/注意事项：
+//
+//	前两个值直接取价格作为初始状态。
+//
+// 示例：
+//
+//	ss, err := CalculateSuperSmoother(closes, 10)
+func CalculateSuperSmoother(prices []float64, period int) (*TaFilter, error) {
+	if err := checkFilterInput(prices, period, 2); err != nil {
+		return nil, err
+	}
+
+	length := len(prices)
+	filt := make([]float64, length)
+
+	a1 := math.Exp(-math.Sqrt2 * math.Pi / float64(period))
+	c2 := 2 * a1 * math.Cos(math.Sqrt2*math.Pi/float64(period))
+	c3 := -a1 * a1
+	c1 := 1 - c2 - c3
+
+	filt[0], filt[1] = prices[0], prices[1]
+	for i := 2; i < length; i++ {
+		filt[i] = c1*(prices[i]+prices[i-1])/2 + c2*filt[i-1] + c3*filt[i-2]
+	}
+
+	return &TaFilter{Values: filt, Period: period}, nil
+}
+
+// CalculateSuperSmoother3 计算 Ehlers 三阶超级平滑器
+// 参数：
+//   - prices: 价格序列
+//   - period: 截止周期，必须不小于2
+//
+// 返回值：
+//   - *TaFilter: 计算结果
+//   - error: 参数无效或数据不足时返回错误
+//
+// 说明/注意事项：
+//
+//	三阶滤波对高频噪声的衰减更强，但滞后略大于二阶。
+func CalculateSuperSmoother3(prices []float64, period int) (*TaFilter, error) {
+	if err := checkFilterInput(prices, period, 2); err != nil {
+		return nil, err
+	}
+
+	length := len(prices)
+	filt := make([]float64, length)
+
+	a1 := math.Exp(-math.Pi / float64(period))
+	b1 := 2 * a1 * math.Cos(1.738*math.Pi/float64(period))
+	c1 := a1 * a1
+	coef2 := b1 + c1
+	coef3 := -(c1 + b1*c1)
+	coef4 := c1 * c1
+	coef1 := 1 - coef2 - coef3 - coef4
+
+	filt[0], filt[1], filt[2] = prices[0], prices[1], prices[2]
+	for i := 3; i < length; i++ {
+		filt[i] = coef1*prices[i] + coef2*filt[i-1] + coef3*filt[i-2] + coef4*filt[i-3]
+	}
+
+	return &TaFilter{Values: filt, Period: period}, nil
+}
+
+// CalculateButterworth 计算二阶巴特沃斯低通滤波
+// 参数：
+//   - prices: 价格序列
+//   - period: 截止周期，必须不小于2
+//
+// 返回值：
+//   - *TaFilter: 计算结果
+//   - error: 参数无效或数据不足时返回错误
+func CalculateButterworth(prices []float64, period int) (*TaFilter, error) {
+	if err := checkFilterInput(prices, period, 2); err != nil {
+		return nil, err
+	}
+
+	length := len(prices)
+	filt := make([]float64, length)
+
+	a1 := math.Exp(-math.Sqrt2 * math.Pi / float64(period))
+	b1 := 2 * a1 * math.Cos(math.Sqrt2*math.Pi/float64(period))
+	coef2 := b1
+	coef3 := -a1 * a1
+	coef1 := (1 - b1 + a1*a1) / 4
+
+	filt[0], filt[1] = prices[0], prices[1]
+	for i := 2; i < length; i++ {
+		filt[i] = coef1*(prices[i]+2*prices[i-1]+prices[i-2]) + coef2*filt[i-1] + coef3*filt[i-2]
+	}
+
+	return &TaFilter{Values: filt, Period: period}, nil
+}
+
+// CalculateHighPass 计算 Ehlers 二阶高通滤波
+// 参数：
+//   - prices: 价格序列
+//   - period: 截止周期，周期长于该值的成分（趋势）被滤除
+//
+// 返回值：
+//   - *TaFilter: 计算结果，围绕 0 波动
+//   - error: 参数无效或数据不足时返回错误
+func CalculateHighPass(prices []float64, period int) (*TaFilter, error) {
+	if err := checkFilterInput(prices, period, 2); err != nil {
+		return nil, err
+	}
+
+	length := len(prices)
+	hp := make([]float64, length)
+
+	w := 0.707 * 2 * math.Pi / float64(period)
+	alpha := (math.Cos(w) + math.Sin(w) - 1) / math.Cos(w)
+	c1 := (1 - alpha/2) * (1 - alpha/2)
+	c2 := 2 * (1 - alpha)
+	c3 := -(1 - alpha) * (1 - alpha)
+
+	for i := 2; i < length; i++ {
+		hp[i] = c1*(prices[i]-2*prices[i-1]+prices[i-2]) + c2*hp[i-1] + c3*hp[i-2]
+	}
+
+	return &TaFilter{Values: hp, Period: period}, nil
+}
+
+// CalculateBandPass 计算 Ehlers 带通滤波
+// 参数：
+//   - prices: 价格序列
+//   - period: 中心周期
+//   - bandwidth: 相对带宽，如 0.3，须小于 period/8
+//
+// 返回值：
+//   - *TaFilter: 计算结果，围绕 0 波动，突出接近中心周期的循环成分
+//   - error: 参数无效或数据不足时返回错误
+//
+// 说明/注意事项：
+//
+//	系数 gamma = 1/cos(4π·bandwidth/period)，角度达到 π/2 时 gamma 为无穷大或负数，
+//	alpha 随之为 NaN 或大于 1，滤波发散，因此短周期下带宽上限为 period/8（如周期 4 时须小于 0.5）。
+func CalculateBandPass(prices []float64, period int, bandwidth float64) (*TaFilter, error) {
+	if err := checkFilterInput(prices, period, 3); err != nil {
+		return nil, err
+	}
+	if bandwidth <= 0 || bandwidth >= 1 {
+		return nil, fmt.Errorf("带宽必须在(0, 1)之间")
+	}
+	if bandwidth*8 >= float64(period) {
+		return nil, fmt.Errorf("带宽%v过大，周期%d下须小于%v", bandwidth, period, float64(period)/8)
+	}
+
+	length := len(prices)
+	bp := make([]float64, length)
+
+	beta := math.Cos(2 * math.Pi / float64(period))
+	gamma := 1 / math.Cos(4*math.Pi*bandwidth/float64(period))
+	alpha := gamma - math.Sqrt(gamma*gamma-1)
+
+	for i := 2; i < length; i++ {
+		bp[i] = 0.5*(1-alpha)*(prices[i]-prices[i-2]) + beta*(1+alpha)*bp[i-1] - alpha*bp[i-2]
+	}
+
+	return &TaFilter{Values: bp, Period: period}, nil
+}
+
+// SuperSmoother 从 KlineDatas 中提取数据计算二阶超级平滑器
+func (k *KlineDatas) SuperSmoother(period int, source string) (*TaFilter, error) {
+	prices, err := k.ExtractSlice(source)
+	if err != nil {
+		return nil, err
+	}
+	return CalculateSuperSmoother(prices, period)
+}
+
+// Butterworth 从 KlineDatas 中提取数据计算二阶巴特沃斯低通滤波
+func (k *KlineDatas) Butterworth(period int, source string) (*TaFilter, error) {
+	prices, err := k.ExtractSlice(source)
+	if err != nil {
+		return nil, err
+	}
+	return CalculateButterworth(prices, period)
+}
+
+// HighPass 从 KlineDatas 中提取数据计算二阶高通滤波
+func (k *KlineDatas) HighPass(period int, source string) (*TaFilter, error) {
+	prices, err := k.ExtractSlice(source)
+	if err != nil {
+		return nil, err
+	}
+	return CalculateHighPass(prices, period)
+}
+
+// BandPass 从 KlineDatas 中提取数据计算带通滤波
+func (k *KlineDatas) BandPass(period int, bandwidth float64, source string) (*TaFilter, error) {
+	prices, err := k.ExtractSlice(source)
+	if err != nil {
+		return nil, err
+	}
+	return CalculateBandPass(prices, period, bandwidth)
+}
+
+// Value 获取最新的滤波值
+func (t *TaFilter) Value() float64 {
+	return t.Values[len(t.Values)-1]
+}
+
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
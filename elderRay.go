@@ -0,0 +1,144 @@
+package ta
+
+import (
+	"fmt"
+)
+
+// TaElderRay 艾达透视指标（Elder Ray Index）的计算结果
+// 字段：
+//   - BullPower: 多头力量，最高价 − EMA，前 Period-1 个位置为 0
+//   - BearPower: 空头力量，最低价 − EMA，前 Period-1 个位置为 0
+//   - EMA: 收盘价的 EMA，用于判断趋势方向
+//   - Period: EMA 周期
+type TaElderRay struct {
+	BullPower []float64 `json:"bull_power"`
+	BearPower []float64 `json:"bear_power"`
+	EMA       []float64 `json:"ema"`
+	Period    int       `json:"period"`
+}
+
+// CalculateElderRay 计算艾达透视指标
+// 参数：
+//   - high: 最高价数组
+//   - low: 最低价数组
+//   - close: 收盘价数组
+//   - period: EMA 周期，Elder 原文使用 13
+//
+// 返回值：
+//   - *TaElderRay: 多头力量、空头力量与 EMA
+//   - error: 参数无效或数据不足时返回错误
+//
+// 说明/注意事项：
+//
+//	多头力量衡量买方能把价格推到均值之上多远，空头力量衡量卖方能把价格压到均值之下多远。
+//	经典用法是先用 EMA 方向确定趋势，再在趋势方向上等待对手方力量减弱时入场，见 IsBuySignal/IsSellSignal。
+//
+// 示例：
+//
+//	elder, err := CalculateElderRay(high, low, close, 13)
+//	if err != nil {
+//	    // 处理错误
+//	}
+//	if elder.IsBuySignal() {
+//	    // 上升趋势中空头力量为负但在减弱
+//	}
+func CalculateElderRay(high, low, close []float64, period int) (*TaElderRay, error) {
+	if period <= 0 {
+		return nil, fmt.Errorf("周期必须大于0")
+	}
+	length := len(close)
+	if len(high) != length || len(low) != length {
+		return nil, fmt.Errorf("输入数据长度不一致")
+	}
+	ema, err := CalculateEMA(close, period)
+	if err != nil {
+		return nil, err
+	}
+
+	slices := preallocateSlices(length, 2)
+	bull, bear := slices[0], slices[1]
+	for i := period - 1; i < length; i++ {
+		bull[i] = high[i] - ema.Values[i]
+		bear[i] = low[i] - ema.Values[i]
+	}
+
+	return &TaElderRay{
+		BullPower: bull,
+		BearPower: bear,
+		EMA:       ema.Values,
+		Period:    period,
+	}, nil
+}
+
+// ElderRay 从 KlineDatas 中提取数据并计算艾达透视指标
+// 参数：
+//   - period: EMA 周期
+//
+// 返回值：
+//   - *TaElderRay: 计算结果
+//   - error: 提取数据或计算过程中的错误
+func (k *KlineDatas) ElderRay(period int) (*TaElderRay, error) {
+	high, err := k.ExtractSlice("high")
+	if err != nil {
+		return nil, err
+	}
+	low, err := k.ExtractSlice("low")
+	if err != nil {
+		return nil, err
+	}
+	close, err := k.ExtractSlice("close")
+	if err != nil {
+		return nil, err
+	}
+	return CalculateElderRay(high, low, close, period)
+}
+
+// ElderRay_ 计算并返回最新的多头力量和空头力量，数据不足时返回 0
+func (k *KlineDatas) ElderRay_(period int) (bull, bear float64) {
+	_k, err := k.Keep(quickKeep("elderray", period))
+	if err != nil {
+		_k = *k
+	}
+	elder, err := _k.ElderRay(period)
+	if err != nil {
+		return 0, 0
+	}
+	return elder.Value()
+}
+
+// Value 返回最新的多头力量和空头力量
+func (t *TaElderRay) Value() (bull, bear float64) {
+	lastIndex := len(t.BullPower) - 1
+	return t.BullPower[lastIndex], t.BearPower[lastIndex]
+}
+
+// GetTrend 根据最近 n 根 K 线的 EMA 斜率返回趋势方向：TrendUp、TrendDown 或 TrendFlat
+func (t *TaElderRay) GetTrend(n int) int {
+	return seriesTrend(t.EMA, n)
+}
+
+// IsBuySignal 判断最新一根K线是否满足 Elder 买入条件：EMA 上升，空头力量为负且比前一根上升
+func (t *TaElderRay) IsBuySignal() bool {
+	lastIndex := len(t.BearPower) - 1
+	if lastIndex-1 < t.Period {
+		return false
+	}
+	bear, prev := t.BearPower[lastIndex], t.BearPower[lastIndex-1]
+	return t.GetTrend(1) == TrendUp && bear < 0 && bear > prev
+}
+
+// IsSellSignal 判断最新一根K线是否满足 Elder 卖出条件：EMA 下降，多头力量为正且比前一根下降
+func (t *TaElderRay) IsSellSignal() bool {
+	lastIndex := len(t.BullPower) - 1
+	if lastIndex-1 < t.Period {
+		return false
+	}
+	bull, prev := t.BullPower[lastIndex], t.BullPower[lastIndex-1]
+	return t.GetTrend(1) == TrendDown && bull > 0 && bull < prev
+}
+
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
@@ -92,6 +92,34 @@ func (k *KlineDatas) EMA(period int, source string) (*TaEMA, error) {
 	return CalculateEMA(prices, period)
 }
 
+// EMA_ 计算并返回最新的 EMA 值，数据不足时返回 0
+func (k *KlineDatas) EMA_(period int, source string) float64 {
+	_k, err := k.Keep(quickKeep("ema", period))
+	if err != nil {
+		_k = *k
+	}
+	ema, err := _k.EMA(period, source)
+	if err != nil {
+		return 0
+	}
+	return ema.Value()
+}
+
+// Update 追加一个新价格并增量计算最新的 EMA，结果同时追加到 Values 末尾
+// 说明/注意事项：
+//
+//	只依赖导出的 Period 与最新值，从 JSON 解码的 TaEMA 也可以继续更新；
+//	周期无效或没有历史值时返回错误。
+func (t *TaEMA) Update(price float64) (float64, error) {
+	if t.Period <= 0 || len(t.Values) == 0 {
+		return 0, fmt.Errorf("EMA 状态无效，无法增量更新")
+	}
+	multiplier := 2.0 / float64(t.Period+1)
+	value := price*multiplier + t.Value()*(1-multiplier)
+	t.Values = append(t.Values, value)
+	return value, nil
+}
+
 // Value 获取 TaEMA 结构体中最后一个 EMA 值
 // 返回值：
 //   - float64: TaEMA 结构体中最后一个 EMA 值
@@ -108,6 +136,21 @@ func (t *TaEMA) Value() float64 {
 	return t.Values[len(t.Values)-1]
 }
 
+// Slope 返回最近 n 根 K 线 EMA 的平均每根变化量，数据不足或落在预热期时返回 0
+func (t *TaEMA) Slope(n int) float64 {
+	return seriesSlope(t.Values, n)
+}
+
+// Acceleration 返回最近 n 根 K 线 EMA 斜率相对再之前 n 根的变化，正数表示加速上行或减速下行
+func (t *TaEMA) Acceleration(n int) float64 {
+	return seriesAcceleration(t.Values, n)
+}
+
+// GetTrend 按最近 n 根 K 线 EMA 的斜率返回 TrendUp、TrendDown 或 TrendFlat
+func (t *TaEMA) GetTrend(n int) int {
+	return seriesTrend(t.Values, n)
+}
+
 // ----------------------------------------------------------------------------
 // ----------------------------------------------------------------------------
 // ----------------------------------------------------------------------------
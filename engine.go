@@ -0,0 +1,269 @@
+package ta
+
+import (
+	"fmt"
+	"sync"
+)
+
+// EngineStrategy 引擎在每次更新指标后调用的策略
+// 参数：
+//   - symbol: 交易对
+//   - klines: 当前 K 线的副本，可安全持有
+//   - values: 本次更新后的指标快照
+type EngineStrategy func(symbol string, klines KlineDatas, values map[string]float64)
+
+// Engine 单个交易对的指标引擎
+// 说明：
+//
+//	持有滚动 K 线、按 SnapshotConfig 配置的指标和策略。Start 后从输入通道接收 K 线，
+//	开始时间与最后一根相同时视为未收盘 K 线的更新并替换，否则追加；每次接收后重新计算指标快照并依次调用策略。
+//	可通过 AddTimeframe 添加由基础 K 线实时聚合的大周期，其指标以 "周期名.指标名" 合并到快照中。
+//	快照与 K 线的查询方法可在任意协程中调用。
+//
+// 字段：
+//   - Symbol: 交易对
+//   - MaxBars: 保留的最大 K 线数量
+//   - Config: 指标快照配置，Window 建议按 DefaultKeepPolicy 设置以控制每次计算量
+//   - OnError: 通道接收的 K 线处理失败时的回调，为空时忽略错误
+type Engine struct {
+	Symbol  string
+	MaxBars int
+	Config  SnapshotConfig
+	OnError func(symbol string, err error)
+
+	mu         sync.RWMutex
+	klines     KlineDatas
+	values     map[string]float64
+	baseValues map[string]float64
+	frames     []*engineFrame
+	strategies []EngineStrategy
+	input      chan *KlineData
+	wg         sync.WaitGroup
+}
+
+// NewEngine 创建单个交易对的指标引擎
+// 参数：
+//   - symbol: 交易对
+//   - history: 历史 K 线，超过 maxBars 时只保留最近部分
+//   - config: 指标快照配置
+//   - maxBars: 保留的最大 K 线数量
+//
+// 返回值：
+//   - *Engine: 引擎，历史数据达到 config.MinBars 时已计算一次快照，否则快照为空，待 Ingest 补足数据
+//   - error: 参数无效或指标配置错误时返回错误
+//
+// 示例：
+//
+//	engine, err := NewEngine("BTCUSDT", history, SnapshotConfig{
+//	    Indicators: []SnapshotIndicator{{Name: "rsi14", Type: "rsi", Args: []float64{14}}},
+//	    Window:     500,
+//	}, 2000)
+//	engine.AddStrategy(func(symbol string, klines KlineDatas, values map[string]float64) {
+//	    if values["rsi14"] < 30 {
+//	        // 超卖
+//	    }
+//	})
+//	engine.Start(64)
+//	defer engine.Stop()
+//	engine.Input() <- kline
+func NewEngine(symbol string, history KlineDatas, config SnapshotConfig, maxBars int) (*Engine, error) {
+	if maxBars <= 0 {
+		return nil, fmt.Errorf("K线数量必须大于0")
+	}
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	if need := config.MinBars(); need > maxBars {
+		return nil, fmt.Errorf("指标需要至少%d根K线，超过保留数量%d", need, maxBars)
+	}
+	e := &Engine{Symbol: symbol, MaxBars: maxBars, Config: config}
+	if len(history) > maxBars {
+		history = history[len(history)-maxBars:]
+	}
+	e.klines = make(KlineDatas, len(history))
+	copy(e.klines, history)
+	if len(e.klines) >= config.MinBars() {
+		values, err := e.klines.Snapshot(config)
+		if err != nil {
+			return nil, err
+		}
+		e.baseValues = values
+		e.values = copyValues(values)
+	}
+	return e, nil
+}
+
+// AddStrategy 添加策略，应在 Start 之前调用
+func (e *Engine) AddStrategy(strategy EngineStrategy) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.strategies = append(e.strategies, strategy)
+}
+
+// Start 启动接收协程
+// 参数：
+//   - buffer: 输入通道的缓冲大小
+//
+// 返回值：
+//   - error: 引擎已在运行时返回错误
+func (e *Engine) Start(buffer int) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.input != nil {
+		return fmt.Errorf("引擎 %s 已在运行", e.Symbol)
+	}
+	e.input = make(chan *KlineData, buffer)
+	e.wg.Add(1)
+	go e.run(e.input)
+	return nil
+}
+
+// Stop 关闭输入通道并等待已进入通道的 K 线处理完毕，调用前应停止向 Input 发送
+func (e *Engine) Stop() {
+	e.mu.Lock()
+	input := e.input
+	e.input = nil
+	e.mu.Unlock()
+	if input == nil {
+		return
+	}
+	close(input)
+	e.wg.Wait()
+}
+
+// Input 返回 K 线输入通道，引擎未运行时返回 nil
+func (e *Engine) Input() chan<- *KlineData {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	return e.input
+}
+
+// Running 判断引擎是否在运行
+func (e *Engine) Running() bool {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	return e.input != nil
+}
+
+func (e *Engine) run(input <-chan *KlineData) {
+	defer e.wg.Done()
+	for kline := range input {
+		if err := e.safeIngest(kline); err != nil && e.OnError != nil {
+			e.OnError(e.Symbol, err)
+		}
+	}
+}
+
+// safeIngest 调用 Ingest 并将指标或策略中的 panic 转为错误，避免接收协程崩溃
+func (e *Engine) safeIngest(kline *KlineData) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("处理K线时发生异常: %v", r)
+		}
+	}()
+	return e.Ingest(kline)
+}
+
+// Ingest 同步接收一根 K 线，更新指标并调用策略
+// 参数：
+//   - kline: 新 K 线或最后一根未收盘 K 线的更新
+//
+// 返回值：
+//   - error: K 线早于最后一根时返回错误并忽略该 K 线；指标计算失败时返回错误，K 线已更新但快照保持不变；
+//     大周期计算失败时基础快照照常更新并调用策略，该周期保留上一次的值，错误在策略调用后返回
+//
+// 说明/注意事项：
+//
+//	K 线数量少于 Config.MinBars 时视为预热中，只保存 K 线，快照为空且不调用策略。
+//	大周期同样按各自的 MinBars 判断预热，预热中的大周期不出现在快照中，不影响基础周期与策略，可用 TimeframeReady 查询。
+func (e *Engine) Ingest(kline *KlineData) error {
+	if kline == nil {
+		return fmt.Errorf("K线为空")
+	}
+	klines, values, strategies, err := e.apply(kline)
+	if values == nil {
+		return err
+	}
+	for _, strategy := range strategies {
+		strategy(e.Symbol, klines, copyValues(values))
+	}
+	return err
+}
+
+// apply 在锁内并入 K 线并重新计算快照，基础周期预热中或计算失败时返回的 values 为 nil；
+// 大周期计算失败不影响基础快照，values 与错误同时返回
+func (e *Engine) apply(kline *KlineData) (KlineDatas, map[string]float64, []EngineStrategy, error) {
+	copied := *kline
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	n := len(e.klines)
+	switch {
+	case n > 0 && e.klines[n-1].StartTime == copied.StartTime:
+		e.klines[n-1] = &copied
+	case n > 0 && e.klines[n-1].StartTime > copied.StartTime:
+		return nil, nil, nil, fmt.Errorf("K线开始时间 %d 早于最后一根 %d", copied.StartTime, e.klines[n-1].StartTime)
+	default:
+		e.klines = append(e.klines, &copied)
+		if len(e.klines) > e.MaxBars {
+			e.klines = append(KlineDatas(nil), e.klines[len(e.klines)-e.MaxBars:]...)
+		}
+	}
+	for _, f := range e.frames {
+		f.update(&copied)
+	}
+	frameErr := e.refreshFrames()
+	if len(e.klines) < e.Config.MinBars() {
+		e.values = e.mergeFrameValues(e.baseValues)
+		return nil, nil, nil, frameErr
+	}
+
+	values, err := e.klines.Snapshot(e.Config)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	e.baseValues = values
+	e.values = e.mergeFrameValues(values)
+
+	klines := make(KlineDatas, len(e.klines))
+	copy(klines, e.klines)
+	return klines, copyValues(e.values), e.strategies, frameErr
+}
+
+// Snapshot 返回最新的指标快照副本
+func (e *Engine) Snapshot() map[string]float64 {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	return copyValues(e.values)
+}
+
+// Value 返回快照中指定指标的最新值，不存在时返回 0 和 false
+func (e *Engine) Value(name string) (float64, bool) {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	v, ok := e.values[name]
+	return v, ok
+}
+
+// Klines 返回当前 K 线的副本
+func (e *Engine) Klines() KlineDatas {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	out := make(KlineDatas, len(e.klines))
+	copy(out, e.klines)
+	return out
+}
+
+func copyValues(values map[string]float64) map[string]float64 {
+	out := make(map[string]float64, len(values))
+	for k, v := range values {
+		out[k] = v
+	}
+	return out
+}
+
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
@@ -0,0 +1,208 @@
+package ta
+
+import (
+	"fmt"
+)
+
+// engineFrame 引擎中由基础 K 线增量聚合的大周期
+type engineFrame struct {
+	name     string
+	interval int64
+	config   SnapshotConfig
+	maxBars  int
+	klines   KlineDatas
+	values   map[string]float64
+	// align 返回基础 K 线所属大周期的开始时间，与 Resample 的分桶一致
+	align func(t int64) int64
+
+	// closed 当前大周期内已收盘基础 K 线的聚合，pending 为最新一根基础 K 线（可能未收盘）
+	closed  *KlineData
+	pending *KlineData
+}
+
+// bucket 返回基础 K 线所属大周期的开始时间
+func (f *engineFrame) bucket(t int64) int64 {
+	return f.align(t)
+}
+
+// update 将一根基础 K 线并入大周期，开始时间与上一根相同时视为未收盘 K 线的更新
+func (f *engineFrame) update(kline *KlineData) {
+	start := f.bucket(kline.StartTime)
+	if f.pending != nil && kline.StartTime != f.pending.StartTime {
+		if f.bucket(f.pending.StartTime) == start {
+			f.closed = mergeKline(f.closed, f.pending, start)
+		} else {
+			f.closed = nil
+		}
+	}
+	copied := *kline
+	f.pending = &copied
+
+	bar := mergeKline(f.closed, f.pending, start)
+	if n := len(f.klines); n > 0 && f.klines[n-1].StartTime == start {
+		f.klines[n-1] = bar
+		return
+	}
+	f.klines = append(f.klines, bar)
+	if len(f.klines) > f.maxBars {
+		f.klines = append(KlineDatas(nil), f.klines[len(f.klines)-f.maxBars:]...)
+	}
+}
+
+// mergeKline 返回 agg 与 kline 合并后的新 K 线，agg 为 nil 时以 kline 开始
+func mergeKline(agg, kline *KlineData, start int64) *KlineData {
+	if agg == nil {
+		merged := *kline
+		merged.StartTime = start
+		return &merged
+	}
+	return &KlineData{
+		StartTime: start,
+		Open:      agg.Open,
+		High:      max(agg.High, kline.High),
+		Low:       min(agg.Low, kline.Low),
+		Close:     kline.Close,
+		Volume:    agg.Volume + kline.Volume,
+	}
+}
+
+// AddTimeframe 添加由基础 K 线实时聚合的大周期及其指标，应在 Start 之前调用
+// 参数：
+//   - name: 周期名称，如 "1h"，大周期的指标以 "name.指标名" 合并到快照中
+//   - interval: 大周期的毫秒数，可由 ParseInterval 得到，应为基础周期的整数倍；1 周按 UTC 自然周（周一开始）对齐，不支持多周
+//   - config: 大周期的指标快照配置
+//   - maxBars: 大周期保留的最大 K 线数量
+//
+// 返回值：
+//   - error: 参数无效、名称重复或指标配置错误时返回错误
+//
+// 说明/注意事项：
+//
+//	添加时用引擎现有的基础 K 线聚合一次，此后每根基础 K 线只更新最后一根大周期 K 线，不会重新重采样历史。
+//	大周期最后一根通常尚未收盘，其指标值相当于"若此刻收盘"的临时值。
+//	现有数据不足以计算大周期指标时该周期处于预热中，其指标暂不出现在快照中，基础周期与策略照常运行；
+//	应在 NewEngine 中传入足够的基础 K 线，使大周期尽快达到指标的预热长度。
+//
+// 示例：
+//
+//	hour, _ := ParseInterval("1h")
+//	err := engine.AddTimeframe("1h", hour, SnapshotConfig{
+//	    Indicators: []SnapshotIndicator{{Name: "ema50", Type: "ema", Args: []float64{50}}},
+//	}, 500)
+//	engine.AddStrategy(func(symbol string, klines KlineDatas, values map[string]float64) {
+//	    if values["rsi14"] < 30 && klines[len(klines)-1].Close > values["1h.ema50"] {
+//	        // 小周期超卖且大周期趋势向上
+//	    }
+//	})
+func (e *Engine) AddTimeframe(name string, interval int64, config SnapshotConfig, maxBars int) error {
+	align, err := intervalBucket(interval)
+	if err != nil {
+		return err
+	}
+	if maxBars <= 0 {
+		return fmt.Errorf("K线数量必须大于0")
+	}
+	if err := config.Validate(); err != nil {
+		return fmt.Errorf("周期 %s: %v", name, err)
+	}
+	if need := config.MinBars(); need > maxBars {
+		return fmt.Errorf("周期 %s: 指标需要至少%d根K线，超过保留数量%d", name, need, maxBars)
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	for _, f := range e.frames {
+		if f.name == name {
+			return fmt.Errorf("周期 %s 已存在", name)
+		}
+	}
+	f := &engineFrame{name: name, interval: interval, config: config, maxBars: maxBars, align: align}
+	for _, kline := range e.klines {
+		f.update(kline)
+	}
+	if len(f.klines) >= config.MinBars() {
+		values, err := f.klines.Snapshot(config)
+		if err != nil {
+			return fmt.Errorf("周期 %s: %v", name, err)
+		}
+		f.values = values
+	}
+	e.frames = append(e.frames, f)
+	e.values = e.mergeFrameValues(e.baseValues)
+	return nil
+}
+
+// Timeframes 按添加顺序返回大周期名称
+func (e *Engine) Timeframes() []string {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	names := make([]string, len(e.frames))
+	for i, f := range e.frames {
+		names[i] = f.name
+	}
+	return names
+}
+
+// TimeframeReady 判断大周期是否已完成预热，其指标已出现在快照中；周期不存在时返回 false
+func (e *Engine) TimeframeReady(name string) bool {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	for _, f := range e.frames {
+		if f.name == name {
+			return f.values != nil
+		}
+	}
+	return false
+}
+
+// TimeframeKlines 返回大周期 K 线的副本，周期不存在时返回 nil
+func (e *Engine) TimeframeKlines(name string) KlineDatas {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	for _, f := range e.frames {
+		if f.name == name {
+			out := make(KlineDatas, len(f.klines))
+			copy(out, f.klines)
+			return out
+		}
+	}
+	return nil
+}
+
+// refreshFrames 重新计算已完成预热的大周期快照，预热中的大周期没有指标值；
+// 计算失败的大周期保留上一次的值并返回第一个错误，调用方需持有锁
+func (e *Engine) refreshFrames() error {
+	var firstErr error
+	for _, f := range e.frames {
+		if len(f.klines) < f.config.MinBars() {
+			f.values = nil
+			continue
+		}
+		values, err := f.klines.Snapshot(f.config)
+		if err != nil {
+			if firstErr == nil {
+				firstErr = fmt.Errorf("周期 %s: %v", f.name, err)
+			}
+			continue
+		}
+		f.values = values
+	}
+	return firstErr
+}
+
+// mergeFrameValues 返回基础快照与各大周期带前缀快照的合并结果，调用方需持有锁
+func (e *Engine) mergeFrameValues(base map[string]float64) map[string]float64 {
+	merged := copyValues(base)
+	for _, f := range e.frames {
+		for k, v := range f.values {
+			merged[f.name+"."+k] = v
+		}
+	}
+	return merged
+}
+
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
@@ -0,0 +1,54 @@
+package ta
+
+import (
+	"testing"
+	"time"
+)
+
+func TestEngineWeeklyTimeframe(t *testing.T) {
+	base := time.Date(2024, 1, 3, 0, 0, 0, 0, time.UTC)
+	var klineData KlineDatas
+	for i := 0; i < 20; i++ {
+		c := float64(100 + i)
+		klineData = append(klineData, &KlineData{
+			StartTime: base.AddDate(0, 0, i).UnixMilli(),
+			Open:      c - 1, High: c + 1, Low: c - 2, Close: c, Volume: 1,
+		})
+	}
+	config := SnapshotConfig{Indicators: []SnapshotIndicator{{Name: "sma2", Type: "sma", Args: []float64{2}}}}
+	engine, err := NewEngine("BTCUSDT", klineData[:10], config, 100)
+	if err != nil {
+		t.Fatal(err)
+	}
+	week, _ := ParseInterval("1w")
+	if err := engine.AddTimeframe("1w", week, config, 100); err != nil {
+		t.Fatal(err)
+	}
+	for _, kline := range klineData[10:] {
+		if err := engine.Ingest(kline); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// 逐根增量聚合的周线应与整体重采样一致，且每周从周一开始
+	want, err := klineData.Resample(week)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := engine.TimeframeKlines("1w")
+	if len(got) != len(want) {
+		t.Fatalf("周线数量 = %d, want %d", len(got), len(want))
+	}
+	for i := range want {
+		if *got[i] != *want[i] {
+			t.Errorf("周线 %d = %+v, want %+v", i, *got[i], *want[i])
+		}
+		if weekday := time.UnixMilli(got[i].StartTime).UTC().Weekday(); weekday != time.Monday {
+			t.Errorf("周线 %d 从 %v 开始", i, weekday)
+		}
+	}
+
+	if err := engine.AddTimeframe("2w", 2*week, config, 100); err == nil {
+		t.Error("多周周期应返回错误")
+	}
+}
@@ -0,0 +1,179 @@
+package ta
+
+import (
+	"fmt"
+	"math"
+	"sort"
+)
+
+// Excursion 一次信号在观察窗口内的最大不利/有利偏移
+// 字段：
+//   - Index: 信号 K 线下标，以该 K 线收盘价为入场价
+//   - Side: 方向，1 做多，-1 做空
+//   - EntryPrice: 入场价
+//   - MAE: 最大不利偏移，相对入场价的比例，≥ 0
+//   - MFE: 最大有利偏移，相对入场价的比例，≥ 0
+//   - MAEATR: 最大不利偏移相当于入场时 ATR 的倍数，未提供 ATR 时为 0
+//   - MFEATR: 最大有利偏移相当于入场时 ATR 的倍数，未提供 ATR 时为 0
+//   - Bars: 实际观察的 K 线数量，靠近数据末尾时可能小于窗口
+type Excursion struct {
+	Index      int     `json:"index"`
+	Side       int     `json:"side"`
+	EntryPrice float64 `json:"entry_price"`
+	MAE        float64 `json:"mae"`
+	MFE        float64 `json:"mfe"`
+	MAEATR     float64 `json:"mae_atr"`
+	MFEATR     float64 `json:"mfe_atr"`
+	Bars       int     `json:"bars"`
+}
+
+// TaExcursion 一组信号的 MAE/MFE 统计
+// 字段：
+//   - Excursions: 每个信号的偏移，没有后续 K 线的信号被跳过
+//   - Window: 观察窗口长度
+type TaExcursion struct {
+	Excursions []Excursion `json:"excursions"`
+	Window     int         `json:"window"`
+}
+
+// Excursions 计算每个信号之后 window 根 K 线内的最大不利偏移（MAE）与最大有利偏移（MFE）
+// 参数：
+//   - entries: 信号 K 线下标
+//   - sides: 与 entries 等长的方向，正数做多、负数做空；为 nil 时全部做多
+//   - window: 向后观察的 K 线数量
+//   - atr: 与 K 线等长的 ATR 序列，用于把偏移换算为 ATR 倍数；为 nil 时不换算
+//
+// 返回值：
+//   - *TaExcursion: 偏移统计
+//   - error: 参数无效或下标越界时返回错误
+//
+// 说明/注意事项：
+//
+//	以信号 K 线收盘价入场，使用之后 K 线的最高价和最低价计算偏移，不考虑止损止盈提前离场。
+//	MAE 分布的高分位可作为止损距离的参考，MFE 分布可作为止盈距离的参考。
+//
+// 示例：
+//
+//	atr, _ := klineData.ATR(14)
+//	ex, err := klineData.Excursions(entries, sides, 20, atr.Values)
+//	stop := ex.MAEQuantile(0.8, true)   // 80% 的信号不利偏移不超过该 ATR 倍数
+//	target := ex.MFEQuantile(0.5, true) // MFE 中位数
+func (k *KlineDatas) Excursions(entries, sides []int, window int, atr []float64) (*TaExcursion, error) {
+	klines := *k
+	if window <= 0 {
+		return nil, fmt.Errorf("窗口长度必须大于0")
+	}
+	if sides != nil && len(sides) != len(entries) {
+		return nil, fmt.Errorf("输入数据长度不一致")
+	}
+	if atr != nil && len(atr) != len(klines) {
+		return nil, fmt.Errorf("输入数据长度不一致")
+	}
+
+	result := &TaExcursion{Window: window}
+	for n, index := range entries {
+		if index < 0 || index >= len(klines) {
+			return nil, fmt.Errorf("信号下标越界: %d", index)
+		}
+		side := 1
+		if sides != nil && sides[n] < 0 {
+			side = -1
+		}
+		end := index + window
+		if end > len(klines)-1 {
+			end = len(klines) - 1
+		}
+		entry := klines[index].Close
+		if end == index || entry == 0 {
+			continue
+		}
+
+		highest, lowest := math.Inf(-1), math.Inf(1)
+		for _, kline := range klines[index+1 : end+1] {
+			highest = math.Max(highest, kline.High)
+			lowest = math.Min(lowest, kline.Low)
+		}
+		adverse, favorable := entry-lowest, highest-entry
+		if side < 0 {
+			adverse, favorable = highest-entry, entry-lowest
+		}
+		e := Excursion{
+			Index:      index,
+			Side:       side,
+			EntryPrice: entry,
+			MAE:        math.Max(adverse, 0) / entry,
+			MFE:        math.Max(favorable, 0) / entry,
+			Bars:       end - index,
+		}
+		if atr != nil && atr[index] > 0 {
+			e.MAEATR = math.Max(adverse, 0) / atr[index]
+			e.MFEATR = math.Max(favorable, 0) / atr[index]
+		}
+		result.Excursions = append(result.Excursions, e)
+	}
+	return result, nil
+}
+
+// quantile 返回某个字段的分位数，没有信号时返回 NaN
+func (t *TaExcursion) quantile(q float64, field func(e Excursion) float64) float64 {
+	values := make([]float64, len(t.Excursions))
+	for i, e := range t.Excursions {
+		values[i] = field(e)
+	}
+	sort.Float64s(values)
+	return percentileSorted(values, q)
+}
+
+// MAEQuantile 返回 MAE 的分位数
+// 参数：
+//   - q: 分位，取值 [0, 1]，如 0.8
+//   - inATR: 为 true 时返回 ATR 倍数，否则返回相对入场价的比例
+func (t *TaExcursion) MAEQuantile(q float64, inATR bool) float64 {
+	return t.quantile(q, func(e Excursion) float64 {
+		if inATR {
+			return e.MAEATR
+		}
+		return e.MAE
+	})
+}
+
+// MFEQuantile 返回 MFE 的分位数，参数含义同 MAEQuantile
+func (t *TaExcursion) MFEQuantile(q float64, inATR bool) float64 {
+	return t.quantile(q, func(e Excursion) float64 {
+		if inATR {
+			return e.MFEATR
+		}
+		return e.MFE
+	})
+}
+
+// HitRate 返回窗口内有利偏移达到 target 且不利偏移始终未达到 stop 的信号占比
+// 参数：
+//   - stop: 止损距离
+//   - target: 止盈距离
+//   - inATR: 为 true 时 stop 与 target 以 ATR 倍数表示，否则以相对入场价的比例表示
+//
+// 返回值：
+//   - float64: 占比 0-1，没有信号时返回 NaN
+func (t *TaExcursion) HitRate(stop, target float64, inATR bool) float64 {
+	if len(t.Excursions) == 0 {
+		return math.NaN()
+	}
+	hits := 0
+	for _, e := range t.Excursions {
+		mae, mfe := e.MAE, e.MFE
+		if inATR {
+			mae, mfe = e.MAEATR, e.MFEATR
+		}
+		if mfe >= target && mae < stop {
+			hits++
+		}
+	}
+	return float64(hits) / float64(len(t.Excursions))
+}
+
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
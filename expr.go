@@ -0,0 +1,603 @@
+package ta
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// TaExpr 由字符串表达式编译得到的自定义指标
+// 说明：
+//
+//	支持四则运算、比较（结果为 1/0）、逻辑与或（&&、||）、括号和一元负号。
+//	数据源：open、high、low、close、volume、hl2、hlc3、ohlc4。
+//	函数：
+//	  - sma/ema/rma/rsi(series, period)
+//	  - atr/cci/wr(period)
+//	  - shift(series, n)：向后平移 n 根 K 线（同 Shift），前 n 个位置为 0
+//	  - abs(x)、max(a, b)、min(a, b)
+//	  - pctl(series, lookback, pct)：series 此前 lookback 个值的 pct 百分位（0-100），跳过开头的预热 0 值，用作动态阈值
+//	周期参数必须是数字常量。其他标识符按 EvalWith 传入的命名序列解析。
+//
+// 字段：
+//   - Source: 原始表达式字符串
+type TaExpr struct {
+	Source string `json:"source"`
+
+	root exprNode
+}
+
+// CompileExpr 编译表达式
+// 参数：
+//   - source: 表达式字符串，如 "ema(close,20) - ema(close,50)" 或 "rsi(hlc3,14) < 30"
+//
+// 返回值：
+//   - *TaExpr: 编译后的表达式
+//   - error: 语法错误时返回错误
+//
+// 示例：
+//
+//	expr, err := CompileExpr("rsi(hlc3,14) < 30")
+//	if err != nil {
+//	    // 处理错误
+//	}
+//	values, err := expr.Eval(klineData)
+func CompileExpr(source string) (*TaExpr, error) {
+	tokens, err := tokenizeExpr(source)
+	if err != nil {
+		return nil, err
+	}
+	p := &exprParser{tokens: tokens}
+	root, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if p.pos < len(p.tokens) {
+		return nil, fmt.Errorf("表达式第%d个字符附近存在多余内容: %q", p.peek().pos+1, p.peek().text)
+	}
+	return &TaExpr{Source: source, root: root}, nil
+}
+
+// Eval 在 K 线数据上计算表达式
+// 参数：
+//   - klineData: K 线数据
+//
+// 返回值：
+//   - []float64: 与 K 线等长的结果序列
+//   - error: 计算过程中的错误，如数据不足
+func (e *TaExpr) Eval(klineData KlineDatas) ([]float64, error) {
+	return e.EvalWith(klineData, nil)
+}
+
+// EvalWith 在 K 线数据上计算表达式，并允许引用额外的命名序列
+// 参数：
+//   - klineData: K 线数据
+//   - vars: 命名序列，长度必须与 K 线一致，可在表达式中按名称引用
+//
+// 返回值：
+//   - []float64: 与 K 线等长的结果序列
+//   - error: 计算过程中的错误
+//
+// 示例：
+//
+//	values, err := expr.EvalWith(klineData, map[string][]float64{"fast": fast})
+func (e *TaExpr) EvalWith(klineData KlineDatas, vars map[string][]float64) ([]float64, error) {
+	if len(klineData) == 0 {
+		return nil, fmt.Errorf("没有K线数据")
+	}
+	ctx := &exprContext{klines: klineData, sources: make(map[string][]float64)}
+	for name, series := range vars {
+		if len(series) != len(klineData) {
+			return nil, fmt.Errorf("序列 %s 长度(%d)与K线数量(%d)不一致", name, len(series), len(klineData))
+		}
+		ctx.sources[strings.ToLower(name)] = series
+	}
+	return e.root.eval(ctx)
+}
+
+// Expr 编译并在当前 K 线数据上计算表达式
+// 参数：
+//   - source: 表达式字符串
+//
+// 返回值：
+//   - []float64: 结果序列
+//   - error: 编译或计算过程中的错误
+//
+// 示例：
+//
+//	spread, err := klineData.Expr("ema(close,20) - ema(close,50)")
+func (k *KlineDatas) Expr(source string) ([]float64, error) {
+	expr, err := CompileExpr(source)
+	if err != nil {
+		return nil, err
+	}
+	return expr.Eval(*k)
+}
+
+type exprContext struct {
+	klines  KlineDatas
+	sources map[string][]float64
+}
+
+func (c *exprContext) source(name string) ([]float64, error) {
+	if s, ok := c.sources[name]; ok {
+		return s, nil
+	}
+	if !exprSources[name] {
+		return nil, fmt.Errorf("未知数据源: %s", name)
+	}
+	s, err := c.klines.ExtractSlice(name)
+	if err != nil {
+		return nil, err
+	}
+	c.sources[name] = s
+	return s, nil
+}
+
+type exprNode interface {
+	eval(ctx *exprContext) ([]float64, error)
+}
+
+type numberNode struct {
+	value float64
+}
+
+func (n *numberNode) eval(ctx *exprContext) ([]float64, error) {
+	out := make([]float64, len(ctx.klines))
+	for i := range out {
+		out[i] = n.value
+	}
+	return out, nil
+}
+
+type sourceNode struct {
+	name string
+}
+
+func (n *sourceNode) eval(ctx *exprContext) ([]float64, error) {
+	return ctx.source(n.name)
+}
+
+type unaryNode struct {
+	operand exprNode
+}
+
+func (n *unaryNode) eval(ctx *exprContext) ([]float64, error) {
+	v, err := n.operand.eval(ctx)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]float64, len(v))
+	for i := range v {
+		out[i] = -v[i]
+	}
+	return out, nil
+}
+
+type binaryNode struct {
+	op          string
+	left, right exprNode
+}
+
+func boolValue(b bool) float64 {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+func (n *binaryNode) eval(ctx *exprContext) ([]float64, error) {
+	a, err := n.left.eval(ctx)
+	if err != nil {
+		return nil, err
+	}
+	b, err := n.right.eval(ctx)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]float64, len(a))
+	for i := range a {
+		switch n.op {
+		case "+":
+			out[i] = a[i] + b[i]
+		case "-":
+			out[i] = a[i] - b[i]
+		case "*":
+			out[i] = a[i] * b[i]
+		case "/":
+			if b[i] != 0 {
+				out[i] = a[i] / b[i]
+			}
+		case "<":
+			out[i] = boolValue(a[i] < b[i])
+		case "<=":
+			out[i] = boolValue(a[i] <= b[i])
+		case ">":
+			out[i] = boolValue(a[i] > b[i])
+		case ">=":
+			out[i] = boolValue(a[i] >= b[i])
+		case "==":
+			out[i] = boolValue(a[i] == b[i])
+		case "!=":
+			out[i] = boolValue(a[i] != b[i])
+		case "&&":
+			out[i] = boolValue(a[i] != 0 && b[i] != 0)
+		case "||":
+			out[i] = boolValue(a[i] != 0 || b[i] != 0)
+		}
+	}
+	return out, nil
+}
+
+type callNode struct {
+	name string
+	args []exprNode
+}
+
+// periodArg 读取常量周期参数
+func (n *callNode) periodArg(index int) (int, error) {
+	num, ok := n.args[index].(*numberNode)
+	if !ok || num.value <= 0 || num.value != math.Trunc(num.value) {
+		return 0, fmt.Errorf("%s 的第%d个参数必须是正整数常量", n.name, index+1)
+	}
+	return int(num.value), nil
+}
+
+// checkLength 按 WarmupLength 检查数据量，避免数据不足时调用指标函数
+func (n *callNode) checkLength(period, length int) error {
+	if need := WarmupLength(n.name, period) + 1; length < need {
+		return fmt.Errorf("%s(%d) 数据不足，需要至少%d根K线，实际为%d根", n.name, period, need, length)
+	}
+	return nil
+}
+
+func (n *callNode) eval(ctx *exprContext) ([]float64, error) {
+	switch n.name {
+	case "sma", "ema", "rma", "rsi", "shift":
+		series, err := n.args[0].eval(ctx)
+		if err != nil {
+			return nil, err
+		}
+		period, err := n.periodArg(1)
+		if err != nil {
+			return nil, err
+		}
+		if n.name != "shift" {
+			if err := n.checkLength(period, len(series)); err != nil {
+				return nil, err
+			}
+		}
+		switch n.name {
+		case "sma":
+			r, err := CalculateSMA(series, period)
+			if err != nil {
+				return nil, err
+			}
+			return r.Values, nil
+		case "ema":
+			r, err := CalculateEMA(series, period)
+			if err != nil {
+				return nil, err
+			}
+			return r.Values, nil
+		case "rma":
+			r, err := CalculateRMA(series, period)
+			if err != nil {
+				return nil, err
+			}
+			return r.Values, nil
+		case "rsi":
+			r, err := CalculateRSI(series, period)
+			if err != nil {
+				return nil, err
+			}
+			return r.Values, nil
+		default:
+			return Shift(series, period), nil
+		}
+	case "atr", "cci", "wr":
+		period, err := n.periodArg(0)
+		if err != nil {
+			return nil, err
+		}
+		if err := n.checkLength(period, len(ctx.klines)); err != nil {
+			return nil, err
+		}
+		switch n.name {
+		case "atr":
+			r, err := CalculateATR(ctx.klines, period)
+			if err != nil {
+				return nil, err
+			}
+			return r.Values, nil
+		case "cci":
+			r, err := CalculateCCI(ctx.klines, period)
+			if err != nil {
+				return nil, err
+			}
+			return r.Values, nil
+		default:
+			r, err := ctx.klines.WilliamsR(period)
+			if err != nil {
+				return nil, err
+			}
+			return r.Values, nil
+		}
+	case "pctl":
+		series, err := n.args[0].eval(ctx)
+		if err != nil {
+			return nil, err
+		}
+		lookback, err := n.periodArg(1)
+		if err != nil {
+			return nil, err
+		}
+		pct, ok := n.args[2].(*numberNode)
+		if !ok {
+			return nil, fmt.Errorf("pctl 的第3个参数必须是数字常量")
+		}
+		start := 0
+		for start < len(series) && series[start] == 0 {
+			start++
+		}
+		r, err := CalculateDynamicThresholds(series, start, lookback, pct.value, pct.value)
+		if err != nil {
+			return nil, err
+		}
+		return r.Upper, nil
+	case "abs":
+		v, err := n.args[0].eval(ctx)
+		if err != nil {
+			return nil, err
+		}
+		out := make([]float64, len(v))
+		for i := range v {
+			out[i] = math.Abs(v[i])
+		}
+		return out, nil
+	case "max", "min":
+		a, err := n.args[0].eval(ctx)
+		if err != nil {
+			return nil, err
+		}
+		b, err := n.args[1].eval(ctx)
+		if err != nil {
+			return nil, err
+		}
+		out := make([]float64, len(a))
+		for i := range a {
+			if n.name == "max" {
+				out[i] = max(a[i], b[i])
+			} else {
+				out[i] = min(a[i], b[i])
+			}
+		}
+		return out, nil
+	}
+	return nil, fmt.Errorf("未知函数: %s", n.name)
+}
+
+// validateExprArgs 按 Describe 的参数说明在编译时校验指标函数的常量参数，
+// 指标参数位于参数列表末尾，前面的序列参数不参与校验
+func validateExprArgs(name string, args []exprNode) error {
+	info, err := Describe(name)
+	if err != nil {
+		return nil
+	}
+	offset := len(args) - len(info.Params)
+	for i, p := range info.Params {
+		if num, ok := args[offset+i].(*numberNode); ok {
+			if err := p.Validate(num.value); err != nil {
+				return fmt.Errorf("函数 %s: %v", name, err)
+			}
+		}
+	}
+	return nil
+}
+
+// exprArity 各函数的参数个数
+var exprArity = map[string]int{
+	"sma": 2, "ema": 2, "rma": 2, "rsi": 2, "shift": 2,
+	"atr": 1, "cci": 1, "wr": 1,
+	"abs": 1, "max": 2, "min": 2,
+	"pctl": 3,
+}
+
+var exprSources = map[string]bool{
+	"open": true, "high": true, "low": true, "close": true, "volume": true,
+	"hl2": true, "hlc3": true, "ohlc4": true,
+}
+
+type exprToken struct {
+	kind string // num, ident, op
+	text string
+	pos  int
+}
+
+func tokenizeExpr(source string) ([]exprToken, error) {
+	var tokens []exprToken
+	runes := []rune(source)
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case unicode.IsDigit(r) || r == '.':
+			start := i
+			for i < len(runes) && (unicode.IsDigit(runes[i]) || runes[i] == '.') {
+				i++
+			}
+			tokens = append(tokens, exprToken{kind: "num", text: string(runes[start:i]), pos: start})
+		case unicode.IsLetter(r) || r == '_':
+			start := i
+			for i < len(runes) && (unicode.IsLetter(runes[i]) || unicode.IsDigit(runes[i]) || runes[i] == '_') {
+				i++
+			}
+			tokens = append(tokens, exprToken{kind: "ident", text: strings.ToLower(string(runes[start:i])), pos: start})
+		default:
+			if i+1 < len(runes) {
+				two := string(runes[i : i+2])
+				switch two {
+				case "<=", ">=", "==", "!=", "&&", "||":
+					tokens = append(tokens, exprToken{kind: "op", text: two, pos: i})
+					i += 2
+					continue
+				}
+			}
+			if strings.ContainsRune("+-*/<>(),", r) {
+				tokens = append(tokens, exprToken{kind: "op", text: string(r), pos: i})
+				i++
+				continue
+			}
+			return nil, fmt.Errorf("表达式第%d个字符无法识别: %q", i+1, string(r))
+		}
+	}
+	return tokens, nil
+}
+
+type exprParser struct {
+	tokens []exprToken
+	pos    int
+}
+
+func (p *exprParser) peek() exprToken {
+	if p.pos < len(p.tokens) {
+		return p.tokens[p.pos]
+	}
+	return exprToken{}
+}
+
+func (p *exprParser) accept(ops ...string) (string, bool) {
+	t := p.peek()
+	if t.kind != "op" {
+		return "", false
+	}
+	for _, op := range ops {
+		if t.text == op {
+			p.pos++
+			return op, true
+		}
+	}
+	return "", false
+}
+
+func (p *exprParser) parseBinary(next func() (exprNode, error), ops ...string) (exprNode, error) {
+	left, err := next()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		op, ok := p.accept(ops...)
+		if !ok {
+			return left, nil
+		}
+		right, err := next()
+		if err != nil {
+			return nil, err
+		}
+		left = &binaryNode{op: op, left: left, right: right}
+	}
+}
+
+func (p *exprParser) parseOr() (exprNode, error) {
+	return p.parseBinary(p.parseAnd, "||")
+}
+
+func (p *exprParser) parseAnd() (exprNode, error) {
+	return p.parseBinary(p.parseCompare, "&&")
+}
+
+func (p *exprParser) parseCompare() (exprNode, error) {
+	return p.parseBinary(p.parseAdd, "<", "<=", ">", ">=", "==", "!=")
+}
+
+func (p *exprParser) parseAdd() (exprNode, error) {
+	return p.parseBinary(p.parseMul, "+", "-")
+}
+
+func (p *exprParser) parseMul() (exprNode, error) {
+	return p.parseBinary(p.parseUnary, "*", "/")
+}
+
+func (p *exprParser) parseUnary() (exprNode, error) {
+	if _, ok := p.accept("-"); ok {
+		operand, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		if num, ok := operand.(*numberNode); ok {
+			return &numberNode{value: -num.value}, nil
+		}
+		return &unaryNode{operand: operand}, nil
+	}
+	return p.parsePrimary()
+}
+
+func (p *exprParser) parsePrimary() (exprNode, error) {
+	if p.pos >= len(p.tokens) {
+		return nil, fmt.Errorf("表达式意外结束")
+	}
+	t := p.tokens[p.pos]
+	p.pos++
+
+	switch t.kind {
+	case "num":
+		v, err := strconv.ParseFloat(t.text, 64)
+		if err != nil {
+			return nil, fmt.Errorf("表达式第%d个字符处数字无效: %s", t.pos+1, t.text)
+		}
+		return &numberNode{value: v}, nil
+	case "ident":
+		if _, ok := p.accept("("); !ok {
+			return &sourceNode{name: t.text}, nil
+		}
+		arity, ok := exprArity[t.text]
+		if !ok {
+			return nil, fmt.Errorf("未知函数: %s", t.text)
+		}
+		var args []exprNode
+		if _, ok := p.accept(")"); !ok {
+			for {
+				arg, err := p.parseOr()
+				if err != nil {
+					return nil, err
+				}
+				args = append(args, arg)
+				if _, ok := p.accept(","); ok {
+					continue
+				}
+				if _, ok := p.accept(")"); ok {
+					break
+				}
+				return nil, fmt.Errorf("函数 %s 的参数列表缺少右括号", t.text)
+			}
+		}
+		if len(args) != arity {
+			return nil, fmt.Errorf("函数 %s 需要%d个参数，实际%d个", t.text, arity, len(args))
+		}
+		if err := validateExprArgs(t.text, args); err != nil {
+			return nil, err
+		}
+		return &callNode{name: t.text, args: args}, nil
+	case "op":
+		if t.text == "(" {
+			node, err := p.parseOr()
+			if err != nil {
+				return nil, err
+			}
+			if _, ok := p.accept(")"); !ok {
+				return nil, fmt.Errorf("缺少右括号")
+			}
+			return node, nil
+		}
+	}
+	return nil, fmt.Errorf("表达式第%d个字符处语法错误: %q", t.pos+1, t.text)
+}
+
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
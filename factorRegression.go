@@ -0,0 +1,291 @@
+package ta
+
+import (
+	"fmt"
+	"math"
+)
+
+// TaFactorRegression 滚动多因子 OLS 回归的计算结果
+// 说明：
+//
+//	在每个长度为 Period 的窗口内用最小二乘拟合 y = Alpha + Σ Beta_j × factor_j + ε，
+//	窗口以当前 K 线为右端（包含），前 Period-1 个位置为 0。
+//
+// 字段：
+//   - Names: 因子名称
+//   - Alpha: 截距
+//   - Betas: 因子暴露，Betas[j][i] 为第 j 个因子在第 i 根 K 线的 beta
+//   - Residuals: 残差，y − Alpha − Σ Beta_j × factor_j，使用同一窗口的系数（样本内）
+//   - R2: 拟合优度
+//   - Period: 回归窗口长度
+//   - Start: 第一个有效结果的下标，CalculateFactorRegression 为 Period-1，K 线方法因补齐第一根为 Period
+type TaFactorRegression struct {
+	Names     []string    `json:"names"`
+	Alpha     []float64   `json:"alpha"`
+	Betas     [][]float64 `json:"betas"`
+	Residuals []float64   `json:"residuals"`
+	R2        []float64   `json:"r2"`
+	Period    int         `json:"period"`
+	Start     int         `json:"start"`
+}
+
+// solveLinearSystem 用部分主元高斯消元求解 a·x = b，矩阵奇异时返回 false，会修改 a 与 b
+func solveLinearSystem(a [][]float64, b []float64) ([]float64, bool) {
+	m := len(b)
+	for col := 0; col < m; col++ {
+		pivot := col
+		for r := col + 1; r < m; r++ {
+			if math.Abs(a[r][col]) > math.Abs(a[pivot][col]) {
+				pivot = r
+			}
+		}
+		if math.Abs(a[pivot][col]) < 1e-12 {
+			return nil, false
+		}
+		a[col], a[pivot] = a[pivot], a[col]
+		b[col], b[pivot] = b[pivot], b[col]
+		for r := col + 1; r < m; r++ {
+			f := a[r][col] / a[col][col]
+			for c := col; c < m; c++ {
+				a[r][c] -= f * a[col][c]
+			}
+			b[r] -= f * b[col]
+		}
+	}
+	x := make([]float64, m)
+	for r := m - 1; r >= 0; r-- {
+		sum := b[r]
+		for c := r + 1; c < m; c++ {
+			sum -= a[r][c] * x[c]
+		}
+		x[r] = sum / a[r][r]
+	}
+	return x, true
+}
+
+// CalculateFactorRegression 计算收益率对多个因子序列的滚动 OLS 回归
+// 参数：
+//   - returns: 被解释的收益率序列，如标的的 SimpleReturns
+//   - factors: 因子序列，factors[j][i]，各序列与 returns 等长且时间对齐，如 BTC 收益、指数收益、资金费率
+//   - names: 因子名称，与 factors 等长
+//   - period: 回归窗口长度，必须大于因子数量 + 1
+//
+// 返回值：
+//   - *TaFactorRegression: 回归结果
+//   - error: 参数无效或数据不足时返回错误
+//
+// 说明/注意事项：
+//
+//	窗口内因子共线（如两个因子完全相同）或方差为 0 时无法求解，该位置的结果为 0。
+//	残差剔除了因子解释的部分，可作为市场中性信号的输入，如用 ResidualZScore 做统计套利。
+//	残差使用包含当前 K 线的窗口拟合，属于样本内残差；对 returns[0] 为 0 的收益率序列，建议从下标 1 开始截取。
+//
+// 示例：
+//
+//	ethReturns, _ := ethKlines.Returns(false)
+//	btcReturns, _ := btcKlines.Returns(false)
+//	reg, err := CalculateFactorRegression(ethReturns[1:], [][]float64{btcReturns[1:], funding[1:]}, []string{"btc", "funding"}, 90)
+//	if err != nil {
+//	    // 处理错误
+//	}
+//	alpha, betas := reg.Value()
+//	if reg.ResidualZScore(20) < -2 {
+//	    // 相对 BTC 超跌
+//	}
+func CalculateFactorRegression(returns []float64, factors [][]float64, names []string, period int) (*TaFactorRegression, error) {
+	count := len(factors)
+	if count == 0 {
+		return nil, fmt.Errorf("因子数量不能为0")
+	}
+	if len(names) != count {
+		return nil, fmt.Errorf("因子名称数量与因子数量不一致")
+	}
+	length := len(returns)
+	for _, factor := range factors {
+		if len(factor) != length {
+			return nil, fmt.Errorf("输入数据长度不一致")
+		}
+	}
+	m := count + 1
+	if period <= m {
+		return nil, fmt.Errorf("窗口长度必须大于因子数量+1")
+	}
+	if length < period {
+		return nil, fmt.Errorf("计算数据不足")
+	}
+
+	result := &TaFactorRegression{
+		Names:     names,
+		Alpha:     make([]float64, length),
+		Betas:     preallocateSlices(length, count),
+		Residuals: make([]float64, length),
+		R2:        make([]float64, length),
+		Period:    period,
+		Start:     period - 1,
+	}
+
+	row := make([]float64, m)
+	row[0] = 1
+	xtx := make([][]float64, m)
+	for p := range xtx {
+		xtx[p] = make([]float64, m)
+	}
+	xty := make([]float64, m)
+
+	for i := period - 1; i < length; i++ {
+		for p := range xtx {
+			for q := range xtx[p] {
+				xtx[p][q] = 0
+			}
+			xty[p] = 0
+		}
+		var sumY, sumYY float64
+		for t := i - period + 1; t <= i; t++ {
+			for j := 0; j < count; j++ {
+				row[j+1] = factors[j][t]
+			}
+			y := returns[t]
+			for p := 0; p < m; p++ {
+				for q := p; q < m; q++ {
+					xtx[p][q] += row[p] * row[q]
+				}
+				xty[p] += row[p] * y
+			}
+			sumY += y
+			sumYY += y * y
+		}
+		for p := 0; p < m; p++ {
+			for q := 0; q < p; q++ {
+				xtx[p][q] = xtx[q][p]
+			}
+		}
+
+		coef, ok := solveLinearSystem(xtx, xty)
+		if !ok {
+			continue
+		}
+
+		// xty 已在消元中被修改，残差平方和按拟合值逐点计算
+		var sse float64
+		for t := i - period + 1; t <= i; t++ {
+			fit := coef[0]
+			for j := 0; j < count; j++ {
+				fit += coef[j+1] * factors[j][t]
+			}
+			e := returns[t] - fit
+			sse += e * e
+			if t == i {
+				result.Residuals[i] = e
+			}
+		}
+		result.Alpha[i] = coef[0]
+		for j := 0; j < count; j++ {
+			result.Betas[j][i] = coef[j+1]
+		}
+		if sst := sumYY - sumY*sumY/float64(period); sst > 0 {
+			result.R2[i] = 1 - sse/sst
+		}
+	}
+
+	return result, nil
+}
+
+// FactorRegression 计算 K 线收盘价收益率对因子序列的滚动 OLS 回归
+// 参数：
+//   - factors: 因子序列，与 K 线等长且时间对齐
+//   - names: 因子名称
+//   - period: 回归窗口长度
+//   - isLog: 是否使用对数收益率
+//
+// 返回值：
+//   - *TaFactorRegression: 回归结果，与 K 线等长；第一根 K 线没有收益率，回归从第二根开始
+//   - error: 提取数据或计算过程中的错误
+func (k *KlineDatas) FactorRegression(factors [][]float64, names []string, period int, isLog bool) (*TaFactorRegression, error) {
+	returns, err := k.Returns(isLog)
+	if err != nil {
+		return nil, err
+	}
+	if len(returns) == 0 {
+		return nil, fmt.Errorf("计算数据不足")
+	}
+	trimmed := make([][]float64, len(factors))
+	for j, factor := range factors {
+		if len(factor) != len(returns) {
+			return nil, fmt.Errorf("输入数据长度不一致")
+		}
+		trimmed[j] = factor[1:]
+	}
+	reg, err := CalculateFactorRegression(returns[1:], trimmed, names, period)
+	if err != nil {
+		return nil, err
+	}
+	pad := func(values []float64) []float64 {
+		return append([]float64{0}, values...)
+	}
+	reg.Alpha, reg.Residuals, reg.R2 = pad(reg.Alpha), pad(reg.Residuals), pad(reg.R2)
+	reg.Start++
+	for j := range reg.Betas {
+		reg.Betas[j] = pad(reg.Betas[j])
+	}
+	return reg, nil
+}
+
+// Value 返回最新的截距和各因子的 beta，beta 顺序与 Names 一致
+func (t *TaFactorRegression) Value() (alpha float64, betas []float64) {
+	lastIndex := len(t.Alpha) - 1
+	betas = make([]float64, len(t.Betas))
+	for j := range t.Betas {
+		betas[j] = t.Betas[j][lastIndex]
+	}
+	return t.Alpha[lastIndex], betas
+}
+
+// Beta 返回指定因子的 beta 序列，因子不存在时返回 nil
+func (t *TaFactorRegression) Beta(name string) []float64 {
+	for j, n := range t.Names {
+		if n == name {
+			return t.Betas[j]
+		}
+	}
+	return nil
+}
+
+// CumulativeResiduals 返回残差的累计和，即剔除因子影响后的特质价格路径（对数收益近似）
+func (t *TaFactorRegression) CumulativeResiduals() []float64 {
+	cumulative := make([]float64, len(t.Residuals))
+	var sum float64
+	for i, r := range t.Residuals {
+		sum += r
+		cumulative[i] = sum
+	}
+	return cumulative
+}
+
+// ResidualZScore 返回最新累计残差相对最近 lookback 个累计残差的 Z 分数，窗口含预热期（Start 之前）、数据不足或标准差为 0 时返回 0
+// 说明：
+//
+//	Z 分数为负表示标的相对因子组合超跌，为正表示超涨，可作为均值回归的市场中性信号。
+func (t *TaFactorRegression) ResidualZScore(lookback int) float64 {
+	cumulative := t.CumulativeResiduals()
+	lastIndex := len(cumulative) - 1
+	if lookback < 2 || lastIndex-lookback+1 < t.Start {
+		return 0
+	}
+	var sum, sumSquares float64
+	for i := lastIndex - lookback + 1; i <= lastIndex; i++ {
+		sum += cumulative[i]
+		sumSquares += cumulative[i] * cumulative[i]
+	}
+	mean := sum / float64(lookback)
+	variance := sumSquares/float64(lookback) - mean*mean
+	if variance <= 0 {
+		return 0
+	}
+	return (cumulative[lastIndex] - mean) / math.Sqrt(variance)
+}
+
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
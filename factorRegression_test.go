@@ -0,0 +1,62 @@
+package ta
+
+import (
+	"math"
+	"testing"
+)
+
+func TestCalculateFactorRegression(t *testing.T) {
+	// returns = 0.01 + 2 × factor，回归应精确还原系数且残差为 0
+	factor := []float64{0.01, -0.02, 0.03, 0.005, -0.01, 0.02, -0.015, 0.025}
+	returns := make([]float64, len(factor))
+	for i, f := range factor {
+		returns[i] = 0.01 + 2*f
+	}
+	reg, err := CalculateFactorRegression(returns, [][]float64{factor}, []string{"btc"}, 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if reg.Start != 3 {
+		t.Errorf("Start = %d, want 3", reg.Start)
+	}
+	for i := reg.Start; i < len(returns); i++ {
+		if math.Abs(reg.Alpha[i]-0.01) > 1e-9 || math.Abs(reg.Betas[0][i]-2) > 1e-9 || math.Abs(reg.Residuals[i]) > 1e-9 {
+			t.Errorf("位置 %d = (alpha %v, beta %v, residual %v), want (0.01, 2, 0)", i, reg.Alpha[i], reg.Betas[0][i], reg.Residuals[i])
+		}
+	}
+}
+
+func TestFactorRegressionResidualZScoreWarmup(t *testing.T) {
+	closes := []float64{100, 101, 99, 102, 103, 101, 104, 106, 105, 107}
+	factor := []float64{0, 0.02, -0.01, 0.025, 0.005, -0.03, 0.02, 0.01, -0.02, 0.015}
+	klineData := make(KlineDatas, len(closes))
+	for i, c := range closes {
+		klineData[i] = &KlineData{StartTime: int64(i), Open: c, High: c, Low: c, Close: c}
+	}
+	reg, err := klineData.FactorRegression([][]float64{factor}, []string{"btc"}, 4, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 补齐第一根后第一个有效残差的下标为 Period
+	if reg.Start != 4 {
+		t.Fatalf("Start = %d, want 4", reg.Start)
+	}
+
+	tests := []struct {
+		name     string
+		lookback int
+		valid    bool
+	}{
+		{"全部有效", len(closes) - 4, true},
+		{"包含一个预热残差", len(closes) - 3, false},
+		{"窗口过短", 1, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			z := reg.ResidualZScore(tt.lookback)
+			if (z != 0) != tt.valid {
+				t.Errorf("ResidualZScore(%d) = %v, want valid=%v", tt.lookback, z, tt.valid)
+			}
+		})
+	}
+}
@@ -0,0 +1,135 @@
+package ta
+
+import (
+	"fmt"
+)
+
+// TaForceIndex 强力指数（Force Index）的计算结果
+// 字段：
+//   - Values: 强力指数，(收盘价 − 前收盘价) × 成交量；Period > 1 时为其 EMA 平滑值
+//   - Period: EMA 平滑周期，1 表示原始值
+//
+// 说明：
+//
+//	预热期为 0，前 Period 个位置无效。
+type TaForceIndex struct {
+	Values []float64 `json:"values"`
+	Period int       `json:"period"`
+}
+
+// CalculateForceIndex 计算强力指数
+// 参数：
+//   - close: 收盘价序列
+//   - volume: 成交量序列
+//   - period: EMA 平滑周期，Elder 使用 2（短线入场）和 13（趋势确认），1 表示不平滑
+//
+// 返回值：
+//   - *TaForceIndex: 强力指数结果
+//   - error: 参数无效或数据不足时返回错误
+//
+// 说明/注意事项：
+//
+//	同时反映价格变化的方向、幅度和成交量，放量上涨时为较大的正值。
+//
+// 示例：
+//
+//	fi, err := CalculateForceIndex(closes, volumes, 13)
+//	if err != nil {
+//	    // 处理错误
+//	}
+//	if fi.IsCrossAboveZero() {
+//	    // 多头力量重新占优
+//	}
+func CalculateForceIndex(close, volume []float64, period int) (*TaForceIndex, error) {
+	if period <= 0 {
+		return nil, fmt.Errorf("周期必须大于0")
+	}
+	length := len(close)
+	if len(volume) != length {
+		return nil, fmt.Errorf("输入数据长度不一致")
+	}
+	if length < period+1 {
+		return nil, fmt.Errorf("计算数据不足")
+	}
+
+	raw := make([]float64, length)
+	for i := 1; i < length; i++ {
+		raw[i] = (close[i] - close[i-1]) * volume[i]
+	}
+
+	values := raw
+	if period > 1 {
+		ema, err := CalculateEMA(raw[1:], period)
+		if err != nil {
+			return nil, err
+		}
+		values = make([]float64, length)
+		copy(values[1:], ema.Values)
+	}
+
+	return &TaForceIndex{
+		Values: values,
+		Period: period,
+	}, nil
+}
+
+// ForceIndex 从 KlineDatas 中提取数据并计算强力指数
+// 参数：
+//   - period: EMA 平滑周期，1 表示不平滑
+//
+// 返回值：
+//   - *TaForceIndex: 强力指数结果
+//   - error: 提取数据或计算过程中的错误
+func (k *KlineDatas) ForceIndex(period int) (*TaForceIndex, error) {
+	close, err := k.ExtractSlice("close")
+	if err != nil {
+		return nil, err
+	}
+	volume, err := k.ExtractSlice("volume")
+	if err != nil {
+		return nil, err
+	}
+	return CalculateForceIndex(close, volume, period)
+}
+
+// ForceIndex_ 计算并返回最新的强力指数，数据不足时返回 0
+func (k *KlineDatas) ForceIndex_(period int) float64 {
+	_k, err := k.Keep(quickKeep("forceindex", period))
+	if err != nil {
+		_k = *k
+	}
+	fi, err := _k.ForceIndex(period)
+	if err != nil {
+		return 0
+	}
+	return fi.Value()
+}
+
+// Value 返回最新的强力指数
+func (t *TaForceIndex) Value() float64 {
+	return t.Values[len(t.Values)-1]
+}
+
+// IsCrossAboveZero 判断最新一根K线强力指数是否上穿 0 轴（前一根 ≤ 0，当前 > 0）
+func (t *TaForceIndex) IsCrossAboveZero() bool {
+	lastIndex := len(t.Values) - 1
+	if lastIndex-1 < t.Period {
+		return false
+	}
+	return t.Values[lastIndex-1] <= 0 && t.Values[lastIndex] > 0
+}
+
+// IsCrossBelowZero 判断最新一根K线强力指数是否下穿 0 轴（前一根 ≥ 0，当前 < 0）
+func (t *TaForceIndex) IsCrossBelowZero() bool {
+	lastIndex := len(t.Values) - 1
+	if lastIndex-1 < t.Period {
+		return false
+	}
+	return t.Values[lastIndex-1] >= 0 && t.Values[lastIndex] < 0
+}
+
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
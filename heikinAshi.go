@@ -0,0 +1,118 @@
+package ta
+
+import (
+	"math"
+)
+
+// 平均 K 线（Heikin Ashi）的强弱分类
+const (
+	HAStrongBear = -2 // 强势阴线：实体为阴且没有上影线
+	HAWeakBear   = -1 // 弱势阴线：实体为阴且有上影线
+	HANeutral    = 0  // 十字线：实体占振幅的比例过小，方向不明
+	HAWeakBull   = 1  // 弱势阳线：实体为阳且有下影线
+	HAStrongBull = 2  // 强势阳线：实体为阳且没有下影线
+)
+
+// HeikinAshi 将 K 线转换为平均 K 线（Heikin Ashi）
+// 返回值：
+//   - KlineDatas: 与输入等长的新 K 线，开始时间和成交量不变，没有数据时返回 nil
+//
+// 说明/注意事项：
+//
+//	HA 收盘 = (O+H+L+C)/4；HA 开盘 = (前一根 HA 开盘 + 前一根 HA 收盘)/2，第一根为 (O+C)/2；
+//	HA 最高 = max(H, HA 开盘, HA 收盘)；HA 最低 = min(L, HA 开盘, HA 收盘)。
+//	HA 开盘价依赖全部历史，数据起点不同时前若干根的结果会略有差异。
+//	平均 K 线的价格不是实际成交价，用于趋势判断和平滑指标输入，不应作为下单价格。
+//
+// 示例：
+//
+//	ha := klineData.HeikinAshi()
+//	st, err := ha.SuperTrend(10, 3)
+//	classes := HeikinAshiClasses(ha, 0.05)
+func (k KlineDatas) HeikinAshi() KlineDatas {
+	if len(k) == 0 {
+		return nil
+	}
+	result := make(KlineDatas, len(k))
+	var prevOpen, prevClose float64
+	for i, kline := range k {
+		haClose := (kline.Open + kline.High + kline.Low + kline.Close) / 4
+		haOpen := (kline.Open + kline.Close) / 2
+		if i > 0 {
+			haOpen = (prevOpen + prevClose) / 2
+		}
+		result[i] = &KlineData{
+			StartTime: kline.StartTime,
+			Open:      haOpen,
+			High:      math.Max(kline.High, math.Max(haOpen, haClose)),
+			Low:       math.Min(kline.Low, math.Min(haOpen, haClose)),
+			Close:     haClose,
+			Volume:    kline.Volume,
+		}
+		prevOpen, prevClose = haOpen, haClose
+	}
+	return result
+}
+
+// ClassifyHeikinAshi 判断一根平均 K 线的强弱
+// 参数：
+//   - candle: 平均 K 线
+//   - tolerance: 影线占振幅的比例不超过该值时视为没有影线，实体占振幅的比例不超过该值时视为十字线，如 0.05
+//
+// 返回值：
+//   - int: HAStrongBull、HAWeakBull、HANeutral、HAWeakBear 或 HAStrongBear
+//
+// 说明/注意事项：
+//
+//	没有下影线的阳线说明买方持续占优，是趋势延续的信号；出现反向影线或十字线通常意味着趋势减弱。
+func ClassifyHeikinAshi(candle *KlineData, tolerance float64) int {
+	r := candle.High - candle.Low
+	body := candle.Close - candle.Open
+	if r <= 0 || math.Abs(body) <= tolerance*r {
+		return HANeutral
+	}
+	if body > 0 {
+		if candle.Open-candle.Low <= tolerance*r {
+			return HAStrongBull
+		}
+		return HAWeakBull
+	}
+	if candle.High-candle.Open <= tolerance*r {
+		return HAStrongBear
+	}
+	return HAWeakBear
+}
+
+// HeikinAshiClasses 返回每根平均 K 线的强弱分类，参数含义同 ClassifyHeikinAshi
+func HeikinAshiClasses(ha KlineDatas, tolerance float64) []int {
+	classes := make([]int, len(ha))
+	for i, candle := range ha {
+		classes[i] = ClassifyHeikinAshi(candle, tolerance)
+	}
+	return classes
+}
+
+// HeikinAshiStreak 返回截至最后一根连续同向强势平均 K 线的数量，强势阳线为正、强势阴线为负，最后一根不是强势 K 线时返回 0
+// 参数：
+//   - ha: 平均 K 线，可由 HeikinAshi 得到
+//   - tolerance: 同 ClassifyHeikinAshi
+func HeikinAshiStreak(ha KlineDatas, tolerance float64) int {
+	streak := 0
+	for i := len(ha) - 1; i >= 0; i-- {
+		switch c := ClassifyHeikinAshi(ha[i], tolerance); {
+		case c == HAStrongBull && streak >= 0:
+			streak++
+		case c == HAStrongBear && streak <= 0:
+			streak--
+		default:
+			return streak
+		}
+	}
+	return streak
+}
+
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
@@ -0,0 +1,212 @@
+package ta
+
+import (
+	"fmt"
+	"math"
+)
+
+// hilbertWarmup 希尔伯特变换的预热长度，与 TA-Lib 的 HT_TRENDMODE 一致
+const hilbertWarmup = 63
+
+// TaHilbert 希尔伯特变换主导周期指标组的计算结果
+// 说明：
+//
+//	基于 Ehlers 的 MESA 同差法测量价格的主导循环周期，并派生出
+//	瞬时趋势线、循环相位、正弦波和趋势/循环模式，对应 TA-Lib 的
+//	HT_DCPERIOD、HT_DCPHASE、HT_SINE、HT_TRENDLINE、HT_TRENDMODE。
+//	预热期（前 63 个位置）的值为 0。
+//
+// 字段：
+//   - Period: 平滑后的主导周期
+//   - Phase: 主导循环相位（度）
+//   - Sine: 相位正弦值
+//   - LeadSine: 超前 45 度的正弦值
+//   - Trendline: 瞬时趋势线
+//   - TrendMode: 1 表示趋势模式，0 表示循环模式
+type TaHilbert struct {
+	Period    []float64 `json:"period"`
+	Phase     []float64 `json:"phase"`
+	Sine      []float64 `json:"sine"`
+	LeadSine  []float64 `json:"lead_sine"`
+	Trendline []float64 `json:"trendline"`
+	TrendMode []int     `json:"trend_mode"`
+}
+
+// hilbertTransform 四阶希尔伯特变换 FIR 近似
+func hilbertTransform(s []float64, i int, period float64) float64 {
+	return (0.0962*s[i] + 0.5769*s[i-2] - 0.5769*s[i-4] - 0.0962*s[i-6]) * (0.075*period + 0.54)
+}
+
+// CalculateHilbert 计算希尔伯特变换主导周期指标组
+// 参数：
+//   - prices: 价格序列，通常使用 hl2
+//
+// 返回值：
+//   - *TaHilbert: 计算结果
+//   - error: 数据不足（少于 64 个）时返回错误
+//
+// 示例：
+//
+//	ht, err := CalculateHilbert(hl2)
+//	period := ht.Period[len(ht.Period)-1]
+func CalculateHilbert(prices []float64) (*TaHilbert, error) {
+	if len(prices) <= hilbertWarmup {
+		return nil, fmt.Errorf("计算数据不足")
+	}
+
+	length := len(prices)
+	slices := preallocateSlices(length, 15)
+	smooth, detrender, i1, q1 := slices[0], slices[1], slices[2], slices[3]
+	i2, q2, re, im := slices[4], slices[5], slices[6], slices[7]
+	period, smoothPeriod, phase, sine := slices[8], slices[9], slices[10], slices[11]
+	leadSine, iTrend, trendline := slices[12], slices[13], slices[14]
+	trendMode := make([]int, length)
+
+	daysInTrend := 0
+	for i := 0; i < length; i++ {
+		if i < 3 {
+			smooth[i] = prices[i]
+			iTrend[i] = prices[i]
+			continue
+		}
+		smooth[i] = (4*prices[i] + 3*prices[i-1] + 2*prices[i-2] + prices[i-3]) / 10
+		if i < 6 {
+			iTrend[i] = prices[i]
+			continue
+		}
+
+		prevPeriod := period[i-1]
+		detrender[i] = hilbertTransform(smooth, i, prevPeriod)
+		q1[i] = hilbertTransform(detrender, i, prevPeriod)
+		i1[i] = detrender[i-3]
+
+		jI := hilbertTransform(i1, i, prevPeriod)
+		jQ := hilbertTransform(q1, i, prevPeriod)
+
+		i2[i] = 0.2*(i1[i]-jQ) + 0.8*i2[i-1]
+		q2[i] = 0.2*(q1[i]+jI) + 0.8*q2[i-1]
+
+		re[i] = 0.2*(i2[i]*i2[i-1]+q2[i]*q2[i-1]) + 0.8*re[i-1]
+		im[i] = 0.2*(i2[i]*q2[i-1]-q2[i]*i2[i-1]) + 0.8*im[i-1]
+
+		p := prevPeriod
+		if im[i] != 0 && re[i] != 0 {
+			p = 2 * math.Pi / math.Atan(im[i]/re[i])
+		}
+		if prevPeriod > 0 {
+			p = math.Min(p, 1.5*prevPeriod)
+			p = math.Max(p, 0.67*prevPeriod)
+		}
+		p = math.Max(6, math.Min(50, p))
+		period[i] = 0.2*p + 0.8*prevPeriod
+		smoothPeriod[i] = 0.33*period[i] + 0.67*smoothPeriod[i-1]
+
+		// 主导循环相位
+		dcPeriod := int(smoothPeriod[i] + 0.5)
+		if dcPeriod < 1 {
+			dcPeriod = 1
+		}
+		var realPart, imagPart float64
+		for k := 0; k < dcPeriod && i-k >= 0; k++ {
+			angle := 2 * math.Pi * float64(k) / float64(dcPeriod)
+			realPart += math.Sin(angle) * smooth[i-k]
+			imagPart += math.Cos(angle) * smooth[i-k]
+		}
+		ph := phase[i-1]
+		if math.Abs(imagPart) > 0 {
+			ph = math.Atan(realPart/imagPart) * 180 / math.Pi
+		} else if realPart != 0 {
+			ph = math.Copysign(90, realPart)
+		}
+		ph += 90
+		ph += 360 / smoothPeriod[i]
+		if imagPart < 0 {
+			ph += 180
+		}
+		if ph > 315 {
+			ph -= 360
+		}
+		phase[i] = ph
+		sine[i] = math.Sin(ph * math.Pi / 180)
+		leadSine[i] = math.Sin((ph + 45) * math.Pi / 180)
+
+		// 瞬时趋势线
+		var sum float64
+		count := 0
+		for k := 0; k < dcPeriod && i-k >= 0; k++ {
+			sum += prices[i-k]
+			count++
+		}
+		iTrend[i] = sum / float64(count)
+		trendline[i] = (4*iTrend[i] + 3*iTrend[i-1] + 2*iTrend[i-2] + iTrend[i-3]) / 10
+
+		// 趋势/循环模式
+		trend := 1
+		if (sine[i] > leadSine[i] && sine[i-1] <= leadSine[i-1]) ||
+			(sine[i] < leadSine[i] && sine[i-1] >= leadSine[i-1]) {
+			daysInTrend = 0
+			trend = 0
+		}
+		daysInTrend++
+		if float64(daysInTrend) < 0.5*smoothPeriod[i] {
+			trend = 0
+		}
+		delta := phase[i] - phase[i-1]
+		if smoothPeriod[i] != 0 && delta > 0.67*360/smoothPeriod[i] && delta < 1.5*360/smoothPeriod[i] {
+			trend = 0
+		}
+		if trendline[i] != 0 && math.Abs((smooth[i]-trendline[i])/trendline[i]) >= 0.015 {
+			trend = 1
+		}
+		trendMode[i] = trend
+	}
+
+	for i := 0; i < hilbertWarmup; i++ {
+		smoothPeriod[i], phase[i], sine[i], leadSine[i], trendline[i] = 0, 0, 0, 0, 0
+		trendMode[i] = 0
+	}
+
+	return &TaHilbert{
+		Period:    smoothPeriod,
+		Phase:     phase,
+		Sine:      sine,
+		LeadSine:  leadSine,
+		Trendline: trendline,
+		TrendMode: trendMode,
+	}, nil
+}
+
+// Hilbert 从 KlineDatas 中提取数据计算希尔伯特变换主导周期指标组
+// 参数：
+//   - source: 数据源，推荐 "hl2"
+//
+// 返回值：
+//   - *TaHilbert: 计算结果
+//   - error: 提取数据或计算过程中的错误
+func (k *KlineDatas) Hilbert(source string) (*TaHilbert, error) {
+	prices, err := k.ExtractSlice(source)
+	if err != nil {
+		return nil, err
+	}
+	return CalculateHilbert(prices)
+}
+
+// Value 返回最新的主导周期和趋势模式
+// 返回值：
+//   - period: 主导周期
+//   - isTrend: 是否处于趋势模式
+func (t *TaHilbert) Value() (period float64, isTrend bool) {
+	lastIndex := len(t.Period) - 1
+	return t.Period[lastIndex], t.TrendMode[lastIndex] == 1
+}
+
+// DominantPeriod 返回最新主导周期取整后的值，可直接作为自适应指标的周期
+func (t *TaHilbert) DominantPeriod() int {
+	return int(t.Period[len(t.Period)-1] + 0.5)
+}
+
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
@@ -0,0 +1,108 @@
+package ta
+
+import (
+	"fmt"
+	"math"
+	"sort"
+)
+
+// 插值方式
+const (
+	// InterpolatePrevious 取不晚于目标时间的最近一个值（前值填充）
+	InterpolatePrevious = iota
+	// InterpolateLinear 在相邻两个时间点之间线性插值
+	InterpolateLinear
+)
+
+// SampleAt 在任意时间点上对指标序列取样
+// 参数：
+//   - times: 指标序列对应的时间戳，必须严格升序
+//   - values: 指标序列，长度与 times 一致
+//   - at: 需要取样的时间戳，顺序任意
+//   - mode: 插值方式，InterpolatePrevious 或 InterpolateLinear
+//
+// 返回值：
+//   - []float64: 与 at 等长的取样结果，早于第一个时间戳的位置为 NaN
+//   - error: 输入长度不一致、时间戳未升序或插值方式无效时返回错误
+//
+// 说明/注意事项：
+//
+//	晚于最后一个时间戳的位置取最后一个值。
+//	K 线指标的值在收盘后才确定，跨周期对齐时应传入收盘时间（开始时间加周期）
+//	并使用 InterpolatePrevious，以避免引入未来数据。
+//
+// 示例：
+//
+//	hourlyRsi, _ := hourly.RSI(14, "close")
+//	aligned, err := SampleAt(hourlyCloseTimes, hourlyRsi.Values, minuteTimes, InterpolatePrevious)
+func SampleAt(times []int64, values []float64, at []int64, mode int) ([]float64, error) {
+	if len(times) != len(values) {
+		return nil, fmt.Errorf("输入数据长度不一致")
+	}
+	if len(times) == 0 {
+		return nil, fmt.Errorf("计算数据不足")
+	}
+	if mode != InterpolatePrevious && mode != InterpolateLinear {
+		return nil, fmt.Errorf("无效的插值方式: %d", mode)
+	}
+	for i := 1; i < len(times); i++ {
+		if times[i] <= times[i-1] {
+			return nil, fmt.Errorf("时间戳必须严格升序，第%d个位置出错", i+1)
+		}
+	}
+
+	out := make([]float64, len(at))
+	last := len(times) - 1
+	for j, t := range at {
+		// 第一个大于 t 的位置
+		idx := sort.Search(len(times), func(i int) bool { return times[i] > t })
+		switch {
+		case idx == 0:
+			out[j] = math.NaN()
+		case idx > last || mode == InterpolatePrevious || times[idx-1] == t:
+			out[j] = values[idx-1]
+		default:
+			t0, t1 := times[idx-1], times[idx]
+			w := float64(t-t0) / float64(t1-t0)
+			out[j] = values[idx-1] + (values[idx]-values[idx-1])*w
+		}
+	}
+	return out, nil
+}
+
+// SampleAt 以当前 K 线的收盘时间（开始时间加周期）为时间轴，在任意时间点上对指标序列取样
+// 参数：
+//   - values: 与当前 K 线等长的指标序列
+//   - interval: 当前 K 线的周期（毫秒），可由 ParseInterval 得到
+//   - at: 需要取样的时间戳
+//   - mode: 插值方式
+//
+// 返回值：
+//   - []float64: 与 at 等长的取样结果，早于第一根 K 线收盘的位置为 NaN
+//   - error: 周期无效或取样过程中的错误
+//
+// 说明/注意事项：
+//
+//	每个值在所属 K 线收盘后才可见，配合 InterpolatePrevious 时不会把大周期收盘才确定的值提前到该 K 线开始时。
+//
+// 示例：
+//
+//	hour, _ := ParseInterval("1h")
+//	hourlyRsi, _ := hourly.RSI(14, "close")
+//	aligned, err := hourly.SampleAt(hourlyRsi.Values, hour, minuteCloseTimes, InterpolatePrevious)
+func (k *KlineDatas) SampleAt(values []float64, interval int64, at []int64, mode int) ([]float64, error) {
+	if interval <= 0 {
+		return nil, fmt.Errorf("周期必须大于0")
+	}
+	times := make([]int64, len(*k))
+	for i, kline := range *k {
+		times[i] = kline.StartTime + interval
+	}
+	return SampleAt(times, values, at, mode)
+}
+
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
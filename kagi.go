@@ -0,0 +1,161 @@
+package ta
+
+import (
+	"fmt"
+	"math"
+)
+
+// KagiSegment 卡吉图的一段竖线
+// 字段：
+//   - Direction: 1 为上升线，-1 为下降线
+//   - Start: 起点价格
+//   - End: 终点价格（上升线的最高点或下降线的最低点）
+//   - Yang: 结束时是否为阳线（粗线）
+//   - StartIndex: 开始时的原始 K 线下标
+//   - EndIndex: 最后一次延伸时的原始 K 线下标
+type KagiSegment struct {
+	Direction  int     `json:"direction"`
+	Start      float64 `json:"start"`
+	End        float64 `json:"end"`
+	Yang       bool    `json:"yang"`
+	StartIndex int     `json:"start_index"`
+	EndIndex   int     `json:"end_index"`
+}
+
+// TaKagi 卡吉图（Kagi）的计算结果
+// 字段：
+//   - Segments: 各段竖线，按时间顺序排列
+//   - Signals: 阴阳转换信号，价格突破前一个肩部（前一段上升线的高点）由阴转阳为买入，跌破前一个腰部由阳转阴为卖出
+//   - Reversal: 转向幅度
+//   - Percent: Reversal 是否为百分比
+type TaKagi struct {
+	Segments []KagiSegment `json:"segments"`
+	Signals  []ChartSignal `json:"signals"`
+	Reversal float64       `json:"reversal"`
+	Percent  bool          `json:"percent"`
+
+	bars int
+}
+
+// CalculateKagi 将 K 线收盘价转换为卡吉图
+// 参数：
+//   - klineData: K 线数据
+//   - reversal: 转向幅度，价格从当前段的端点反向变动不少于该幅度时转向
+//   - percent: 为 true 时 reversal 为端点价格的百分比，如 4 表示 4%
+//
+// 返回值：
+//   - *TaKagi: 卡吉图结果
+//   - error: 参数无效、数据不足或价格波动不足以形成一段时返回错误
+//
+// 说明/注意事项：
+//
+//	第一段的阴阳由方向决定（上升为阳）。此后价格高于前一个肩部时转为阳线，低于前一个腰部时转为阴线，
+//	阴阳转换即为信号，比单纯的转向更能过滤震荡。
+//
+// 示例：
+//
+//	kagi, err := CalculateKagi(klineData, 4, true)
+//	if err != nil {
+//	    // 处理错误
+//	}
+//	if kagi.IsBuySignal() {
+//	    // 最新一根 K 线由阴转阳
+//	}
+func CalculateKagi(klineData KlineDatas, reversal float64, percent bool) (*TaKagi, error) {
+	if reversal <= 0 {
+		return nil, fmt.Errorf("转向幅度必须大于0")
+	}
+	length := len(klineData)
+	if length < 2 {
+		return nil, fmt.Errorf("计算数据不足")
+	}
+	threshold := func(p float64) float64 {
+		if percent {
+			return math.Abs(p) * reversal / 100
+		}
+		return reversal
+	}
+
+	result := &TaKagi{Reversal: reversal, Percent: percent, bars: length}
+	base := klineData[0].Close
+	yang := false
+	// shoulder/waist 为最近一个已完成的上升段高点与下降段低点，NaN 表示尚不存在
+	shoulder, waist := math.NaN(), math.NaN()
+
+	for i := 1; i < length; i++ {
+		price := klineData[i].Close
+		n := len(result.Segments)
+		if n == 0 {
+			if math.Abs(price-base) >= threshold(base) {
+				direction := 1
+				if price < base {
+					direction = -1
+				}
+				yang = direction == 1
+				result.Segments = append(result.Segments, KagiSegment{
+					Direction: direction, Start: base, End: price, Yang: yang, StartIndex: i, EndIndex: i,
+				})
+			}
+			continue
+		}
+
+		s := &result.Segments[n-1]
+		switch {
+		case (s.Direction == 1 && price > s.End) || (s.Direction == -1 && price < s.End):
+			s.End, s.EndIndex = price, i
+		case math.Abs(price-s.End) >= threshold(s.End):
+			if s.Direction == 1 {
+				shoulder = s.End
+			} else {
+				waist = s.End
+			}
+			result.Segments = append(result.Segments, KagiSegment{
+				Direction: -s.Direction, Start: s.End, End: price, Yang: yang, StartIndex: i, EndIndex: i,
+			})
+			s = &result.Segments[n]
+		default:
+			continue
+		}
+
+		switch {
+		case !yang && !math.IsNaN(shoulder) && price > shoulder:
+			yang = true
+			result.Signals = append(result.Signals, ChartSignal{Index: i, StartTime: klineData[i].StartTime, Direction: 1, Price: shoulder})
+		case yang && !math.IsNaN(waist) && price < waist:
+			yang = false
+			result.Signals = append(result.Signals, ChartSignal{Index: i, StartTime: klineData[i].StartTime, Direction: -1, Price: waist})
+		}
+		s.Yang = yang
+	}
+
+	if len(result.Segments) == 0 {
+		return nil, fmt.Errorf("价格波动不足以形成卡吉图")
+	}
+	return result, nil
+}
+
+// Kagi 将 K 线转换为卡吉图，参数含义同 CalculateKagi
+func (k *KlineDatas) Kagi(reversal float64, percent bool) (*TaKagi, error) {
+	return CalculateKagi(*k, reversal, percent)
+}
+
+// Value 返回当前是否为阳线（粗线）
+func (t *TaKagi) Value() bool {
+	return t.Segments[len(t.Segments)-1].Yang
+}
+
+// IsBuySignal 判断最新一根K线是否由阴转阳
+func (t *TaKagi) IsBuySignal() bool {
+	return lastChartSignal(t.Signals, t.bars) == 1
+}
+
+// IsSellSignal 判断最新一根K线是否由阳转阴
+func (t *TaKagi) IsSellSignal() bool {
+	return lastChartSignal(t.Signals, t.bars) == -1
+}
+
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
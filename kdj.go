@@ -132,6 +132,19 @@ func (k *KlineDatas) KDJ(rsvPeriod, kPeriod, dPeriod int) (*TaKDJ, error) {
 	return CalculateKDJ(high, low, close, rsvPeriod, kPeriod, dPeriod)
 }
 
+// KDJ_ 计算并返回最新的 K、D、J 值，数据不足时返回 0
+func (k *KlineDatas) KDJ_(rsvPeriod, kPeriod, dPeriod int) (kValue, dValue, jValue float64) {
+	_k, err := k.Keep(quickKeep("kdj", rsvPeriod, kPeriod, dPeriod))
+	if err != nil {
+		_k = *k
+	}
+	kdj, err := _k.KDJ(rsvPeriod, kPeriod, dPeriod)
+	if err != nil {
+		return 0, 0, 0
+	}
+	return kdj.Value()
+}
+
 // Value 获取 TaKDJ 结构体中 K、D、J 线的最后一个值
 // 返回值：
 //   - k: K 线的最后一个值
@@ -0,0 +1,166 @@
+package ta
+
+import (
+	"fmt"
+	"math"
+)
+
+// KellyFraction 计算完整凯利仓位比例
+// 参数：
+//   - p: 盈利概率，取值 [0, 1]
+//   - payoff: 盈亏比（盈利幅度 / 亏损幅度）
+//
+// 返回值：
+//   - float64: 凯利比例 f* = p − (1−p)/payoff，可能为负（表示不应下注）
+func KellyFraction(p, payoff float64) float64 {
+	if payoff <= 0 {
+		return -1
+	}
+	return p - (1-p)/payoff
+}
+
+// KellySizer 基于校准概率的仓位计算器
+// 说明：
+//
+//	完整凯利对概率误差非常敏感，实践中通常使用分数凯利并设置上限。
+//	分数凯利 Fraction 近似等价于风险厌恶系数为 1/Fraction 的 CRRA 效用最优解。
+//	Size 返回的是风险比例，即触发止损时亏损占权益的比例，而非名义价值比例；
+//	名义价值比例为风险比例除以止损距离占价格的比例，可用 Notional 或 QuantityWithStop 换算。
+//
+// 字段：
+//   - Fraction: 凯利乘数，如 0.5 表示半凯利
+//   - MaxFraction: 单笔风险比例的上限
+//   - MinFraction: 风险比例低于该值时不开仓
+type KellySizer struct {
+	Fraction    float64 `json:"fraction"`
+	MaxFraction float64 `json:"max_fraction"`
+	MinFraction float64 `json:"min_fraction"`
+}
+
+// NewKellySizer 创建仓位计算器
+// 参数：
+//   - fraction: 凯利乘数，取值 (0, 1]
+//   - maxFraction: 单笔风险比例上限，如 0.02 表示每笔最多亏损权益的 2%
+//
+// 返回值：
+//   - *KellySizer: 仓位计算器
+//   - error: 参数无效时返回错误
+func NewKellySizer(fraction, maxFraction float64) (*KellySizer, error) {
+	if fraction <= 0 || fraction > 1 {
+		return nil, fmt.Errorf("凯利乘数必须在(0, 1]之间")
+	}
+	if maxFraction <= 0 {
+		return nil, fmt.Errorf("仓位上限必须大于0")
+	}
+	return &KellySizer{Fraction: fraction, MaxFraction: maxFraction}, nil
+}
+
+// Size 计算单笔风险占权益的比例
+// 参数：
+//   - p: 盈利概率
+//   - payoff: 盈亏比
+//
+// 返回值：
+//   - float64: 风险比例，取值 [0, MaxFraction]，触发止损时亏损为权益乘以该比例
+func (s *KellySizer) Size(p, payoff float64) float64 {
+	f := KellyFraction(p, payoff) * s.Fraction
+	if f <= 0 || f < s.MinFraction {
+		return 0
+	}
+	if f > s.MaxFraction {
+		return s.MaxFraction
+	}
+	return f
+}
+
+// SizeWithCosts 扣除交易成本后计算风险比例
+// 参数：
+//   - p: 盈利概率
+//   - winReturn: 盈利时的收益比例（如止盈距离）
+//   - lossReturn: 亏损时的亏损比例（如止损距离），传正数
+//   - costs: 交易成本
+//
+// 返回值：
+//   - float64: 风险比例，取值 [0, MaxFraction]
+//
+// 说明/注意事项：
+//
+//	净盈亏比 = (win − 成本) / (loss + 成本)，成本吞噬全部盈利时返回 0。
+func (s *KellySizer) SizeWithCosts(p, winReturn, lossReturn float64, costs TradingCosts) float64 {
+	cost := costs.RoundTrip()
+	netWin := winReturn - cost
+	netLoss := lossReturn + cost
+	if netWin <= 0 || netLoss <= 0 {
+		return 0
+	}
+	return s.Size(p, netWin/netLoss)
+}
+
+// Notional 将风险比例换算为持仓名义价值占权益的比例
+// 参数：
+//   - price: 开仓价格
+//   - stop: 止损价格
+//   - p: 盈利概率
+//   - payoff: 盈亏比
+//
+// 返回值：
+//   - float64: 名义价值比例，可直接传给 PaperTrader.Signal，价格无效或止损与开仓价相同时返回 0
+//
+// 说明/注意事项：
+//
+//	名义价值比例 = 风险比例 × 价格 / |价格 − 止损|，止损越近比例越大，可能超过 1（需要杠杆）。
+func (s *KellySizer) Notional(price, stop, p, payoff float64) float64 {
+	distance := math.Abs(price - stop)
+	if price <= 0 || stop <= 0 || distance == 0 {
+		return 0
+	}
+	return s.Size(p, payoff) * price / distance
+}
+
+// Quantity 将 Size 直接作为名义价值比例换算为下单数量
+// 参数：
+//   - equity: 账户权益
+//   - price: 开仓价格
+//   - p: 盈利概率
+//   - payoff: 盈亏比
+//
+// 返回值：
+//   - float64: 下单数量，价格无效时返回 0
+//
+// Deprecated: Size 是风险比例，直接按名义价值换算会忽略止损距离，止损较近时仓位偏小、较远时偏大；
+// 请改用 QuantityWithStop。保留该方法只为兼容已有调用，结果与之前的版本相同。
+func (s *KellySizer) Quantity(equity, price, p, payoff float64) float64 {
+	if price <= 0 || equity <= 0 {
+		return 0
+	}
+	return equity * s.Size(p, payoff) / price
+}
+
+// QuantityWithStop 按止损距离将风险比例换算为下单数量
+// 参数：
+//   - equity: 账户权益
+//   - price: 开仓价格
+//   - stop: 止损价格
+//   - p: 盈利概率
+//   - payoff: 盈亏比
+//
+// 返回值：
+//   - float64: 下单数量，数量 × |价格 − 止损| 等于权益 × 风险比例；参数无效时返回 0
+//
+// 示例：
+//
+//	sizer, _ := NewKellySizer(0.5, 0.02)
+//	// 权益 10000，100 开仓，95 止损，胜率 55%，盈亏比 2
+//	qty := sizer.QuantityWithStop(10000, 100, 95, 0.55, 2) // 风险比例封顶 2%，亏损 200，数量 40
+func (s *KellySizer) QuantityWithStop(equity, price, stop, p, payoff float64) float64 {
+	if equity <= 0 {
+		return 0
+	}
+	return equity * s.Notional(price, stop, p, payoff) / price
+}
+
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
@@ -0,0 +1,35 @@
+package ta
+
+import (
+	"math"
+	"testing"
+)
+
+func TestKellySizerQuantity(t *testing.T) {
+	sizer, err := NewKellySizer(0.5, 0.02)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name string
+		got  float64
+		want float64
+	}{
+		// 风险比例封顶 2%，止损距离 5，亏损 200 对应数量 40
+		{"按止损距离", sizer.QuantityWithStop(10000, 100, 95, 0.55, 2), 40},
+		{"空头止损", sizer.QuantityWithStop(10000, 100, 105, 0.55, 2), 40},
+		{"止损与开仓价相同", sizer.QuantityWithStop(10000, 100, 100, 0.55, 2), 0},
+		{"权益非正", sizer.QuantityWithStop(0, 100, 95, 0.55, 2), 0},
+		// 兼容旧行为：Size 直接作为名义价值比例
+		{"旧版 Quantity", sizer.Quantity(10000, 100, 0.55, 2), 2},
+		{"旧版 Quantity 价格无效", sizer.Quantity(10000, 0, 0.55, 2), 0},
+		{"没有优势", sizer.QuantityWithStop(10000, 100, 95, 0.3, 1), 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if math.Abs(tt.got-tt.want) > 1e-9 {
+				t.Errorf("got %v, want %v", tt.got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,131 @@
+package ta
+
+import (
+	"fmt"
+	"sort"
+)
+
+// 水平价位事件类型
+const (
+	// LevelBreak 突破：收盘价越过价位且超出 mult×ATR
+	LevelBreak = iota
+	// LevelRetest 回踩：突破后 retestBars 根 K 线内影线触及价位，收盘仍在突破一侧
+	LevelRetest
+	// LevelReclaim 收复：反向突破后 retestBars 根 K 线内重新站回原来一侧（假突破）
+	LevelReclaim
+)
+
+// LevelEvent 水平价位的一次突破、回踩或收复事件
+// 字段：
+//   - Index: 事件发生的 K 线下标
+//   - Level: 价位
+//   - Type: 事件类型，LevelBreak、LevelRetest 或 LevelReclaim
+//   - Direction: 方向，1 表示价格位于价位上方（向上突破/回踩支撑/收复上方），-1 相反
+type LevelEvent struct {
+	Index     int     `json:"index"`
+	Level     float64 `json:"level"`
+	Type      int     `json:"type"`
+	Direction int     `json:"direction"`
+}
+
+// DetectLevelEvents 检测价格相对一组水平价位的突破、回踩和收复事件
+// 参数：
+//   - klineData: K 线数据
+//   - levels: 支撑/阻力价位，可来自摆动点或用户指定
+//   - atr: 与 K 线等长的 ATR 序列，用于确认幅度；ATR 为 0 的位置（预热期）被跳过
+//   - mult: 确认幅度的 ATR 倍数，收盘价须超出价位 mult×ATR 才算突破，为 0 时只要求收盘越过价位
+//   - retestBars: 突破后判断回踩和收复的 K 线数量
+//
+// 返回值：
+//   - []LevelEvent: 按 K 线下标排序的事件，同一根 K 线按价位升序
+//   - error: 参数无效时返回错误
+//
+// 说明/注意事项：
+//
+//	每个价位独立跟踪价格所在的一侧，初始一侧由第一根有效 K 线的收盘价决定。
+//	收盘价落在价位 ±mult×ATR 之内时不改变所在一侧。
+//	反向突破发生在上一次突破后的 retestBars 根 K 线内时记为 LevelReclaim，否则记为 LevelBreak。
+//	每次突破最多产生一次回踩事件。只使用当根及之前的数据，可用于实时告警。
+//
+// 示例：
+//
+//	atr, _ := klineData.ATR(14)
+//	events, err := DetectLevelEvents(klineData, []float64{42000, 45000}, atr.Values, 0.5, 10)
+func DetectLevelEvents(klineData KlineDatas, levels, atr []float64, mult float64, retestBars int) ([]LevelEvent, error) {
+	if len(atr) != len(klineData) {
+		return nil, fmt.Errorf("输入数据长度不一致")
+	}
+	if mult < 0 || retestBars < 0 {
+		return nil, fmt.Errorf("确认倍数和回踩K线数量不能为负数")
+	}
+
+	sorted := append([]float64(nil), levels...)
+	sort.Float64s(sorted)
+
+	type levelState struct {
+		side     int
+		lastTurn int
+		retested bool
+	}
+	states := make([]levelState, len(sorted))
+	for j := range states {
+		states[j].lastTurn = -1
+	}
+
+	var events []LevelEvent
+	for i, kline := range klineData {
+		if atr[i] == 0 && mult > 0 {
+			continue
+		}
+		band := mult * atr[i]
+		for j, level := range sorted {
+			s := &states[j]
+			if s.side == 0 {
+				s.side = 1
+				if kline.Close < level {
+					s.side = -1
+				}
+				continue
+			}
+
+			switch {
+			case s.side < 0 && kline.Close > level+band, s.side > 0 && kline.Close < level-band:
+				typ := LevelBreak
+				if s.lastTurn >= 0 && i-s.lastTurn <= retestBars {
+					typ = LevelReclaim
+				}
+				s.side = -s.side
+				s.lastTurn, s.retested = i, false
+				events = append(events, LevelEvent{Index: i, Level: level, Type: typ, Direction: s.side})
+			case s.lastTurn >= 0 && !s.retested && i-s.lastTurn <= retestBars:
+				touched := (s.side > 0 && kline.Low <= level && kline.Close > level) ||
+					(s.side < 0 && kline.High >= level && kline.Close < level)
+				if touched {
+					s.retested = true
+					events = append(events, LevelEvent{Index: i, Level: level, Type: LevelRetest, Direction: s.side})
+				}
+			}
+		}
+	}
+	return events, nil
+}
+
+// LevelEvents 计算 ATR 并检测水平价位事件，参数含义同 DetectLevelEvents
+// 参数：
+//   - levels: 支撑/阻力价位
+//   - atrPeriod: ATR 周期
+//   - mult: 确认幅度的 ATR 倍数
+//   - retestBars: 突破后判断回踩和收复的 K 线数量
+func (k *KlineDatas) LevelEvents(levels []float64, atrPeriod int, mult float64, retestBars int) ([]LevelEvent, error) {
+	atr, err := k.ATR(atrPeriod)
+	if err != nil {
+		return nil, err
+	}
+	return DetectLevelEvents(*k, levels, atr.Values, mult, retestBars)
+}
+
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
@@ -0,0 +1,177 @@
+package ta
+
+import (
+	"fmt"
+	"math"
+)
+
+// TaLinReg 滚动线性回归（LSMA）及回归通道
+// 说明：
+//
+//	对每个长度为 Period 的窗口以最小二乘拟合直线，取直线在窗口末端的值作为 LSMA，
+//	通道宽度为 ±K 倍回归标准误差。预热期（前 Period-1 个位置）为 0。
+//
+// 字段：
+//   - Values: LSMA，拟合直线在当前 K 线处的值
+//   - Slope: 拟合直线的斜率（每根 K 线的价格变化）
+//   - R2: 拟合优度 R²，取值 0..1，越接近 1 趋势越线性
+//   - StdErr: 回归标准误差 √(残差平方和 / (Period−2))
+//   - Upper: Values + K × StdErr
+//   - Lower: Values − K × StdErr
+//   - Period: 回归窗口长度
+//   - K: 通道的标准误差倍数
+type TaLinReg struct {
+	Values []float64 `json:"values"`
+	Slope  []float64 `json:"slope"`
+	R2     []float64 `json:"r2"`
+	StdErr []float64 `json:"std_err"`
+	Upper  []float64 `json:"upper"`
+	Lower  []float64 `json:"lower"`
+	Period int       `json:"period"`
+	K      float64   `json:"k"`
+}
+
+// CalculateLinReg 计算滚动线性回归及回归通道
+// 参数：
+//   - prices: 价格序列
+//   - period: 回归窗口长度，必须不小于3
+//   - k: 通道的标准误差倍数，如 2
+//
+// 返回值：
+//   - *TaLinReg: 计算结果
+//   - error: 数据不足或周期无效时返回错误
+//
+// 示例：
+//
+//	lr, err := CalculateLinReg(closes, 50, 2)
+//	if err != nil {
+//	    // 处理错误
+//	}
+//	lsma, slope, r2 := lr.Value()
+func CalculateLinReg(prices []float64, period int, k float64) (*TaLinReg, error) {
+	if period < 3 {
+		return nil, fmt.Errorf("周期必须不小于3")
+	}
+	if len(prices) < period {
+		return nil, fmt.Errorf("计算数据不足")
+	}
+
+	length := len(prices)
+	slices := preallocateSlices(length, 6)
+	values, slope, r2, stdErr, upper, lower := slices[0], slices[1], slices[2], slices[3], slices[4], slices[5]
+
+	// 窗口内横坐标固定为 0..period-1
+	n := float64(period)
+	meanX := (n - 1) / 2
+	var sxx float64
+	for x := 0; x < period; x++ {
+		sxx += (float64(x) - meanX) * (float64(x) - meanX)
+	}
+
+	for i := period - 1; i < length; i++ {
+		window := prices[i-period+1 : i+1]
+		var meanY float64
+		for _, y := range window {
+			meanY += y
+		}
+		meanY /= n
+		var sxy, syy float64
+		for x, y := range window {
+			dx, dy := float64(x)-meanX, y-meanY
+			sxy += dx * dy
+			syy += dy * dy
+		}
+
+		b := sxy / sxx
+		a := meanY - b*meanX
+		sse := syy - b*sxy
+		if sse < 0 {
+			sse = 0
+		}
+
+		values[i] = a + b*(n-1)
+		slope[i] = b
+		if syy > 0 {
+			r2[i] = 1 - sse/syy
+		}
+		stdErr[i] = math.Sqrt(sse / (n - 2))
+		upper[i] = values[i] + k*stdErr[i]
+		lower[i] = values[i] - k*stdErr[i]
+	}
+
+	return &TaLinReg{
+		Values: values,
+		Slope:  slope,
+		R2:     r2,
+		StdErr: stdErr,
+		Upper:  upper,
+		Lower:  lower,
+		Period: period,
+		K:      k,
+	}, nil
+}
+
+// LinReg 从 KlineDatas 中提取数据并计算滚动线性回归
+// 参数：
+//   - period: 回归窗口长度
+//   - mult: 通道的标准误差倍数
+//   - source: 数据源，如 "close"
+//
+// 返回值：
+//   - *TaLinReg: 计算结果
+//   - error: 提取数据或计算过程中的错误
+func (k *KlineDatas) LinReg(period int, mult float64, source string) (*TaLinReg, error) {
+	prices, err := k.ExtractSlice(source)
+	if err != nil {
+		return nil, err
+	}
+	return CalculateLinReg(prices, period, mult)
+}
+
+// LinReg_ 计算并返回最新的 LSMA、斜率和 R²，数据不足时返回 0
+func (k *KlineDatas) LinReg_(period int, source string) (lsma, slope, r2 float64) {
+	_k, err := k.Keep(quickKeep("linreg", period))
+	if err != nil {
+		_k = *k
+	}
+	lr, err := _k.LinReg(period, 2, source)
+	if err != nil {
+		return 0, 0, 0
+	}
+	return lr.Value()
+}
+
+// Value 返回最新的 LSMA、斜率和 R²
+func (t *TaLinReg) Value() (lsma, slope, r2 float64) {
+	lastIndex := len(t.Values) - 1
+	return t.Values[lastIndex], t.Slope[lastIndex], t.R2[lastIndex]
+}
+
+// SlopePercent 返回最新斜率相对 LSMA 的百分比，便于跨品种比较趋势陡峭程度
+func (t *TaLinReg) SlopePercent() float64 {
+	lsma, slope, _ := t.Value()
+	if lsma == 0 {
+		return 0
+	}
+	return slope / lsma * 100
+}
+
+// IsTrendUp 判断最新回归斜率为正且 R² 不低于 minR2
+// 参数：
+//   - minR2: 最低拟合优度，如 0.5；传 0 时只判断斜率方向
+func (t *TaLinReg) IsTrendUp(minR2 float64) bool {
+	_, slope, r2 := t.Value()
+	return slope > 0 && r2 >= minR2
+}
+
+// IsTrendDown 判断最新回归斜率为负且 R² 不低于 minR2
+func (t *TaLinReg) IsTrendDown(minR2 float64) bool {
+	_, slope, r2 := t.Value()
+	return slope < 0 && r2 >= minR2
+}
+
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
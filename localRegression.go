@@ -0,0 +1,226 @@
+package ta
+
+import (
+	"fmt"
+	"math"
+)
+
+// TaLocalRegression 滚动局部多项式回归（Savitzky-Golay / LOESS）的计算结果
+// 说明：
+//
+//	每个位置对截至当前的 Window 个数据加权拟合 Degree 次多项式，取多项式在当前 K 线处的值和导数，
+//	只使用当前及之前的数据。预热期（前 Window-1 个位置）为 0。
+//
+// 字段：
+//   - Values: 平滑值
+//   - Velocity: 一阶导数，每根 K 线的价格变化，Degree < 1 时为 0
+//   - Acceleration: 二阶导数，每根 K 线斜率的变化，Degree < 2 时为 0
+//   - Window: 窗口长度
+//   - Degree: 多项式次数
+type TaLocalRegression struct {
+	Values       []float64 `json:"values"`
+	Velocity     []float64 `json:"velocity"`
+	Acceleration []float64 `json:"acceleration"`
+	Window       int       `json:"window"`
+	Degree       int       `json:"degree"`
+}
+
+// localRegressionCoeffs 返回加权多项式最小二乘在窗口末端的值、一阶导数和二阶导数对应的卷积系数
+func localRegressionCoeffs(weights []float64, degree int) ([3][]float64, error) {
+	var coeffs [3][]float64
+	n, m := len(weights), degree+1
+	xs := make([]float64, n)
+	for j := range xs {
+		xs[j] = float64(j - (n - 1))
+	}
+
+	// 增广矩阵 [XᵀWX | I]，Gauss-Jordan 消元求逆
+	a := make([][]float64, m)
+	for p := range a {
+		a[p] = make([]float64, 2*m)
+		for q := 0; q < m; q++ {
+			for j, x := range xs {
+				a[p][q] += weights[j] * math.Pow(x, float64(p+q))
+			}
+		}
+		a[p][m+p] = 1
+	}
+	for col := 0; col < m; col++ {
+		pivot := col
+		for r := col + 1; r < m; r++ {
+			if math.Abs(a[r][col]) > math.Abs(a[pivot][col]) {
+				pivot = r
+			}
+		}
+		if math.Abs(a[pivot][col]) < 1e-12 {
+			return coeffs, fmt.Errorf("窗口长度不足以拟合%d次多项式", degree)
+		}
+		a[col], a[pivot] = a[pivot], a[col]
+		div := a[col][col]
+		for c := range a[col] {
+			a[col][c] /= div
+		}
+		for r := 0; r < m; r++ {
+			if r == col || a[r][col] == 0 {
+				continue
+			}
+			f := a[r][col]
+			for c := range a[r] {
+				a[r][c] -= f * a[col][c]
+			}
+		}
+	}
+
+	// 第 p 个多项式系数为 Σ_j (A⁻¹)_p · w_j x_j^q · y_j，x=0 处的 k 阶导数为 k! × 第 k 个系数
+	for d := 0; d < 3; d++ {
+		coeffs[d] = make([]float64, n)
+		if d > degree {
+			continue
+		}
+		factorial := 1.0
+		if d == 2 {
+			factorial = 2
+		}
+		for j, x := range xs {
+			var c float64
+			for q := 0; q < m; q++ {
+				c += a[d][m+q] * weights[j] * math.Pow(x, float64(q))
+			}
+			coeffs[d][j] = factorial * c
+		}
+	}
+	return coeffs, nil
+}
+
+// calculateLocalRegression 按给定窗口权重计算滚动局部多项式回归
+func calculateLocalRegression(prices, weights []float64, degree int) (*TaLocalRegression, error) {
+	window := len(weights)
+	if degree < 0 || degree > 4 {
+		return nil, fmt.Errorf("多项式次数必须在0到4之间")
+	}
+	if window <= degree {
+		return nil, fmt.Errorf("窗口长度必须大于多项式次数")
+	}
+	if len(prices) < window {
+		return nil, fmt.Errorf("计算数据不足")
+	}
+	coeffs, err := localRegressionCoeffs(weights, degree)
+	if err != nil {
+		return nil, err
+	}
+
+	length := len(prices)
+	slices := preallocateSlices(length, 3)
+	for i := window - 1; i < length; i++ {
+		start := i - window + 1
+		for d, out := range slices {
+			var sum float64
+			for j, c := range coeffs[d] {
+				sum += c * prices[start+j]
+			}
+			out[i] = sum
+		}
+	}
+
+	return &TaLocalRegression{
+		Values:       slices[0],
+		Velocity:     slices[1],
+		Acceleration: slices[2],
+		Window:       window,
+		Degree:       degree,
+	}, nil
+}
+
+// CalculateSavitzkyGolay 计算因果 Savitzky-Golay 平滑及导数
+// 参数：
+//   - prices: 价格序列
+//   - window: 窗口长度，如 21
+//   - degree: 多项式次数，0 到 4，常用 2 或 3
+//
+// 返回值：
+//   - *TaLocalRegression: 平滑值、速度与加速度
+//   - error: 参数无效或数据不足时返回错误
+//
+// 说明/注意事项：
+//
+//	窗口内等权拟合多项式，相当于一个固定系数的卷积；次数越高越贴近价格但越容易受噪声影响。
+//	与对 EMA 做差分相比，导数由拟合多项式直接解析得到，不会放大逐根噪声，更适合作为机器学习特征。
+//
+// 示例：
+//
+//	sg, err := CalculateSavitzkyGolay(closes, 21, 2)
+//	value, velocity, acceleration := sg.Value()
+func CalculateSavitzkyGolay(prices []float64, window, degree int) (*TaLocalRegression, error) {
+	if window <= 0 {
+		return nil, fmt.Errorf("窗口长度必须大于0")
+	}
+	weights := make([]float64, window)
+	for j := range weights {
+		weights[j] = 1
+	}
+	return calculateLocalRegression(prices, weights, degree)
+}
+
+// CalculateLOESS 计算因果 LOESS（局部加权回归）平滑及导数
+// 参数：
+//   - prices: 价格序列
+//   - window: 窗口长度，如 30
+//   - degree: 多项式次数，0 到 4，常用 1 或 2
+//
+// 返回值：
+//   - *TaLocalRegression: 平滑值、速度与加速度
+//   - error: 参数无效或数据不足时返回错误
+//
+// 说明/注意事项：
+//
+//	窗口内按距当前 K 线的距离使用 tricube 权重 (1 − (d/window)³)³，越近的数据权重越大，
+//	因此比等权的 Savitzky-Golay 更快跟随最新价格。为保持因果性只使用单侧窗口，未做稳健性迭代。
+func CalculateLOESS(prices []float64, window, degree int) (*TaLocalRegression, error) {
+	if window <= 0 {
+		return nil, fmt.Errorf("窗口长度必须大于0")
+	}
+	weights := make([]float64, window)
+	for j := range weights {
+		d := float64(window-1-j) / float64(window)
+		weights[j] = math.Pow(1-d*d*d, 3)
+	}
+	return calculateLocalRegression(prices, weights, degree)
+}
+
+// SavitzkyGolay 从 KlineDatas 中提取数据并计算 Savitzky-Golay 平滑
+// 参数：
+//   - window: 窗口长度
+//   - degree: 多项式次数
+//   - source: 数据源，如 "close"
+func (k *KlineDatas) SavitzkyGolay(window, degree int, source string) (*TaLocalRegression, error) {
+	prices, err := k.ExtractSlice(source)
+	if err != nil {
+		return nil, err
+	}
+	return CalculateSavitzkyGolay(prices, window, degree)
+}
+
+// LOESS 从 KlineDatas 中提取数据并计算 LOESS 平滑
+// 参数：
+//   - window: 窗口长度
+//   - degree: 多项式次数
+//   - source: 数据源，如 "close"
+func (k *KlineDatas) LOESS(window, degree int, source string) (*TaLocalRegression, error) {
+	prices, err := k.ExtractSlice(source)
+	if err != nil {
+		return nil, err
+	}
+	return CalculateLOESS(prices, window, degree)
+}
+
+// Value 返回最新的平滑值、速度和加速度
+func (t *TaLocalRegression) Value() (value, velocity, acceleration float64) {
+	lastIndex := len(t.Values) - 1
+	return t.Values[lastIndex], t.Velocity[lastIndex], t.Acceleration[lastIndex]
+}
+
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
@@ -0,0 +1,178 @@
+package ta
+
+import (
+	"fmt"
+	"math"
+)
+
+// LookaheadIssue 未来函数检查发现的问题
+// 字段：
+//   - Column: 特征列名
+//   - Index: 问题出现的位置，相关性检查时为 -1
+//   - Reason: 问题描述
+//   - Score: 相关系数或数值偏差
+type LookaheadIssue struct {
+	Column string  `json:"column"`
+	Index  int     `json:"index"`
+	Reason string  `json:"reason"`
+	Score  float64 `json:"score"`
+}
+
+// FeatureFunc 从 K 线生成特征矩阵的函数，返回 rows[i][j] 为第 i 根 K 线的第 j 个特征
+type FeatureFunc func(klineData KlineDatas) ([][]float64, error)
+
+// CheckLookaheadCorrelation 通过与下一根收益的相关性检查特征矩阵中的未来函数
+// 参数：
+//   - features: 特征矩阵，features[i][j] 为第 i 根 K 线的第 j 个特征
+//   - names: 特征列名，长度与特征数一致
+//   - closes: 收盘价序列，长度与特征矩阵行数一致
+//   - threshold: 相关系数绝对值阈值，如 0.3
+//
+// 返回值：
+//   - []LookaheadIssue: 与下一根收益相关性超过阈值的列
+//   - error: 输入维度不一致时返回错误
+//
+// 说明/注意事项：
+//
+//	正常特征与未来一根收益的相关性通常很弱（|ρ| < 0.1），
+//	明显偏高往往说明特征使用了 i 之后的数据。该检查是启发式的，应配合 CheckLookaheadPrefix 使用。
+func CheckLookaheadCorrelation(features [][]float64, names []string, closes []float64, threshold float64) ([]LookaheadIssue, error) {
+	if len(features) != len(closes) {
+		return nil, fmt.Errorf("特征行数(%d)与收盘价数量(%d)不一致", len(features), len(closes))
+	}
+	if len(features) < 3 {
+		return nil, fmt.Errorf("计算数据不足")
+	}
+
+	rows := len(features) - 1
+	forward := make([]float64, rows)
+	for i := 0; i < rows; i++ {
+		if closes[i] != 0 {
+			forward[i] = closes[i+1]/closes[i] - 1
+		}
+	}
+
+	var issues []LookaheadIssue
+	column := make([]float64, rows)
+	for j, name := range names {
+		for i := 0; i < rows; i++ {
+			if j >= len(features[i]) {
+				return nil, fmt.Errorf("第%d行特征数量不足%d个", i+1, len(names))
+			}
+			column[i] = features[i][j]
+		}
+		corr := pearson(column, forward)
+		if math.Abs(corr) > threshold {
+			issues = append(issues, LookaheadIssue{
+				Column: name,
+				Index:  -1,
+				Reason: "与下一根收益高度相关",
+				Score:  corr,
+			})
+		}
+	}
+	return issues, nil
+}
+
+// CheckLookaheadPrefix 通过截断数据重新计算检查特征是否使用了未来数据
+// 参数：
+//   - klineData: K 线数据
+//   - names: 特征列名
+//   - fn: 特征生成函数
+//   - samples: 抽查的位置数量，均匀分布在后半段数据中
+//   - tolerance: 允许的数值偏差
+//
+// 返回值：
+//   - []LookaheadIssue: 截断前后特征值不一致的列（每列只报告第一次出现的位置）
+//   - error: 特征生成失败时返回错误
+//
+// 说明/注意事项：
+//
+//	对位置 i，用 klineData[:i+1] 重新生成特征并与全量计算的第 i 行比较。
+//	没有未来函数的特征只依赖 i 及之前的数据，两者应完全一致。
+//
+// 示例：
+//
+//	issues, err := CheckLookaheadPrefix(klineData, names, buildFeatures, 20, 1e-9)
+func CheckLookaheadPrefix(klineData KlineDatas, names []string, fn FeatureFunc, samples int, tolerance float64) ([]LookaheadIssue, error) {
+	full, err := fn(klineData)
+	if err != nil {
+		return nil, err
+	}
+	if len(full) != len(klineData) {
+		return nil, fmt.Errorf("特征行数(%d)与K线数量(%d)不一致", len(full), len(klineData))
+	}
+	if samples <= 0 {
+		return nil, fmt.Errorf("抽查数量必须大于0")
+	}
+
+	length := len(klineData)
+	start := length / 2
+	step := max(1, float64(length-1-start)/float64(samples))
+
+	reported := make(map[int]bool)
+	var issues []LookaheadIssue
+	for s := 0; s < samples; s++ {
+		i := start + int(float64(s)*step)
+		if i >= length-1 {
+			break
+		}
+		partial, err := fn(klineData[:i+1])
+		if err != nil {
+			return nil, fmt.Errorf("截断至第%d根时生成特征失败: %v", i+1, err)
+		}
+		if len(partial) != i+1 {
+			return nil, fmt.Errorf("截断至第%d根时特征行数(%d)不一致", i+1, len(partial))
+		}
+		for j, name := range names {
+			if reported[j] || j >= len(full[i]) || j >= len(partial[i]) {
+				continue
+			}
+			a, b := full[i][j], partial[i][j]
+			if math.IsNaN(a) && math.IsNaN(b) {
+				continue
+			}
+			if diff := math.Abs(a - b); diff > tolerance || math.IsNaN(diff) {
+				reported[j] = true
+				issues = append(issues, LookaheadIssue{
+					Column: name,
+					Index:  i,
+					Reason: "截断数据后特征值发生变化",
+					Score:  diff,
+				})
+			}
+		}
+	}
+	return issues, nil
+}
+
+// pearson 计算两个等长序列的皮尔逊相关系数，方差为 0 时返回 0
+func pearson(a, b []float64) float64 {
+	n := float64(len(a))
+	if n == 0 {
+		return 0
+	}
+	var sumA, sumB float64
+	for i := range a {
+		sumA += a[i]
+		sumB += b[i]
+	}
+	meanA, meanB := sumA/n, sumB/n
+	var cov, varA, varB float64
+	for i := range a {
+		da, db := a[i]-meanA, b[i]-meanB
+		cov += da * db
+		varA += da * da
+		varB += db * db
+	}
+	if varA == 0 || varB == 0 {
+		return 0
+	}
+	return cov / math.Sqrt(varA*varB)
+}
+
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
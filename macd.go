@@ -110,6 +110,19 @@ func (k *KlineDatas) MACD(source string, shortPeriod, longPeriod, signalPeriod i
 	return CalculateMACD(prices, shortPeriod, longPeriod, signalPeriod)
 }
 
+// MACD_ 计算并返回最新的 MACD 柱、DIF 和 DEA，数据不足时返回 0
+func (k *KlineDatas) MACD_(source string, shortPeriod, longPeriod, signalPeriod int) (macd, dif, dea float64) {
+	_k, err := k.Keep(quickKeep("macd", shortPeriod, longPeriod, signalPeriod))
+	if err != nil {
+		_k = *k
+	}
+	result, err := _k.MACD(source, shortPeriod, longPeriod, signalPeriod)
+	if err != nil {
+		return 0, 0, 0
+	}
+	return result.Value()
+}
+
 // Value 获取 TaMacd 结构体中 MACD、DIF 和 DEA 线的最后一个值
 // 参数：无
 // 返回值：
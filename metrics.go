@@ -0,0 +1,238 @@
+package ta
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+)
+
+// eulerGamma 欧拉-马歇罗尼常数
+const eulerGamma = 0.5772156649015329
+
+// normCDF 标准正态分布的累积分布函数
+func normCDF(x float64) float64 {
+	return 0.5 * (1 + math.Erf(x/math.Sqrt2))
+}
+
+// normInv 标准正态分布的分位数函数
+func normInv(p float64) float64 {
+	return math.Sqrt2 * math.Erfinv(2*p-1)
+}
+
+// moments 计算均值、标准差（样本）、偏度和峰度（非超额）
+func moments(x []float64) (mean, std, skew, kurt float64) {
+	n := float64(len(x))
+	for _, v := range x {
+		mean += v
+	}
+	mean /= n
+	var m2, m3, m4 float64
+	for _, v := range x {
+		d := v - mean
+		m2 += d * d
+		m3 += d * d * d
+		m4 += d * d * d * d
+	}
+	m2 /= n
+	m3 /= n
+	m4 /= n
+	if n > 1 {
+		std = math.Sqrt(m2 * n / (n - 1))
+	}
+	if m2 > 0 {
+		skew = m3 / math.Pow(m2, 1.5)
+		kurt = m4 / (m2 * m2)
+	}
+	return
+}
+
+// SharpeRatio 计算夏普比率
+// 参数：
+//   - returns: 每期收益率（已扣除无风险利率）
+//   - periodsPerYear: 年化因子，传 1 返回每期（未年化）夏普比率
+//
+// 返回值：
+//   - float64: 夏普比率，数据不足或波动为 0 时返回 0
+func SharpeRatio(returns []float64, periodsPerYear float64) float64 {
+	if len(returns) < 2 {
+		return 0
+	}
+	mean, std, _, _ := moments(returns)
+	if std == 0 {
+		return 0
+	}
+	return mean / std * math.Sqrt(periodsPerYear)
+}
+
+// ProbabilisticSharpe 计算概率夏普比率（PSR）
+// 参数：
+//   - returns: 每期收益率
+//   - benchmark: 比较基准的每期（未年化）夏普比率
+//
+// 返回值：
+//   - float64: 真实夏普比率高于基准的概率，考虑了样本长度、偏度和峰度
+//   - error: 数据不足时返回错误
+func ProbabilisticSharpe(returns []float64, benchmark float64) (float64, error) {
+	if len(returns) < 3 {
+		return 0, fmt.Errorf("计算数据不足")
+	}
+	mean, std, skew, kurt := moments(returns)
+	if std == 0 {
+		return 0, fmt.Errorf("收益波动为0")
+	}
+	sr := mean / std
+	denominator := 1 - skew*sr + (kurt-1)/4*sr*sr
+	if denominator <= 0 {
+		return 0, fmt.Errorf("收益分布矩无效")
+	}
+	return normCDF((sr - benchmark) * math.Sqrt(float64(len(returns)-1)) / math.Sqrt(denominator)), nil
+}
+
+// ExpectedMaxSharpe 估计 N 次独立试验中由运气产生的最大夏普比率期望
+// 参数：
+//   - trials: 尝试过的策略变体数量
+//   - variance: 各变体夏普比率（每期、未年化）之间的方差
+//
+// 返回值：
+//   - float64: 最大夏普比率的期望值（每期、未年化）
+func ExpectedMaxSharpe(trials int, variance float64) float64 {
+	if trials < 2 || variance <= 0 {
+		return 0
+	}
+	n := float64(trials)
+	return math.Sqrt(variance) * ((1-eulerGamma)*normInv(1-1/n) + eulerGamma*normInv(1-1/(n*math.E)))
+}
+
+// DeflatedSharpe 计算紧缩夏普比率（DSR）
+// 参数：
+//   - returns: 被选中策略的每期收益率
+//   - trials: 优化过程中尝试过的策略变体数量
+//   - variance: 各变体夏普比率（每期、未年化）之间的方差
+//
+// 返回值：
+//   - float64: 在考虑多重检验后，策略真实夏普比率大于 0 的概率
+//   - error: 数据不足时返回错误
+//
+// 说明/注意事项：
+//
+//	参见 Bailey & López de Prado (2014)。DSR = PSR(SR₀)，SR₀ 为 ExpectedMaxSharpe，
+//	通常 DSR < 0.95 时应怀疑回测结果来自过拟合。
+//
+// 示例：
+//
+//	dsr, err := DeflatedSharpe(bestReturns, 200, srVariance)
+func DeflatedSharpe(returns []float64, trials int, variance float64) (float64, error) {
+	return ProbabilisticSharpe(returns, ExpectedMaxSharpe(trials, variance))
+}
+
+// Bonferroni 对 p 值进行 Bonferroni 多重检验校正
+// 参数：
+//   - pValues: 原始 p 值
+//
+// 返回值：
+//   - []float64: 校正后的 p 值，p × m，上限为 1
+func Bonferroni(pValues []float64) []float64 {
+	m := float64(len(pValues))
+	out := make([]float64, len(pValues))
+	for i, p := range pValues {
+		out[i] = math.Min(1, p*m)
+	}
+	return out
+}
+
+// HolmBonferroni 对 p 值进行 Holm-Bonferroni 逐步校正，比 Bonferroni 更有功效
+// 参数：
+//   - pValues: 原始 p 值
+//
+// 返回值：
+//   - []float64: 校正后的 p 值，顺序与输入一致
+func HolmBonferroni(pValues []float64) []float64 {
+	m := len(pValues)
+	order := make([]int, m)
+	for i := range order {
+		order[i] = i
+	}
+	sort.Slice(order, func(a, b int) bool { return pValues[order[a]] < pValues[order[b]] })
+
+	out := make([]float64, m)
+	var running float64
+	for rank, idx := range order {
+		adjusted := math.Min(1, pValues[idx]*float64(m-rank))
+		running = math.Max(running, adjusted)
+		out[idx] = running
+	}
+	return out
+}
+
+// WhiteRealityCheck White 现实检验（块自助法）
+// 参数：
+//   - returns: 各策略变体相对基准的每期超额收益，returns[s][t]，各序列等长
+//   - bootstraps: 自助抽样次数，如 1000
+//   - blockSize: 块长度，用于保留序列相关性，如 10
+//   - seed: 随机数种子，保证结果可复现
+//
+// 返回值：
+//   - float64: 原假设“最佳策略没有超越基准”的 p 值
+//   - error: 参数无效或数据不足时返回错误
+//
+// 说明/注意事项：
+//
+//	统计量为 max_s √n·mean(returns[s])，自助分布由中心化后的块重抽样得到。
+func WhiteRealityCheck(returns [][]float64, bootstraps, blockSize int, seed int64) (float64, error) {
+	if len(returns) == 0 || len(returns[0]) < 2 {
+		return 0, fmt.Errorf("计算数据不足")
+	}
+	if bootstraps <= 0 || blockSize <= 0 {
+		return 0, fmt.Errorf("自助抽样次数和块长度必须大于0")
+	}
+	n := len(returns[0])
+	for _, r := range returns {
+		if len(r) != n {
+			return 0, fmt.Errorf("输入数据长度不一致")
+		}
+	}
+
+	sqrtN := math.Sqrt(float64(n))
+	means := make([]float64, len(returns))
+	statistic := math.Inf(-1)
+	for s, r := range returns {
+		var sum float64
+		for _, v := range r {
+			sum += v
+		}
+		means[s] = sum / float64(n)
+		statistic = math.Max(statistic, sqrtN*means[s])
+	}
+
+	rng := rand.New(rand.NewSource(seed))
+	indices := make([]int, n)
+	var exceed int
+	for b := 0; b < bootstraps; b++ {
+		for t := 0; t < n; {
+			start := rng.Intn(n)
+			for j := 0; j < blockSize && t < n; j++ {
+				indices[t] = (start + j) % n
+				t++
+			}
+		}
+		best := math.Inf(-1)
+		for s, r := range returns {
+			var sum float64
+			for _, idx := range indices {
+				sum += r[idx]
+			}
+			best = math.Max(best, sqrtN*(sum/float64(n)-means[s]))
+		}
+		if best >= statistic {
+			exceed++
+		}
+	}
+	return float64(exceed) / float64(bootstraps), nil
+}
+
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
@@ -0,0 +1,150 @@
+package ta
+
+import (
+	"fmt"
+)
+
+// TaMomentum 动量指标的计算结果
+// 字段：
+//   - Values: 动量，当前价 − period 根前价格；smooth > 1 时为其 EMA 平滑值
+//   - Period: 比较的 K 线间隔
+//   - Smooth: EMA 平滑周期，0 或 1 表示不平滑
+//
+// 说明：
+//
+//	预热期为 0，不平滑时前 period 个位置、平滑时前 period+smooth-1 个位置无效。
+type TaMomentum struct {
+	Values []float64 `json:"values"`
+	Period int       `json:"period"`
+	Smooth int       `json:"smooth"`
+}
+
+// CalculateMomentum 计算动量指标
+// 参数：
+//   - prices: 价格序列
+//   - period: 比较的 K 线间隔，如 10
+//   - smooth: EMA 平滑周期，0 或 1 表示不平滑
+//
+// 返回值：
+//   - *TaMomentum: 动量结果
+//   - error: 参数无效或数据不足时返回错误
+//
+// 示例：
+//
+//	mom, err := CalculateMomentum(closes, 10, 0)
+//	if err != nil {
+//	    // 处理错误
+//	}
+//	if mom.IsCrossAboveZero() {
+//	    // 动量由负转正
+//	}
+func CalculateMomentum(prices []float64, period, smooth int) (*TaMomentum, error) {
+	if period <= 0 {
+		return nil, fmt.Errorf("周期必须大于0")
+	}
+	if smooth < 0 {
+		return nil, fmt.Errorf("平滑周期不能为负数")
+	}
+	if smooth < 1 {
+		smooth = 1
+	}
+	if len(prices) < period+smooth {
+		return nil, fmt.Errorf("计算数据不足")
+	}
+
+	length := len(prices)
+	raw := make([]float64, length)
+	for i := period; i < length; i++ {
+		raw[i] = prices[i] - prices[i-period]
+	}
+
+	values := raw
+	if smooth > 1 {
+		ema, err := CalculateEMA(raw[period:], smooth)
+		if err != nil {
+			return nil, err
+		}
+		values = make([]float64, length)
+		copy(values[period:], ema.Values)
+	}
+
+	return &TaMomentum{
+		Values: values,
+		Period: period,
+		Smooth: smooth,
+	}, nil
+}
+
+// Momentum 从 KlineDatas 中提取数据并计算动量指标
+// 参数：
+//   - period: 比较的 K 线间隔
+//   - smooth: EMA 平滑周期，0 或 1 表示不平滑
+//   - source: 数据源，如 "close"
+//
+// 返回值：
+//   - *TaMomentum: 动量结果
+//   - error: 提取数据或计算过程中的错误
+func (k *KlineDatas) Momentum(period, smooth int, source string) (*TaMomentum, error) {
+	prices, err := k.ExtractSlice(source)
+	if err != nil {
+		return nil, err
+	}
+	return CalculateMomentum(prices, period, smooth)
+}
+
+// Momentum_ 计算并返回最新的动量值，数据不足时返回 0
+func (k *KlineDatas) Momentum_(period, smooth int, source string) float64 {
+	_k, err := k.Keep(quickKeep("momentum", period, smooth))
+	if err != nil {
+		_k = *k
+	}
+	mom, err := _k.Momentum(period, smooth, source)
+	if err != nil {
+		return 0
+	}
+	return mom.Value()
+}
+
+// Value 返回最新的动量值
+func (t *TaMomentum) Value() float64 {
+	return t.Values[len(t.Values)-1]
+}
+
+// warmup 返回第一个有效值的下标
+func (t *TaMomentum) warmup() int {
+	return t.Period + t.Smooth - 1
+}
+
+// IsBullish 判断最新动量是否为正
+func (t *TaMomentum) IsBullish() bool {
+	return len(t.Values)-1 >= t.warmup() && t.Value() > 0
+}
+
+// IsBearish 判断最新动量是否为负
+func (t *TaMomentum) IsBearish() bool {
+	return len(t.Values)-1 >= t.warmup() && t.Value() < 0
+}
+
+// IsCrossAboveZero 判断最新一根K线动量是否上穿 0 轴（前一根 ≤ 0，当前 > 0）
+func (t *TaMomentum) IsCrossAboveZero() bool {
+	lastIndex := len(t.Values) - 1
+	if lastIndex-1 < t.warmup() {
+		return false
+	}
+	return t.Values[lastIndex-1] <= 0 && t.Values[lastIndex] > 0
+}
+
+// IsCrossBelowZero 判断最新一根K线动量是否下穿 0 轴（前一根 ≥ 0，当前 < 0）
+func (t *TaMomentum) IsCrossBelowZero() bool {
+	lastIndex := len(t.Values) - 1
+	if lastIndex-1 < t.warmup() {
+		return false
+	}
+	return t.Values[lastIndex-1] >= 0 && t.Values[lastIndex] < 0
+}
+
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
@@ -0,0 +1,128 @@
+package ta
+
+import (
+	"fmt"
+	"math"
+)
+
+// 振荡器归一化方式
+const (
+	// NormMinMax 滚动窗口最小-最大值归一化
+	NormMinMax = iota
+	// NormZScore 滚动窗口 Z 分数归一化，超过 ±normZClip 个标准差的部分截断
+	NormZScore
+)
+
+// normZClip Z 分数归一化的截断标准差倍数
+const normZClip = 3.0
+
+// TaNormalized 归一化后的振荡器
+// 字段：
+//   - Values: 归一化结果，预热期（前 Window-1 个位置）为 0
+//   - Window: 滚动窗口长度
+//   - Method: 归一化方式，NormMinMax 或 NormZScore
+//   - Percent: 为 true 时结果范围为 0..100，否则为 -1..1
+type TaNormalized struct {
+	Values  []float64 `json:"values"`
+	Window  int       `json:"window"`
+	Method  int       `json:"method"`
+	Percent bool      `json:"percent"`
+}
+
+// Normalize 将任意振荡器映射到统一的刻度
+// 参数：
+//   - series: 输入序列，如 CCI、MACD 柱或 OBV 斜率
+//   - window: 滚动窗口长度
+//   - method: 归一化方式，NormMinMax 或 NormZScore
+//   - percent: 为 true 时映射到 0..100，否则映射到 -1..1
+//
+// 返回值：
+//   - *TaNormalized: 归一化结果
+//   - error: 参数无效或数据不足时返回错误
+//
+// 说明/注意事项：
+//
+//	每个位置只使用截至当前的窗口数据，不含未来数据。
+//	窗口内数值全部相同时取中性值（-1..1 刻度为 0，0..100 刻度为 50）。
+//	Z 分数方式按 z/3 线性映射并截断到 -1..1。
+//
+// 示例：
+//
+//	cciNorm, err := Normalize(cci.Values, 100, NormZScore, false)
+//	obvSlope := Sub(obv.Values, Shift(obv.Values, 5))
+//	obvNorm, err := Normalize(obvSlope, 100, NormMinMax, false)
+//	score := Scale(Add(cciNorm.Values, obvNorm.Values), 0.5)
+func Normalize(series []float64, window, method int, percent bool) (*TaNormalized, error) {
+	if window < 2 {
+		return nil, fmt.Errorf("窗口长度必须不小于2")
+	}
+	var fn func(w []float64) float64
+	switch method {
+	case NormMinMax:
+		fn = normMinMax
+	case NormZScore:
+		fn = normZScore
+	default:
+		return nil, fmt.Errorf("无效的归一化方式: %d", method)
+	}
+	if percent {
+		symmetric := fn
+		fn = func(w []float64) float64 {
+			return (symmetric(w) + 1) * 50
+		}
+	}
+
+	rolling, err := Rolling(series, window, fn)
+	if err != nil {
+		return nil, err
+	}
+	return &TaNormalized{
+		Values:  rolling.Values,
+		Window:  window,
+		Method:  method,
+		Percent: percent,
+	}, nil
+}
+
+// normMinMax 将窗口最后一个值按窗口的最小最大值映射到 -1..1
+func normMinMax(w []float64) float64 {
+	low, high := w[0], w[0]
+	for _, v := range w {
+		low = math.Min(low, v)
+		high = math.Max(high, v)
+	}
+	if high == low {
+		return 0
+	}
+	return 2*(w[len(w)-1]-low)/(high-low) - 1
+}
+
+// normZScore 将窗口最后一个值的 Z 分数映射并截断到 -1..1
+func normZScore(w []float64) float64 {
+	var mean float64
+	for _, v := range w {
+		mean += v
+	}
+	mean /= float64(len(w))
+	var variance float64
+	for _, v := range w {
+		variance += (v - mean) * (v - mean)
+	}
+	std := math.Sqrt(variance / float64(len(w)-1))
+	if std == 0 {
+		return 0
+	}
+	z := (w[len(w)-1] - mean) / std / normZClip
+	return math.Max(-1, math.Min(1, z))
+}
+
+// Value 返回最新的归一化值
+func (t *TaNormalized) Value() float64 {
+	return t.Values[len(t.Values)-1]
+}
+
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
@@ -0,0 +1,259 @@
+package ta
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"text/template"
+	"time"
+)
+
+// DefaultNotifyTemplate 默认的通知消息模板，字段见 Notification
+const DefaultNotifyTemplate = `[{{.Symbol}}] {{.Event}}
+{{range $name, $value := .Values}}{{$name}} = {{printf "%.6g" $value}}
+{{end}}{{if .ChartURL}}{{.ChartURL}}{{end}}`
+
+// Notification 一条信号通知
+// 字段：
+//   - Symbol: 交易对
+//   - Event: 事件描述，如 "golden 上穿"
+//   - Time: 触发 K 线的开始时间（毫秒）
+//   - Values: 触发时的指标值
+//   - ChartURL: 图表快照链接，可为空
+type Notification struct {
+	Symbol   string             `json:"symbol"`
+	Event    string             `json:"event"`
+	Time     int64              `json:"time"`
+	Values   map[string]float64 `json:"values"`
+	ChartURL string             `json:"chart_url"`
+}
+
+// Notifier 通知渠道
+type Notifier interface {
+	Notify(ctx context.Context, n Notification) error
+}
+
+// renderNotification 按模板生成消息文本，模板为空时使用 DefaultNotifyTemplate
+func renderNotification(text string, n Notification) (string, error) {
+	if text == "" {
+		text = DefaultNotifyTemplate
+	}
+	tmpl, err := template.New("notify").Parse(text)
+	if err != nil {
+		return "", fmt.Errorf("通知模板无效: %v", err)
+	}
+	var buf strings.Builder
+	if err := tmpl.Execute(&buf, n); err != nil {
+		return "", fmt.Errorf("生成通知失败: %v", err)
+	}
+	return buf.String(), nil
+}
+
+// postJSON 发送 JSON 请求，非 2xx 响应返回错误
+func postJSON(ctx context.Context, client *http.Client, endpoint string, payload interface{}) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return stripURL(err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if client == nil {
+		client = &http.Client{Timeout: 10 * time.Second}
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return stripURL(err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("通知发送失败: HTTP %d %s", resp.StatusCode, strings.TrimSpace(string(msg)))
+	}
+	return nil
+}
+
+// stripURL 去掉 *url.Error 中的请求地址，地址中可能含有 Telegram 机器人 token 或 Discord webhook 密钥
+func stripURL(err error) error {
+	var uerr *url.Error
+	if errors.As(err, &uerr) {
+		return fmt.Errorf("通知发送失败: %s: %v", uerr.Op, uerr.Err)
+	}
+	return err
+}
+
+// dispatchNotification 在独立协程中把通知发送到全部渠道，发送失败时调用 onError（可为空）
+func dispatchNotification(notifiers []Notifier, n Notification, timeout time.Duration, onError func(err error)) {
+	for _, notifier := range notifiers {
+		go func(notifier Notifier) {
+			ctx, cancel := context.WithTimeout(context.Background(), timeout)
+			defer cancel()
+			if err := notifier.Notify(ctx, n); err != nil && onError != nil {
+				onError(err)
+			}
+		}(notifier)
+	}
+}
+
+// WebhookNotifier 通用 webhook 通知，以 JSON 发送 Notification 及渲染后的 text 字段
+// 字段：
+//   - URL: webhook 地址
+//   - Template: 消息模板，为空时使用 DefaultNotifyTemplate
+//   - Client: HTTP 客户端，为空时使用 10 秒超时的默认客户端
+type WebhookNotifier struct {
+	URL      string
+	Template string
+	Client   *http.Client
+}
+
+// Notify 发送通知
+func (w *WebhookNotifier) Notify(ctx context.Context, n Notification) error {
+	text, err := renderNotification(w.Template, n)
+	if err != nil {
+		return err
+	}
+	return postJSON(ctx, w.Client, w.URL, struct {
+		Notification
+		Text string `json:"text"`
+	}{n, text})
+}
+
+// TelegramNotifier Telegram 机器人通知
+// 字段：
+//   - Token: 机器人 token
+//   - ChatID: 接收消息的会话 ID
+//   - Template: 消息模板，为空时使用 DefaultNotifyTemplate
+//   - Client: HTTP 客户端，为空时使用 10 秒超时的默认客户端
+type TelegramNotifier struct {
+	Token    string
+	ChatID   string
+	Template string
+	Client   *http.Client
+}
+
+// Notify 发送通知
+func (t *TelegramNotifier) Notify(ctx context.Context, n Notification) error {
+	text, err := renderNotification(t.Template, n)
+	if err != nil {
+		return err
+	}
+	endpoint := "https://api.telegram.org/bot" + url.PathEscape(t.Token) + "/sendMessage"
+	return postJSON(ctx, t.Client, endpoint, map[string]string{"chat_id": t.ChatID, "text": text})
+}
+
+// DiscordNotifier Discord webhook 通知
+// 字段：
+//   - WebhookURL: 频道的 webhook 地址
+//   - Template: 消息模板，为空时使用 DefaultNotifyTemplate
+//   - Client: HTTP 客户端，为空时使用 10 秒超时的默认客户端
+type DiscordNotifier struct {
+	WebhookURL string
+	Template   string
+	Client     *http.Client
+}
+
+// Notify 发送通知
+func (d *DiscordNotifier) Notify(ctx context.Context, n Notification) error {
+	text, err := renderNotification(d.Template, n)
+	if err != nil {
+		return err
+	}
+	return postJSON(ctx, d.Client, d.WebhookURL, map[string]string{"content": text})
+}
+
+// SignalNotifierConfig 信号通知策略的配置
+// 字段：
+//   - Notifiers: 通知渠道，每条通知发送到全部渠道
+//   - Rules: 信号规则，状态翻转时发送通知
+//   - ChartURL: 生成图表快照链接的函数，可为空
+//   - Timeout: 每条通知的发送超时，0 表示 10 秒
+//   - OnError: 发送失败时的回调，可为空
+type SignalNotifierConfig struct {
+	Notifiers []Notifier
+	Rules     []SignalRule
+	ChartURL  func(symbol string, time int64) string
+	Timeout   time.Duration
+	OnError   func(err error)
+}
+
+// SignalNotifier 返回一个引擎策略，在信号规则状态翻转时发送通知
+// 参数：
+//   - config: 通知配置
+//
+// 返回值：
+//   - EngineStrategy: 可通过 Engine.AddStrategy 添加的策略
+//
+// 说明/注意事项：
+//
+//	第一次回调只记录状态不发送通知。通知在独立协程中发送，不会阻塞引擎处理 K 线。
+//	返回的策略可被多个协程并发调用（如引擎接收协程与直接调用 Ingest 的协程），状态比较在锁内进行。
+//	同一根未收盘 K 线的多次更新可能使信号来回翻转，需要只在收盘确认后通知时，可只向引擎推送已收盘的 K 线。
+//
+// 示例：
+//
+//	engine.AddStrategy(SignalNotifier(SignalNotifierConfig{
+//	    Notifiers: []Notifier{&TelegramNotifier{Token: token, ChatID: chatID}},
+//	    Rules:     []SignalRule{{Name: "rsi超卖", Kind: SignalThreshold, A: "rsi14", Level: 30}},
+//	    ChartURL: func(symbol string, _ int64) string {
+//	        return "https://www.tradingview.com/chart/?symbol=BINANCE:" + symbol
+//	    },
+//	}))
+func SignalNotifier(config SignalNotifierConfig) EngineStrategy {
+	timeout := config.Timeout
+	if timeout <= 0 {
+		timeout = 10 * time.Second
+	}
+	var mu sync.Mutex
+	var prev map[string]float64
+	return func(symbol string, klines KlineDatas, values map[string]float64) {
+		mu.Lock()
+		last := prev
+		prev = values
+		mu.Unlock()
+		if last == nil {
+			return
+		}
+		changes, err := ChangedSince(config.Rules, last, values)
+		if err != nil {
+			if config.OnError != nil {
+				config.OnError(err)
+			}
+			return
+		}
+		var barTime int64
+		if len(klines) > 0 {
+			barTime = klines[len(klines)-1].StartTime
+		}
+		for _, change := range changes {
+			direction := "向下"
+			if change.To > 0 {
+				direction = "向上"
+			}
+			n := Notification{
+				Symbol: symbol,
+				Event:  change.Name + " " + direction,
+				Time:   barTime,
+				Values: values,
+			}
+			if config.ChartURL != nil {
+				n.ChartURL = config.ChartURL(symbol, barTime)
+			}
+			dispatchNotification(config.Notifiers, n, timeout, config.OnError)
+		}
+	}
+}
+
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
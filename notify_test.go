@@ -0,0 +1,63 @@
+package ta
+
+import (
+	"context"
+	"sync"
+	"testing"
+)
+
+type countingNotifier struct {
+	mu    sync.Mutex
+	count int
+	done  chan struct{}
+}
+
+func (c *countingNotifier) Notify(_ context.Context, _ Notification) error {
+	c.mu.Lock()
+	c.count++
+	c.mu.Unlock()
+	c.done <- struct{}{}
+	return nil
+}
+
+func TestSignalNotifierConcurrent(t *testing.T) {
+	notifier := &countingNotifier{done: make(chan struct{}, 1000)}
+	strategy := SignalNotifier(SignalNotifierConfig{
+		Notifiers: []Notifier{notifier},
+		Rules:     []SignalRule{{Name: "rsi", Kind: SignalThreshold, A: "rsi", Level: 50}},
+	})
+
+	// 多个协程并发调用，不应出现数据竞争（go test -race）
+	var wg sync.WaitGroup
+	for g := 0; g < 4; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			for i := 0; i < 100; i++ {
+				strategy("BTCUSDT", nil, map[string]float64{"rsi": float64((g + i) % 2 * 100)})
+			}
+		}(g)
+	}
+	wg.Wait()
+}
+
+func TestSignalNotifierFlip(t *testing.T) {
+	notifier := &countingNotifier{done: make(chan struct{}, 10)}
+	strategy := SignalNotifier(SignalNotifierConfig{
+		Notifiers: []Notifier{notifier},
+		Rules:     []SignalRule{{Name: "rsi", Kind: SignalThreshold, A: "rsi", Level: 50}},
+	})
+
+	// 第一次只记录状态，之后只有翻转时通知
+	for _, v := range []float64{40, 45, 60, 70, 30} {
+		strategy("BTCUSDT", nil, map[string]float64{"rsi": v})
+	}
+	for i := 0; i < 2; i++ {
+		<-notifier.done
+	}
+	notifier.mu.Lock()
+	defer notifier.mu.Unlock()
+	if notifier.count != 2 {
+		t.Errorf("通知次数 = %d, want 2", notifier.count)
+	}
+}
@@ -93,6 +93,48 @@ func (t *TaOBV) Value() float64 {
 	return t.Values[len(t.Values)-1]
 }
 
+// Slope 计算最近 n 根 K 线的 OBV 平均变化量
+// 参数：
+//   - n: 回看的 K 线数量
+//
+// 返回值：
+//   - float64: (最新 OBV − n 根前的 OBV) / n，数据不足时返回 0
+func (t *TaOBV) Slope(n int) float64 {
+	lastIndex := len(t.Values) - 1
+	if n <= 0 || lastIndex-n < 0 {
+		return 0
+	}
+	return (t.Values[lastIndex] - t.Values[lastIndex-n]) / float64(n)
+}
+
+// IsTrendUp 判断最近 n 根 K 线 OBV 是否上升且最新值高于区间均值
+// 参数：
+//   - n: 回看的 K 线数量
+//
+// 返回值：
+//   - bool: 斜率为正且最新 OBV 高于最近 n+1 个值的均值时返回 true，数据不足时返回 false
+//
+// 说明/注意事项：
+//
+//	均值条件用于过滤区间内先大幅下跌、末端小幅回升的情况。
+func (t *TaOBV) IsTrendUp(n int) bool {
+	return t.Slope(n) > 0 && t.Value() > t.recentMean(n)
+}
+
+// IsTrendDown 判断最近 n 根 K 线 OBV 是否下降且最新值低于区间均值
+func (t *TaOBV) IsTrendDown(n int) bool {
+	return t.Slope(n) < 0 && t.Value() < t.recentMean(n)
+}
+
+// recentMean 返回最近 n+1 个 OBV 的均值
+func (t *TaOBV) recentMean(n int) float64 {
+	var sum float64
+	for _, v := range t.Values[len(t.Values)-n-1:] {
+		sum += v
+	}
+	return sum / float64(n+1)
+}
+
 // ----------------------------------------------------------------------------
 // ----------------------------------------------------------------------------
 // ----------------------------------------------------------------------------
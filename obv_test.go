@@ -0,0 +1,61 @@
+package ta
+
+import (
+	"math"
+	"testing"
+)
+
+func TestTaOBVSlope(t *testing.T) {
+	// OBV 为 [100, 300, 250, 250, 280]
+	obv, err := CalculateOBV([]float64{10, 11, 10, 10, 12}, []float64{100, 200, 50, 70, 30})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name string
+		n    int
+		want float64
+	}{
+		{"一根", 1, 30},
+		{"两根", 2, 15},
+		{"全部", 4, 45},
+		{"数据不足", 5, 0},
+		{"无效参数", 0, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := obv.Slope(tt.n); math.Abs(got-tt.want) > 1e-12 {
+				t.Errorf("Slope(%d) = %v, want %v", tt.n, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTaOBVTrend(t *testing.T) {
+	tests := []struct {
+		name     string
+		values   []float64
+		n        int
+		wantUp   bool
+		wantDown bool
+	}{
+		{"稳步上升", []float64{100, 300, 250, 250, 280}, 2, true, false},
+		{"末端小幅回升", []float64{100, 500, 400, 110}, 3, false, false},
+		{"回落", []float64{300, 100, 120}, 2, false, true},
+		{"反弹", []float64{300, 100, 120}, 1, true, false},
+		{"数据不足", []float64{100, 200}, 2, false, false},
+		{"无效参数", []float64{100, 200}, 0, false, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			obv := &TaOBV{Values: tt.values}
+			if got := obv.IsTrendUp(tt.n); got != tt.wantUp {
+				t.Errorf("IsTrendUp(%d) = %v, want %v", tt.n, got, tt.wantUp)
+			}
+			if got := obv.IsTrendDown(tt.n); got != tt.wantDown {
+				t.Errorf("IsTrendDown(%d) = %v, want %v", tt.n, got, tt.wantDown)
+			}
+		})
+	}
+}
@@ -0,0 +1,158 @@
+package ta
+
+import (
+	"fmt"
+	"math"
+)
+
+// PaperTrader 轻量级模拟盘持仓与盈亏跟踪器
+// 说明：
+//
+//	按信号调整目标仓位，并随实时收盘价逐笔盯市，
+//	分别累计已实现盈亏、手续费和资金费，适合在不运行完整回测的情况下做前向测试。
+//
+// 字段：
+//   - Costs: 交易成本，成交价按 Spread/2 + Slippage 向不利方向调整，手续费按成交额收取
+//   - InitialEquity: 初始权益
+//   - Position: 当前持仓数量，正数为多头，负数为空头
+//   - EntryPrice: 持仓均价
+//   - Price: 最新盯市价格
+//   - Realized: 已实现盈亏（不含手续费和资金费）
+//   - Fees: 累计手续费
+//   - Funding: 累计支付的资金费，收到资金费时为负
+//   - Trades: 成交次数
+type PaperTrader struct {
+	Costs         TradingCosts `json:"costs"`
+	InitialEquity float64      `json:"initial_equity"`
+	Position      float64      `json:"position"`
+	EntryPrice    float64      `json:"entry_price"`
+	Price         float64      `json:"price"`
+	Realized      float64      `json:"realized"`
+	Fees          float64      `json:"fees"`
+	Funding       float64      `json:"funding"`
+	Trades        int          `json:"trades"`
+}
+
+// NewPaperTrader 创建模拟盘跟踪器
+// 参数：
+//   - equity: 初始权益
+//   - costs: 交易成本
+//
+// 返回值：
+//   - *PaperTrader: 空仓的跟踪器
+//   - error: 初始权益非正时返回错误
+//
+// 示例：
+//
+//	paper, err := NewPaperTrader(10000, TradingCosts{FeeRate: 0.0004, Slippage: 0.0002})
+//	paper.Signal(1, 0.5, close) // 半仓做多
+//	paper.Mark(nextClose)
+//	fmt.Println(paper.Equity(), paper.Unrealized())
+func NewPaperTrader(equity float64, costs TradingCosts) (*PaperTrader, error) {
+	if equity <= 0 {
+		return nil, fmt.Errorf("初始权益必须大于0")
+	}
+	return &PaperTrader{Costs: costs, InitialEquity: equity}, nil
+}
+
+// Target 以指定价格将持仓调整到目标数量
+// 参数：
+//   - qty: 目标持仓数量，正数为多头，负数为空头，0 为平仓
+//   - price: 参考成交价格，如信号 K 线的收盘价
+//
+// 返回值：
+//   - error: 价格非正时返回错误
+func (p *PaperTrader) Target(qty, price float64) error {
+	if price <= 0 {
+		return fmt.Errorf("价格必须大于0")
+	}
+	p.Price = price
+	delta := qty - p.Position
+	if delta == 0 {
+		return nil
+	}
+
+	fill := p.fillPrice(delta, price)
+	p.Fees += math.Abs(delta) * fill * p.Costs.FeeRate
+	p.Trades++
+
+	switch {
+	case p.Position == 0 || (p.Position > 0) == (delta > 0):
+		// 开仓或加仓，按成交量加权更新均价
+		p.EntryPrice = (p.EntryPrice*math.Abs(p.Position) + fill*math.Abs(delta)) / math.Abs(qty)
+	default:
+		// 减仓、平仓或反手
+		closed := math.Min(math.Abs(delta), math.Abs(p.Position))
+		direction := 1.0
+		if p.Position < 0 {
+			direction = -1
+		}
+		p.Realized += closed * (fill - p.EntryPrice) * direction
+		switch {
+		case qty == 0:
+			p.EntryPrice = 0
+		case (qty > 0) != (p.Position > 0):
+			p.EntryPrice = fill
+		}
+	}
+	p.Position = qty
+	return nil
+}
+
+// fillPrice 返回按点差与滑点向不利方向调整后的成交价
+func (p *PaperTrader) fillPrice(delta, price float64) float64 {
+	impact := p.Costs.Spread/2 + p.Costs.Slippage
+	if delta < 0 {
+		return price * (1 - impact)
+	}
+	return price * (1 + impact)
+}
+
+// Signal 按方向和权益比例调整持仓
+// 参数：
+//   - side: 1 做多，-1 做空，0 平仓
+//   - fraction: 持仓名义价值占当前权益的比例，可由 KellySizer.Notional 按止损距离换算
+//   - price: 参考成交价格
+//
+// 返回值：
+//   - error: 价格非正时返回错误
+func (p *PaperTrader) Signal(side int, fraction, price float64) error {
+	if price <= 0 {
+		return fmt.Errorf("价格必须大于0")
+	}
+	p.Mark(price)
+	return p.Target(float64(side)*fraction*p.Equity()/price, price)
+}
+
+// Mark 使用最新价格盯市
+func (p *PaperTrader) Mark(price float64) {
+	if price > 0 {
+		p.Price = price
+	}
+}
+
+// ApplyFunding 结算一次永续合约资金费
+// 参数：
+//   - rate: 资金费率，正数时多头向空头支付
+func (p *PaperTrader) ApplyFunding(rate float64) {
+	p.Funding += p.Position * p.Price * rate
+}
+
+// Unrealized 返回按最新价格计算的未实现盈亏
+func (p *PaperTrader) Unrealized() float64 {
+	if p.Position == 0 {
+		return 0
+	}
+	return p.Position * (p.Price - p.EntryPrice)
+}
+
+// Equity 返回当前权益：初始权益 + 已实现 + 未实现 − 手续费 − 资金费
+func (p *PaperTrader) Equity() float64 {
+	return p.InitialEquity + p.Realized + p.Unrealized() - p.Fees - p.Funding
+}
+
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
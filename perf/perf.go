@@ -0,0 +1,292 @@
+// Package perf 提供标准化的大规模合成数据集与指标基准测试工具
+//
+// 用于在用户自己的硬件上比较不同实现（如 O(n) 与旧实现）的性能，
+// 以及验证吞吐量目标（如 100 万根 K 线的 EMA 在 10ms 内完成）。
+package perf
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/phrynus/ta"
+)
+
+// Sizes 常用的数据集规模
+var Sizes = []int{1000, 10000, 100000, 1000000}
+
+// Seed 标准数据集的随机数种子，固定种子保证不同机器上的输入完全一致
+const Seed = 20240101
+
+// IndicatorFunc 基准测试的指标计算函数，输入为预先提取好价格序列的数据集
+type IndicatorFunc func(d *Data) error
+
+// Data 基准测试数据集
+// 字段：
+//   - Klines: 合成 K 线
+//   - Open/High/Low/Close/Volume: 预先提取的价格序列，避免把提取开销计入指标耗时
+type Data struct {
+	Klines ta.KlineDatas
+	Open   []float64
+	High   []float64
+	Low    []float64
+	Close  []float64
+	Volume []float64
+}
+
+// Result 一次基准测试的结果
+// 字段：
+//   - Name: 指标名称
+//   - Bars: K 线数量
+//   - NsPerOp: 每次完整计算的耗时（纳秒）
+//   - BarsPerSec: 每秒处理的 K 线数量
+//   - AllocsPerOp: 每次计算的内存分配次数
+//   - BytesPerOp: 每次计算分配的字节数
+type Result struct {
+	Name        string  `json:"name"`
+	Bars        int     `json:"bars"`
+	NsPerOp     int64   `json:"ns_per_op"`
+	BarsPerSec  float64 `json:"bars_per_sec"`
+	AllocsPerOp int64   `json:"allocs_per_op"`
+	BytesPerOp  int64   `json:"bytes_per_op"`
+}
+
+// String 返回便于阅读的结果描述
+func (r Result) String() string {
+	return fmt.Sprintf("%s/%d: %v/op, %.0f bars/s, %d allocs/op, %d B/op",
+		r.Name, r.Bars, time.Duration(r.NsPerOp), r.BarsPerSec, r.AllocsPerOp, r.BytesPerOp)
+}
+
+var (
+	mu         sync.RWMutex
+	indicators = map[string]IndicatorFunc{}
+	datasets   = map[int]*Data{}
+)
+
+func init() {
+	Register("sma", func(d *Data) error { _, err := ta.CalculateSMA(d.Close, 20); return err })
+	Register("ema", func(d *Data) error { _, err := ta.CalculateEMA(d.Close, 20); return err })
+	Register("rma", func(d *Data) error { _, err := ta.CalculateRMA(d.Close, 14); return err })
+	Register("rsi", func(d *Data) error { _, err := ta.CalculateRSI(d.Close, 14); return err })
+	Register("macd", func(d *Data) error { _, err := ta.CalculateMACD(d.Close, 12, 26, 9); return err })
+	Register("boll", func(d *Data) error { _, err := ta.CalculateBoll(d.Close, 20, 2); return err })
+	Register("atr", func(d *Data) error { _, err := ta.CalculateATR(d.Klines, 14); return err })
+	Register("cci", func(d *Data) error { _, err := ta.CalculateCCI(d.Klines, 20); return err })
+	Register("adx", func(d *Data) error { _, err := ta.CalculateADX(d.Klines, 14); return err })
+	Register("supertrend", func(d *Data) error { _, err := ta.CalculateSuperTrend(d.Klines, 10, 3); return err })
+	Register("obv", func(d *Data) error { _, err := ta.CalculateOBV(d.Close, d.Volume); return err })
+	Register("kdj", func(d *Data) error { _, err := ta.CalculateKDJ(d.High, d.Low, d.Close, 9, 3, 3); return err })
+	Register("stoch", func(d *Data) error {
+		_, err := ta.CalculateStoch(d.High, d.Low, d.Close, 14, 3, 3, ta.StochFull)
+		return err
+	})
+	Register("roc", func(d *Data) error { _, err := ta.CalculateROC(d.Close, 12, ta.ROCPercent); return err })
+	Register("ulcer", func(d *Data) error { _, err := ta.CalculateUlcer(d.Close, 14); return err })
+	Register("linreg", func(d *Data) error { _, err := ta.CalculateLinReg(d.Close, 50, 2); return err })
+	Register("savitzkygolay", func(d *Data) error { _, err := ta.CalculateSavitzkyGolay(d.Close, 21, 2); return err })
+}
+
+// Register 注册一个可参与基准测试的指标，名称重复时覆盖
+// 参数：
+//   - name: 指标名称
+//   - fn: 计算函数
+//
+// 示例：
+//
+//	perf.Register("ema_legacy", func(d *perf.Data) error {
+//	    _, err := legacyEMA(d.Close, 20)
+//	    return err
+//	})
+//	results, err := perf.Compare([]string{"ema", "ema_legacy"}, 1000000)
+func Register(name string, fn IndicatorFunc) {
+	mu.Lock()
+	defer mu.Unlock()
+	indicators[name] = fn
+}
+
+// Indicators 返回已注册的指标名称，按字母顺序排列
+func Indicators() []string {
+	mu.RLock()
+	defer mu.RUnlock()
+	names := make([]string, 0, len(indicators))
+	for name := range indicators {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Dataset 返回指定规模的标准合成数据集，相同规模只生成一次
+// 参数：
+//   - size: K 线数量
+//
+// 返回值：
+//   - *Data: 数据集，调用方不应修改
+//   - error: 规模无效时返回错误
+//
+// 说明/注意事项：
+//
+//	数据为固定种子的几何布朗运动 1 分钟 K 线，初始价格 100，每根 K 线波动率 0.2%。
+func Dataset(size int) (*Data, error) {
+	mu.RLock()
+	d, ok := datasets[size]
+	mu.RUnlock()
+	if ok {
+		return d, nil
+	}
+
+	klines, err := ta.GenerateGBM(ta.SyntheticConfig{
+		Bars:       size,
+		StartPrice: 100,
+		StartTime:  time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC).UnixMilli(),
+		Interval:   60 * 1000,
+		Volatility: 0.002,
+		Volume:     1000,
+		Seed:       Seed,
+	})
+	if err != nil {
+		return nil, err
+	}
+	d = &Data{Klines: klines}
+	for _, field := range []struct {
+		dst    *[]float64
+		source string
+	}{{&d.Open, "open"}, {&d.High, "high"}, {&d.Low, "low"}, {&d.Close, "close"}, {&d.Volume, "volume"}} {
+		*field.dst, _ = klines.ExtractSlice(field.source)
+	}
+
+	mu.Lock()
+	datasets[size] = d
+	mu.Unlock()
+	return d, nil
+}
+
+// lookup 返回指标计算函数与数据集
+func lookup(name string, size int) (IndicatorFunc, *Data, error) {
+	mu.RLock()
+	fn, ok := indicators[name]
+	mu.RUnlock()
+	if !ok {
+		return nil, nil, fmt.Errorf("未注册的指标: %s", name)
+	}
+	d, err := Dataset(size)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := fn(d); err != nil {
+		return nil, nil, fmt.Errorf("指标 %s 计算失败: %v", name, err)
+	}
+	return fn, d, nil
+}
+
+// Run 在用户自己的基准测试函数中运行指标，数据集生成不计入耗时
+//
+// 示例：
+//
+//	func BenchmarkEMA1M(b *testing.B) {
+//	    perf.Run(b, "ema", 1000000)
+//	}
+func Run(b *testing.B, name string, size int) {
+	fn, d, err := lookup(name, size)
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = fn(d)
+	}
+	b.StopTimer()
+	b.ReportMetric(float64(size)*float64(b.N)/b.Elapsed().Seconds(), "bars/s")
+}
+
+// BenchmarkIndicator 在普通程序中对指标做基准测试，无需 go test
+// 参数：
+//   - name: 已注册的指标名称
+//   - size: K 线数量
+//
+// 返回值：
+//   - Result: 基准测试结果
+//   - error: 指标未注册、规模无效或计算失败时返回错误
+//
+// 示例：
+//
+//	result, err := perf.BenchmarkIndicator("ema", 1000000)
+//	fmt.Println(result)
+func BenchmarkIndicator(name string, size int) (Result, error) {
+	fn, d, err := lookup(name, size)
+	if err != nil {
+		return Result{}, err
+	}
+	r := testing.Benchmark(func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			_ = fn(d)
+		}
+	})
+	result := Result{
+		Name:        name,
+		Bars:        size,
+		NsPerOp:     r.NsPerOp(),
+		AllocsPerOp: r.AllocsPerOp(),
+		BytesPerOp:  r.AllocedBytesPerOp(),
+	}
+	if result.NsPerOp > 0 {
+		result.BarsPerSec = float64(size) / (float64(result.NsPerOp) / 1e9)
+	}
+	return result, nil
+}
+
+// Compare 在同一数据集上依次测试多个指标，用于比较不同实现
+// 参数：
+//   - names: 已注册的指标名称
+//   - size: K 线数量
+//
+// 返回值：
+//   - []Result: 与 names 顺序一致的结果
+//   - error: 任一指标测试失败时返回错误
+func Compare(names []string, size int) ([]Result, error) {
+	results := make([]Result, 0, len(names))
+	for _, name := range names {
+		r, err := BenchmarkIndicator(name, size)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, r)
+	}
+	return results, nil
+}
+
+// CheckThroughput 验证指标在指定规模下的单次计算耗时不超过目标
+// 参数：
+//   - name: 已注册的指标名称
+//   - size: K 线数量
+//   - limit: 单次计算的耗时上限
+//
+// 返回值：
+//   - Result: 基准测试结果
+//   - error: 测试失败或超过上限时返回错误
+//
+// 示例：
+//
+//	if _, err := perf.CheckThroughput("ema", 1000000, 10*time.Millisecond); err != nil {
+//	    log.Println(err)
+//	}
+func CheckThroughput(name string, size int, limit time.Duration) (Result, error) {
+	r, err := BenchmarkIndicator(name, size)
+	if err != nil {
+		return r, err
+	}
+	if elapsed := time.Duration(r.NsPerOp); elapsed > limit {
+		return r, fmt.Errorf("%s 处理 %d 根K线耗时 %v，超过上限 %v", name, size, elapsed, limit)
+	}
+	return r, nil
+}
+
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
@@ -0,0 +1,66 @@
+package perf
+
+import (
+	"fmt"
+	"testing"
+)
+
+// BenchmarkIndicators 对所有已注册指标在各标准规模下做基准测试，
+// 可用 go test -bench 'Indicators/ema/' ./perf 选择单个指标
+func BenchmarkIndicators(b *testing.B) {
+	for _, name := range Indicators() {
+		for _, size := range Sizes {
+			b.Run(fmt.Sprintf("%s/%d", name, size), func(b *testing.B) {
+				Run(b, name, size)
+			})
+		}
+	}
+}
+
+func BenchmarkEMA1M(b *testing.B)  { Run(b, "ema", 1000000) }
+func BenchmarkSMA1M(b *testing.B)  { Run(b, "sma", 1000000) }
+func BenchmarkRSI1M(b *testing.B)  { Run(b, "rsi", 1000000) }
+func BenchmarkATR1M(b *testing.B)  { Run(b, "atr", 1000000) }
+func BenchmarkMACD1M(b *testing.B) { Run(b, "macd", 1000000) }
+func BenchmarkBoll1M(b *testing.B) { Run(b, "boll", 1000000) }
+
+func TestRegisteredIndicators(t *testing.T) {
+	for _, name := range Indicators() {
+		t.Run(name, func(t *testing.T) {
+			if _, _, err := lookup(name, 1000); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+func TestDatasetDeterministic(t *testing.T) {
+	d, err := Dataset(500)
+	if err != nil {
+		t.Fatal(err)
+	}
+	mu.Lock()
+	delete(datasets, 500)
+	mu.Unlock()
+	again, err := Dataset(500)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d == again {
+		t.Fatal("数据集应重新生成")
+	}
+	for i := range d.Close {
+		if d.Close[i] != again.Close[i] || d.Klines[i].StartTime != again.Klines[i].StartTime {
+			t.Fatalf("第 %d 根 K 线不一致: %v != %v", i, d.Close[i], again.Close[i])
+		}
+	}
+	if len(d.Close) != 500 || len(d.Volume) != 500 {
+		t.Errorf("序列长度 = %d/%d, want 500", len(d.Close), len(d.Volume))
+	}
+}
+
+func TestUnknownIndicator(t *testing.T) {
+	if _, err := BenchmarkIndicator("missing", 1000); err == nil {
+		t.Error("未注册的指标应返回错误")
+	}
+}
@@ -0,0 +1,355 @@
+package ta
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// PipelineConfig 分析流水线配置
+// 说明：
+//
+//	以 JSON 声明完整的分析流程：数据源、重采样周期、指标列表、信号规则和输出。
+//	指标与信号均使用表达式（见 TaExpr），后声明的表达式可以按名称引用之前的结果。
+//
+// 字段：
+//   - Source: 数据源配置
+//   - Resample: 重采样周期，如 "1h"，为空时不重采样；"1w" 按 UTC 自然周（周一开始）聚合，不支持多周
+//   - Indicators: 指标列表
+//   - Signals: 信号规则列表，结果为 1/0
+//   - Outputs: 输出列表
+//
+// 示例：
+//
+//	{
+//	  "source": {"file": "btcusdt_1m.json", "drop_last": true},
+//	  "resample": "1h",
+//	  "indicators": [
+//	    {"name": "fast", "expr": "ema(close,20)"},
+//	    {"name": "slow", "expr": "ema(close,50)"}
+//	  ],
+//	  "signals": [{"name": "long", "expr": "fast > slow && rsi(close,14) < 70"}],
+//	  "outputs": [{"format": "csv", "file": "out.csv"}]
+//	}
+type PipelineConfig struct {
+	Source     PipelineSource   `json:"source"`
+	Resample   string           `json:"resample"`
+	Indicators []PipelineColumn `json:"indicators"`
+	Signals    []PipelineColumn `json:"signals"`
+	Outputs    []PipelineOutput `json:"outputs"`
+}
+
+// PipelineSource 流水线数据源
+// 字段：
+//   - File: K 线 JSON 文件路径，内容为 KlineData 数组
+//   - DropLast: 是否丢弃最后一根（未收盘）K 线，与 NewKlineDatas 的参数含义一致
+type PipelineSource struct {
+	File     string `json:"file"`
+	DropLast bool   `json:"drop_last"`
+}
+
+// PipelineColumn 流水线中的一个命名表达式
+// 字段：
+//   - Name: 列名，供后续表达式和输出引用
+//   - Expr: 表达式字符串
+type PipelineColumn struct {
+	Name string `json:"name"`
+	Expr string `json:"expr"`
+}
+
+// PipelineOutput 流水线输出
+// 字段：
+//   - Format: 输出格式，支持 "csv" 和 "json"
+//   - File: 输出文件路径
+//   - Columns: 输出的列，为空时输出全部列
+type PipelineOutput struct {
+	Format  string   `json:"format"`
+	File    string   `json:"file"`
+	Columns []string `json:"columns"`
+}
+
+// Pipeline 已校验并编译的分析流水线
+type Pipeline struct {
+	Config PipelineConfig
+
+	interval int64
+	exprs    []*TaExpr
+	names    []string
+}
+
+// PipelineResult 流水线计算结果
+// 字段：
+//   - StartTime: 每根 K 线的开始时间
+//   - Names: 列名，按声明顺序排列
+//   - Columns: 列名到序列的映射
+type PipelineResult struct {
+	StartTime []int64              `json:"start_time"`
+	Names     []string             `json:"names"`
+	Columns   map[string][]float64 `json:"columns"`
+}
+
+// LoadPipeline 从配置文件加载流水线
+// 参数：
+//   - path: 配置文件路径，目前仅支持 JSON
+//
+// 返回值：
+//   - *Pipeline: 编译后的流水线
+//   - error: 读取、解析或校验失败时返回错误
+func LoadPipeline(path string) (*Pipeline, error) {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		return nil, fmt.Errorf("暂不支持 YAML 配置，请使用 JSON")
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("读取流水线配置失败: %v", err)
+	}
+	return ParsePipeline(data)
+}
+
+// ParsePipeline 解析 JSON 流水线配置
+// 参数：
+//   - data: JSON 配置内容
+//
+// 返回值：
+//   - *Pipeline: 编译后的流水线
+//   - error: 解析或校验失败时返回错误
+func ParsePipeline(data []byte) (*Pipeline, error) {
+	var config PipelineConfig
+	if err := json.Unmarshal(data, &config); err != nil {
+		return nil, fmt.Errorf("解析流水线配置失败: %v", err)
+	}
+	return NewPipeline(config)
+}
+
+// NewPipeline 校验配置并编译其中的表达式
+// 参数：
+//   - config: 流水线配置
+//
+// 返回值：
+//   - *Pipeline: 编译后的流水线
+//   - error: 列名重复、表达式错误或输出格式无效时返回错误
+func NewPipeline(config PipelineConfig) (*Pipeline, error) {
+	p := &Pipeline{Config: config}
+
+	if config.Resample != "" {
+		interval, err := ParseInterval(config.Resample)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := intervalBucket(interval); err != nil {
+			return nil, err
+		}
+		p.interval = interval
+	}
+
+	seen := make(map[string]bool)
+	columns := append(append([]PipelineColumn{}, config.Indicators...), config.Signals...)
+	for _, c := range columns {
+		name := strings.ToLower(c.Name)
+		if name == "" {
+			return nil, fmt.Errorf("表达式 %q 缺少名称", c.Expr)
+		}
+		if seen[name] || exprSources[name] {
+			return nil, fmt.Errorf("列名重复或与数据源冲突: %s", c.Name)
+		}
+		seen[name] = true
+
+		expr, err := CompileExpr(c.Expr)
+		if err != nil {
+			return nil, fmt.Errorf("编译 %s 失败: %v", c.Name, err)
+		}
+		p.exprs = append(p.exprs, expr)
+		p.names = append(p.names, name)
+	}
+
+	for _, o := range config.Outputs {
+		if o.Format != "csv" && o.Format != "json" {
+			return nil, fmt.Errorf("不支持的输出格式: %q", o.Format)
+		}
+		for _, c := range o.Columns {
+			if !seen[strings.ToLower(c)] && !exprSources[strings.ToLower(c)] {
+				return nil, fmt.Errorf("输出引用了未定义的列: %s", c)
+			}
+		}
+	}
+	return p, nil
+}
+
+// Run 执行流水线并写出配置中的输出
+// 参数：
+//   - klineData: 输入 K 线，为空时从配置的数据源文件加载
+//
+// 返回值：
+//   - *PipelineResult: 计算结果
+//   - error: 加载、计算或写出失败时返回错误
+func (p *Pipeline) Run(klineData KlineDatas) (*PipelineResult, error) {
+	if len(klineData) == 0 {
+		loaded, err := p.load()
+		if err != nil {
+			return nil, err
+		}
+		klineData = loaded
+	}
+	if p.interval > 0 {
+		resampled, err := klineData.Resample(p.interval)
+		if err != nil {
+			return nil, err
+		}
+		klineData = resampled
+	}
+
+	result := &PipelineResult{
+		StartTime: make([]int64, len(klineData)),
+		Names:     p.names,
+		Columns:   make(map[string][]float64, len(p.names)),
+	}
+	for i, kline := range klineData {
+		result.StartTime[i] = kline.StartTime
+	}
+	for i, expr := range p.exprs {
+		values, err := expr.EvalWith(klineData, result.Columns)
+		if err != nil {
+			return nil, fmt.Errorf("计算 %s 失败: %v", p.names[i], err)
+		}
+		result.Columns[p.names[i]] = values
+	}
+	for _, source := range []string{"open", "high", "low", "close", "volume"} {
+		values, _ := klineData.ExtractSlice(source)
+		if _, ok := result.Columns[source]; !ok {
+			result.Columns[source] = values
+		}
+	}
+
+	for _, o := range p.Config.Outputs {
+		if err := result.writeFile(o); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+func (p *Pipeline) load() (KlineDatas, error) {
+	if p.Config.Source.File == "" {
+		return nil, fmt.Errorf("未提供K线数据且未配置数据源文件")
+	}
+	data, err := os.ReadFile(p.Config.Source.File)
+	if err != nil {
+		return nil, fmt.Errorf("读取数据源失败: %v", err)
+	}
+	var klines []KlineData
+	if err := json.Unmarshal(data, &klines); err != nil {
+		return nil, fmt.Errorf("解析数据源失败: %v", err)
+	}
+	return NewKlineDatas(klines, p.Config.Source.DropLast)
+}
+
+// Value 获取指定列的最新值
+// 参数：
+//   - name: 列名
+//
+// 返回值：
+//   - float64: 最新值，列不存在时返回 0
+func (r *PipelineResult) Value(name string) float64 {
+	values := r.Columns[strings.ToLower(name)]
+	if len(values) == 0 {
+		return 0
+	}
+	return values[len(values)-1]
+}
+
+// selectColumns 返回小写的输出列名，列不存在时返回错误
+func (r *PipelineResult) selectColumns(columns []string) ([]string, error) {
+	if len(columns) == 0 {
+		columns = r.Names
+	}
+	out := make([]string, len(columns))
+	for i, c := range columns {
+		out[i] = strings.ToLower(c)
+		if _, ok := r.Columns[out[i]]; !ok {
+			return nil, fmt.Errorf("输出引用了未定义的列: %s", c)
+		}
+	}
+	return out, nil
+}
+
+// WriteCSV 以 CSV 格式写出结果，首列为 start_time
+// 参数：
+//   - w: 输出目标
+//   - columns: 输出的列，为空时输出全部指标和信号列
+//
+// 返回值：
+//   - error: 列不存在或写出失败时返回错误，列不存在时不写出任何内容
+func (r *PipelineResult) WriteCSV(w io.Writer, columns []string) error {
+	columns, err := r.selectColumns(columns)
+	if err != nil {
+		return err
+	}
+	cw := csv.NewWriter(w)
+	if err := cw.Write(append([]string{"start_time"}, columns...)); err != nil {
+		return err
+	}
+	row := make([]string, len(columns)+1)
+	for i, t := range r.StartTime {
+		row[0] = strconv.FormatInt(t, 10)
+		for j, c := range columns {
+			row[j+1] = strconv.FormatFloat(r.Columns[c][i], 'f', -1, 64)
+		}
+		if err := cw.Write(row); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// WriteJSON 以 JSON 格式写出结果
+// 参数：
+//   - w: 输出目标
+//   - columns: 输出的列，为空时输出全部指标和信号列
+//
+// 返回值：
+//   - error: 列不存在或写出失败时返回错误，列不存在时不写出任何内容
+func (r *PipelineResult) WriteJSON(w io.Writer, columns []string) error {
+	columns, err := r.selectColumns(columns)
+	if err != nil {
+		return err
+	}
+	out := &PipelineResult{
+		StartTime: r.StartTime,
+		Names:     columns,
+		Columns:   make(map[string][]float64, len(columns)),
+	}
+	for _, c := range columns {
+		out.Columns[c] = r.Columns[c]
+	}
+	return json.NewEncoder(w).Encode(out)
+}
+
+// writeFile 写出单个输出文件，关闭文件失败（如缓冲数据落盘失败）同样返回错误
+func (r *PipelineResult) writeFile(o PipelineOutput) (err error) {
+	f, err := os.Create(o.File)
+	if err != nil {
+		return fmt.Errorf("创建输出文件失败: %v", err)
+	}
+	defer func() {
+		if cerr := f.Close(); err == nil && cerr != nil {
+			err = fmt.Errorf("关闭输出文件失败: %v", cerr)
+		}
+	}()
+	if o.Format == "csv" {
+		return r.WriteCSV(f, o.Columns)
+	}
+	return r.WriteJSON(f, o.Columns)
+}
+
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
@@ -0,0 +1,235 @@
+package ta
+
+import (
+	"fmt"
+	"math"
+	"sort"
+)
+
+// 枢轴点计算公式
+const (
+	PivotClassic   = iota // 经典：P = (H+L+C)/3
+	PivotFibonacci        // 斐波那契：P ± 0.382/0.618/1.0 × 振幅
+	PivotCamarilla        // Camarilla：C ± 1.1/12、1.1/6、1.1/4 × 振幅
+	PivotWoodie           // Woodie：P = (H+L+2C)/4
+)
+
+// PivotLevels 一个交易时段的枢轴点与支撑阻力位
+// 字段：
+//   - StartTime: 适用时段的开始时间（毫秒），价位由前一个时段的高、低、收计算
+//   - P: 枢轴点
+//   - R1/R2/R3: 阻力位，由近到远
+//   - S1/S2/S3: 支撑位，由近到远
+type PivotLevels struct {
+	StartTime int64   `json:"start_time"`
+	P         float64 `json:"p"`
+	R1        float64 `json:"r1"`
+	R2        float64 `json:"r2"`
+	R3        float64 `json:"r3"`
+	S1        float64 `json:"s1"`
+	S2        float64 `json:"s2"`
+	S3        float64 `json:"s3"`
+}
+
+// CalculatePivotLevels 由一个时段的最高、最低和收盘价计算下一个时段的枢轴点
+// 参数：
+//   - high/low/close: 前一个时段的最高价、最低价和收盘价
+//   - mode: 计算公式，如 PivotClassic
+//
+// 返回值：
+//   - PivotLevels: 枢轴点与支撑阻力位，StartTime 为 0
+//   - error: 公式未知或最高价低于最低价时返回错误
+func CalculatePivotLevels(high, low, close float64, mode int) (PivotLevels, error) {
+	if high < low {
+		return PivotLevels{}, fmt.Errorf("最高价不能低于最低价")
+	}
+	r := high - low
+	var l PivotLevels
+	switch mode {
+	case PivotClassic, PivotWoodie:
+		if mode == PivotClassic {
+			l.P = (high + low + close) / 3
+		} else {
+			l.P = (high + low + 2*close) / 4
+		}
+		l.R1, l.S1 = 2*l.P-low, 2*l.P-high
+		l.R2, l.S2 = l.P+r, l.P-r
+		l.R3, l.S3 = high+2*(l.P-low), low-2*(high-l.P)
+	case PivotFibonacci:
+		l.P = (high + low + close) / 3
+		l.R1, l.S1 = l.P+0.382*r, l.P-0.382*r
+		l.R2, l.S2 = l.P+0.618*r, l.P-0.618*r
+		l.R3, l.S3 = l.P+r, l.P-r
+	case PivotCamarilla:
+		l.P = (high + low + close) / 3
+		l.R1, l.S1 = close+r*1.1/12, close-r*1.1/12
+		l.R2, l.S2 = close+r*1.1/6, close-r*1.1/6
+		l.R3, l.S3 = close+r*1.1/4, close-r*1.1/4
+	default:
+		return PivotLevels{}, fmt.Errorf("未知的枢轴点公式: %d", mode)
+	}
+	return l, nil
+}
+
+// Levels 返回全部价位的名称与价格，按价格从低到高排列
+func (l PivotLevels) Levels() ([]string, []float64) {
+	names := []string{"S3", "S2", "S1", "P", "R1", "R2", "R3"}
+	values := []float64{l.S3, l.S2, l.S1, l.P, l.R1, l.R2, l.R3}
+	sort.Sort(pivotLevelSorter{names, values})
+	return names, values
+}
+
+type pivotLevelSorter struct {
+	names  []string
+	values []float64
+}
+
+func (s pivotLevelSorter) Len() int           { return len(s.values) }
+func (s pivotLevelSorter) Less(i, j int) bool { return s.values[i] < s.values[j] }
+func (s pivotLevelSorter) Swap(i, j int) {
+	s.names[i], s.names[j] = s.names[j], s.names[i]
+	s.values[i], s.values[j] = s.values[j], s.values[i]
+}
+
+// Nearest 返回距离价格最近的价位
+// 参数：
+//   - price: 当前价格
+//
+// 返回值：
+//   - name: 价位名称，如 "R1"、"P"
+//   - level: 价位价格
+func (l PivotLevels) Nearest(price float64) (name string, level float64) {
+	names, values := l.Levels()
+	best := math.Inf(1)
+	for i, v := range values {
+		if d := math.Abs(price - v); d < best {
+			best, name, level = d, names[i], v
+		}
+	}
+	return name, level
+}
+
+// Bracket 返回价格下方最近的支撑位与上方最近的阻力位，价格低于 S3 或高于 R3 时对应一侧的名称为空、价格为 0
+func (l PivotLevels) Bracket(price float64) (belowName string, below float64, aboveName string, above float64) {
+	names, values := l.Levels()
+	for i, v := range values {
+		if v <= price {
+			belowName, below = names[i], v
+		} else {
+			return belowName, below, names[i], v
+		}
+	}
+	return belowName, below, "", 0
+}
+
+// TaPivotPoints 按交易时段计算的枢轴点结果
+// 字段：
+//   - Sessions: 各时段的枢轴点，第一个时段没有前一时段的数据，不包含在内；最后一个为当前（可能未结束的）时段
+//   - Interval: 时段长度（毫秒）
+//   - Mode: 计算公式
+type TaPivotPoints struct {
+	Sessions []PivotLevels `json:"sessions"`
+	Interval int64         `json:"interval"`
+	Mode     int           `json:"mode"`
+}
+
+// CalculatePivotPoints 将 K 线聚合为时段并计算每个时段的枢轴点
+// 参数：
+//   - klineData: K 线数据，周期应小于时段长度，如 15m K 线计算日枢轴点
+//   - interval: 时段长度（毫秒），如 ParseInterval("1d")，按 UTC 对齐；ParseInterval("1w") 按 UTC 自然周（周一开始）对齐，不支持多周
+//   - mode: 计算公式，如 PivotClassic
+//
+// 返回值：
+//   - *TaPivotPoints: 计算结果
+//   - error: 参数无效或不足两个时段时返回错误
+//
+// 说明/注意事项：
+//
+//	每个时段的价位只使用前一个时段的数据，在时段开始时即已确定，不含未来数据。
+//	时段由 Resample 划分，周枢轴点按自然周而非 1970-01-01（周四）起算的固定毫秒数分桶。
+//
+// 示例：
+//
+//	day, _ := ParseInterval("1d")
+//	pivots, err := CalculatePivotPoints(klineData, day, PivotCamarilla)
+//	if err != nil {
+//	    // 处理错误
+//	}
+//	name, level := pivots.Value().Nearest(klineData[len(klineData)-1].Close)
+func CalculatePivotPoints(klineData KlineDatas, interval int64, mode int) (*TaPivotPoints, error) {
+	sessions, err := klineData.Resample(interval)
+	if err != nil {
+		return nil, err
+	}
+	if len(sessions) < 2 {
+		return nil, fmt.Errorf("计算数据不足")
+	}
+
+	result := &TaPivotPoints{
+		Sessions: make([]PivotLevels, 0, len(sessions)-1),
+		Interval: interval,
+		Mode:     mode,
+	}
+	for i := 1; i < len(sessions); i++ {
+		prev := sessions[i-1]
+		levels, err := CalculatePivotLevels(prev.High, prev.Low, prev.Close, mode)
+		if err != nil {
+			return nil, err
+		}
+		levels.StartTime = sessions[i].StartTime
+		result.Sessions = append(result.Sessions, levels)
+	}
+	return result, nil
+}
+
+// PivotPoints 计算 K 线数据的时段枢轴点，参数含义同 CalculatePivotPoints
+func (k *KlineDatas) PivotPoints(interval int64, mode int) (*TaPivotPoints, error) {
+	return CalculatePivotPoints(*k, interval, mode)
+}
+
+// Value 返回当前时段的枢轴点
+func (t *TaPivotPoints) Value() PivotLevels {
+	return t.Sessions[len(t.Sessions)-1]
+}
+
+// At 返回指定时间所在时段的枢轴点，时间早于第一个有枢轴点的时段时返回 false
+func (t *TaPivotPoints) At(startTime int64) (PivotLevels, bool) {
+	i := sort.Search(len(t.Sessions), func(i int) bool {
+		return t.Sessions[i].StartTime > startTime
+	})
+	if i == 0 {
+		return PivotLevels{}, false
+	}
+	return t.Sessions[i-1], true
+}
+
+// Series 将枢轴点展开为与 K 线对齐的序列，可直接绘图或加入 ResultSet
+// 参数：
+//   - klineData: 与计算时相同或时间范围更大的 K 线
+//
+// 返回值：
+//   - map[string][]float64: 键为 "p"、"r1"…"s3"，没有枢轴点的位置为 0
+func (t *TaPivotPoints) Series(klineData KlineDatas) map[string][]float64 {
+	keys := []string{"p", "r1", "r2", "r3", "s1", "s2", "s3"}
+	slices := preallocateSlices(len(klineData), len(keys))
+	for i, kline := range klineData {
+		l, ok := t.At(kline.StartTime)
+		if !ok {
+			continue
+		}
+		for j, v := range []float64{l.P, l.R1, l.R2, l.R3, l.S1, l.S2, l.S3} {
+			slices[j][i] = v
+		}
+	}
+	out := make(map[string][]float64, len(keys))
+	for j, key := range keys {
+		out[key] = slices[j]
+	}
+	return out
+}
+
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
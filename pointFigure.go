@@ -0,0 +1,200 @@
+package ta
+
+import (
+	"fmt"
+	"math"
+)
+
+// 点数图的列类型
+const (
+	PFColumnO = -1 // O 列，价格下跌
+	PFColumnX = 1  // X 列，价格上涨
+)
+
+// ChartSignal 点数图、卡吉图等非时间图表的突破信号
+// 字段：
+//   - Index: 信号出现时的原始 K 线下标
+//   - StartTime: 该 K 线的开始时间
+//   - Direction: 1 为向上突破（买入），-1 为向下突破（卖出）
+//   - Price: 被突破的价位
+type ChartSignal struct {
+	Index     int     `json:"index"`
+	StartTime int64   `json:"start_time"`
+	Direction int     `json:"direction"`
+	Price     float64 `json:"price"`
+}
+
+// PFColumn 点数图的一列
+// 字段：
+//   - Type: 列类型，PFColumnX 或 PFColumnO
+//   - High: 列最高格的价位
+//   - Low: 列最低格的价位
+//   - Boxes: 格数
+//   - StartIndex: 列开始时的原始 K 线下标
+//   - EndIndex: 列最后一次延伸时的原始 K 线下标
+type PFColumn struct {
+	Type       int     `json:"type"`
+	High       float64 `json:"high"`
+	Low        float64 `json:"low"`
+	Boxes      int     `json:"boxes"`
+	StartIndex int     `json:"start_index"`
+	EndIndex   int     `json:"end_index"`
+}
+
+// TaPointFigure 点数图（Point & Figure）的计算结果
+// 字段：
+//   - Columns: 各列，按时间顺序排列
+//   - Signals: 双顶突破（X 列高于前一个 X 列）与双底跌破（O 列低于前一个 O 列）信号，每列最多一个
+//   - BoxSize: 格值
+//   - Reversal: 转向格数
+type TaPointFigure struct {
+	Columns  []PFColumn    `json:"columns"`
+	Signals  []ChartSignal `json:"signals"`
+	BoxSize  float64       `json:"box_size"`
+	Reversal int           `json:"reversal"`
+
+	bars int
+}
+
+// CalculatePointFigure 将 K 线转换为点数图
+// 参数：
+//   - klineData: K 线数据
+//   - boxSize: 格值，价位按格值的整数倍对齐
+//   - reversal: 转向格数，常用 3
+//
+// 返回值：
+//   - *TaPointFigure: 点数图结果
+//   - error: 参数无效、数据不足或价格波动不足以形成一列时返回错误
+//
+// 说明/注意事项：
+//
+//	使用高低价法：X 列先用最高价判断能否延伸，不能延伸时再用最低价判断是否回落 reversal 格而转为 O 列；O 列相反。
+//	新列从前一列端点的下一格开始。点数图忽略时间与小幅波动，只记录有效的价格变化。
+//
+// 示例：
+//
+//	pf, err := CalculatePointFigure(klineData, 100, 3)
+//	if err != nil {
+//	    // 处理错误
+//	}
+//	if pf.IsBuySignal() {
+//	    // 最新一根 K 线形成双顶突破
+//	}
+func CalculatePointFigure(klineData KlineDatas, boxSize float64, reversal int) (*TaPointFigure, error) {
+	if boxSize <= 0 {
+		return nil, fmt.Errorf("格值必须大于0")
+	}
+	if reversal <= 0 {
+		return nil, fmt.Errorf("转向格数必须大于0")
+	}
+	length := len(klineData)
+	if length < 2 {
+		return nil, fmt.Errorf("计算数据不足")
+	}
+
+	// 价位对齐到格值的整数倍，容差避免浮点误差导致少算一格
+	floorBox := func(p float64) float64 { return math.Floor(p/boxSize+1e-9) * boxSize }
+	ceilBox := func(p float64) float64 { return math.Ceil(p/boxSize-1e-9) * boxSize }
+	boxes := func(c PFColumn) int { return int(math.Round((c.High-c.Low)/boxSize)) + 1 }
+
+	result := &TaPointFigure{BoxSize: boxSize, Reversal: reversal, bars: length}
+	signaled := false
+	checkBreakout := func(i int) {
+		n := len(result.Columns)
+		if signaled || n < 3 {
+			return
+		}
+		cur, prev := result.Columns[n-1], result.Columns[n-3]
+		switch {
+		case cur.Type == PFColumnX && cur.High > prev.High:
+			result.Signals = append(result.Signals, ChartSignal{Index: i, StartTime: klineData[i].StartTime, Direction: 1, Price: prev.High})
+			signaled = true
+		case cur.Type == PFColumnO && cur.Low < prev.Low:
+			result.Signals = append(result.Signals, ChartSignal{Index: i, StartTime: klineData[i].StartTime, Direction: -1, Price: prev.Low})
+			signaled = true
+		}
+	}
+	push := func(c PFColumn) {
+		c.Boxes = boxes(c)
+		result.Columns = append(result.Columns, c)
+		signaled = false
+	}
+
+	base := floorBox(klineData[0].Close)
+	for i := 1; i < length; i++ {
+		high, low := klineData[i].High, klineData[i].Low
+		n := len(result.Columns)
+		if n == 0 {
+			if top := floorBox(high); top >= base+boxSize {
+				push(PFColumn{Type: PFColumnX, High: top, Low: base, StartIndex: i, EndIndex: i})
+			} else if bottom := ceilBox(low); bottom <= base-boxSize {
+				push(PFColumn{Type: PFColumnO, High: base, Low: bottom, StartIndex: i, EndIndex: i})
+			}
+			continue
+		}
+
+		c := &result.Columns[n-1]
+		if c.Type == PFColumnX {
+			if top := floorBox(high); top >= c.High+boxSize {
+				c.High, c.EndIndex = top, i
+				c.Boxes = boxes(*c)
+			} else if bottom := ceilBox(low); bottom <= c.High-float64(reversal)*boxSize {
+				push(PFColumn{Type: PFColumnO, High: c.High - boxSize, Low: bottom, StartIndex: i, EndIndex: i})
+			} else {
+				continue
+			}
+		} else {
+			if bottom := ceilBox(low); bottom <= c.Low-boxSize {
+				c.Low, c.EndIndex = bottom, i
+				c.Boxes = boxes(*c)
+			} else if top := floorBox(high); top >= c.Low+float64(reversal)*boxSize {
+				push(PFColumn{Type: PFColumnX, High: top, Low: c.Low + boxSize, StartIndex: i, EndIndex: i})
+			} else {
+				continue
+			}
+		}
+		checkBreakout(i)
+	}
+
+	if len(result.Columns) == 0 {
+		return nil, fmt.Errorf("价格波动不足以形成点数图")
+	}
+	return result, nil
+}
+
+// PointFigure 将 K 线转换为点数图，参数含义同 CalculatePointFigure
+func (k *KlineDatas) PointFigure(boxSize float64, reversal int) (*TaPointFigure, error) {
+	return CalculatePointFigure(*k, boxSize, reversal)
+}
+
+// Value 返回当前列的类型，PFColumnX 或 PFColumnO
+func (t *TaPointFigure) Value() int {
+	return t.Columns[len(t.Columns)-1].Type
+}
+
+// IsBuySignal 判断最新一根K线是否形成双顶突破
+func (t *TaPointFigure) IsBuySignal() bool {
+	return lastChartSignal(t.Signals, t.bars) == 1
+}
+
+// IsSellSignal 判断最新一根K线是否形成双底跌破
+func (t *TaPointFigure) IsSellSignal() bool {
+	return lastChartSignal(t.Signals, t.bars) == -1
+}
+
+// lastChartSignal 返回出现在最后一根 K 线上的信号方向，没有时返回 0
+func lastChartSignal(signals []ChartSignal, bars int) int {
+	if len(signals) == 0 {
+		return 0
+	}
+	if last := signals[len(signals)-1]; last.Index == bars-1 {
+		return last.Direction
+	}
+	return 0
+}
+
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
@@ -0,0 +1,166 @@
+package ta
+
+import (
+	"fmt"
+	"math"
+	"sort"
+)
+
+// 价格预滤波方式
+const (
+	// FilterMedian 滚动中位数滤波，去除单根异常插针
+	FilterMedian = iota
+	// FilterHaar Haar 小波软阈值降噪
+	FilterHaar
+)
+
+// MedianFilter 对序列做滚动中位数滤波
+// 参数：
+//   - values: 输入序列
+//   - window: 窗口长度，包含当前位置在内向前取 window 个值
+//
+// 返回值：
+//   - []float64: 与输入等长的滤波结果，开头不足一个窗口的位置使用已有数据的中位数
+//   - error: 窗口长度无效时返回错误
+//
+// 说明/注意事项：
+//
+//	只使用当前及之前的数据，不会产生未来函数；窗口为 3 或 5 即可去除单根插针。
+func MedianFilter(values []float64, window int) ([]float64, error) {
+	if window <= 0 {
+		return nil, fmt.Errorf("窗口长度必须大于0")
+	}
+	out := make([]float64, len(values))
+	buf := make([]float64, 0, window)
+	w, _ := NewWindow(len(values), window, 1, 1)
+	for w.Next() {
+		buf = append(buf[:0], values[w.Start():w.End()]...)
+		sort.Float64s(buf)
+		out[w.Index()] = percentileSorted(buf, 0.5)
+	}
+	return out, nil
+}
+
+// HaarDenoise 使用 Haar 小波对序列做因果降噪
+// 参数：
+//   - values: 输入序列
+//   - levels: 分解层数，窗口长度为 2^levels，取值 1 到 10
+//
+// 返回值：
+//   - []float64: 与输入等长的降噪结果，开头不足一个窗口的位置保持原值
+//   - error: 分解层数无效时返回错误
+//
+// 说明/注意事项：
+//
+//	每个位置只对截至当前的 2^levels 个数据做小波分解，细节系数按通用阈值 σ√(2ln n) 软阈值收缩，
+//	σ 由最细一层细节系数的中位绝对偏差估计，重构后取窗口最后一个值，因此不含未来数据。
+func HaarDenoise(values []float64, levels int) ([]float64, error) {
+	if levels < 1 || levels > 10 {
+		return nil, fmt.Errorf("分解层数必须在1到10之间")
+	}
+	window := 1 << levels
+	out := make([]float64, len(values))
+	copy(out, values)
+	coeffs := make([]float64, window)
+	tmp := make([]float64, window)
+	for i := window - 1; i < len(values); i++ {
+		copy(coeffs, values[i-window+1:i+1])
+		out[i] = haarDenoiseLast(coeffs, tmp, levels)
+	}
+	return out, nil
+}
+
+// haarDenoiseLast 对长度为 2^levels 的窗口做 Haar 分解、软阈值和重构，返回最后一个值；coeffs 会被改写
+func haarDenoiseLast(coeffs, tmp []float64, levels int) float64 {
+	n := len(coeffs)
+	for size := n; size > n>>levels; size /= 2 {
+		half := size / 2
+		for j := 0; j < half; j++ {
+			a, b := coeffs[2*j], coeffs[2*j+1]
+			tmp[j], tmp[half+j] = (a+b)/math.Sqrt2, (a-b)/math.Sqrt2
+		}
+		copy(coeffs[:size], tmp[:size])
+	}
+
+	finest := make([]float64, n/2)
+	for j, d := range coeffs[n/2:] {
+		finest[j] = math.Abs(d)
+	}
+	sort.Float64s(finest)
+	sigma := percentileSorted(finest, 0.5) / 0.6745
+	threshold := sigma * math.Sqrt(2*math.Log(float64(n)))
+	for j := n >> levels; j < n; j++ {
+		d := coeffs[j]
+		coeffs[j] = math.Copysign(math.Max(math.Abs(d)-threshold, 0), d)
+	}
+
+	for size := (n >> levels) * 2; size <= n; size *= 2 {
+		half := size / 2
+		for j := 0; j < half; j++ {
+			a, d := coeffs[j], coeffs[half+j]
+			tmp[2*j], tmp[2*j+1] = (a+d)/math.Sqrt2, (a-d)/math.Sqrt2
+		}
+		copy(coeffs[:size], tmp[:size])
+	}
+	return coeffs[n-1]
+}
+
+// Filtered 返回开盘价、最高价、最低价和收盘价经过预滤波的 K 线副本
+// 参数：
+//   - method: 滤波方式，FilterMedian 或 FilterHaar
+//   - param: 滤波参数，FilterMedian 为窗口长度，FilterHaar 为分解层数
+//
+// 返回值：
+//   - KlineDatas: 滤波后的新 K 线，开始时间和成交量不变
+//   - error: 滤波方式或参数无效时返回错误
+//
+// 说明/注意事项：
+//
+//	滤波后的 K 线可直接调用任意指标方法，流动性差的交易对无需手动处理价格切片。
+//	四个价格分别滤波后，最高价和最低价会被修正为包含开盘价与收盘价。
+//
+// 示例：
+//
+//	clean, err := klineData.Filtered(FilterMedian, 5)
+//	rsi, err := clean.RSI(14, "close")
+func (k *KlineDatas) Filtered(method, param int) (KlineDatas, error) {
+	var filter func(values []float64, param int) ([]float64, error)
+	switch method {
+	case FilterMedian:
+		filter = MedianFilter
+	case FilterHaar:
+		filter = HaarDenoise
+	default:
+		return nil, fmt.Errorf("无效的滤波方式: %d", method)
+	}
+
+	fields := make([][]float64, 4)
+	for i, source := range []string{"open", "high", "low", "close"} {
+		prices, _ := k.ExtractSlice(source)
+		filtered, err := filter(prices, param)
+		if err != nil {
+			return nil, err
+		}
+		fields[i] = filtered
+	}
+
+	out := make(KlineDatas, len(*k))
+	for i, kline := range *k {
+		open, close := fields[0][i], fields[3][i]
+		out[i] = &KlineData{
+			StartTime: kline.StartTime,
+			Open:      open,
+			High:      math.Max(fields[1][i], math.Max(open, close)),
+			Low:       math.Min(fields[2][i], math.Min(open, close)),
+			Close:     close,
+			Volume:    kline.Volume,
+		}
+	}
+	return out, nil
+}
+
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
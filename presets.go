@@ -0,0 +1,258 @@
+package ta
+
+import (
+	"fmt"
+	"sort"
+)
+
+// indicatorPresets 各指标的命名参数预设
+var indicatorPresets = map[string]map[string]map[string]float64{
+	"rsi": {
+		"binance-default": {"period": 14},
+		"scalping":        {"period": 7},
+		"swing":           {"period": 21},
+	},
+	"ema": {
+		"binance-default": {"period": 20},
+		"scalping":        {"period": 9},
+		"swing":           {"period": 50},
+	},
+	"sma": {
+		"binance-default": {"period": 20},
+		"scalping":        {"period": 10},
+		"swing":           {"period": 50},
+	},
+	"atr": {
+		"binance-default": {"period": 14},
+		"scalping":        {"period": 7},
+		"swing":           {"period": 21},
+	},
+	"macd": {
+		"binance-default": {"short_period": 12, "long_period": 26, "signal_period": 9},
+		"scalping":        {"short_period": 5, "long_period": 13, "signal_period": 4},
+		"swing":           {"short_period": 19, "long_period": 39, "signal_period": 9},
+	},
+	"boll": {
+		"binance-default": {"period": 20, "std_dev": 2},
+		"scalping":        {"period": 10, "std_dev": 1.5},
+		"swing":           {"period": 50, "std_dev": 2.5},
+	},
+	"kdj": {
+		"binance-default": {"rsv_period": 9, "k_period": 3, "d_period": 3},
+		"scalping":        {"rsv_period": 5, "k_period": 3, "d_period": 3},
+		"swing":           {"rsv_period": 21, "k_period": 5, "d_period": 5},
+	},
+	"stoch": {
+		"binance-default": {"k_period": 14, "slowing": 3, "d_period": 3},
+		"scalping":        {"k_period": 5, "slowing": 3, "d_period": 3},
+		"swing":           {"k_period": 21, "slowing": 5, "d_period": 5},
+	},
+	"supertrend": {
+		"binance-default": {"period": 10, "multiplier": 3},
+		"scalping":        {"period": 7, "multiplier": 2},
+		"swing":           {"period": 14, "multiplier": 4},
+	},
+	"stochrsi": {
+		"binance-default": {"rsi_period": 14, "stoch_period": 14, "k_period": 3, "d_period": 3},
+		"scalping":        {"rsi_period": 7, "stoch_period": 7, "k_period": 3, "d_period": 3},
+		"swing":           {"rsi_period": 21, "stoch_period": 21, "k_period": 5, "d_period": 5},
+	},
+}
+
+// GetPreset 获取指标的命名参数预设
+// 参数：
+//   - indicator: 指标名称（小写），如 "rsi"、"macd"
+//   - name: 预设名称，如 "binance-default"、"scalping"、"swing"
+//
+// 返回值：
+//   - map[string]float64: 参数名到参数值的映射（副本，可自由修改）
+//   - error: 指标或预设不存在时返回错误
+//
+// 示例：
+//
+//	params, err := GetPreset("macd", "scalping")
+//	macd, err := klineData.MACD("close", int(params["short_period"]), int(params["long_period"]), int(params["signal_period"]))
+func GetPreset(indicator, name string) (map[string]float64, error) {
+	presets, ok := indicatorPresets[indicator]
+	if !ok {
+		return nil, fmt.Errorf("指标 %s 没有预设", indicator)
+	}
+	preset, ok := presets[name]
+	if !ok {
+		return nil, fmt.Errorf("指标 %s 没有名为 %s 的预设", indicator, name)
+	}
+	params := make(map[string]float64, len(preset))
+	for k, v := range preset {
+		params[k] = v
+	}
+	return params, nil
+}
+
+// PresetNames 返回指标可用的预设名称（按字母排序）
+func PresetNames(indicator string) []string {
+	var names []string
+	for name := range indicatorPresets[indicator] {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// TuneObjective 参数寻优的目标函数，返回值越大越好
+type TuneObjective func(klineData KlineDatas, period int) (float64, error)
+
+// TaTune 参数寻优结果
+// 字段：
+//   - Period: 得分最高的周期
+//   - Score: 最高得分
+//   - Periods: 参与评估的所有周期
+//   - Scores: 各周期对应的得分
+type TaTune struct {
+	Period  int       `json:"period"`
+	Score   float64   `json:"score"`
+	Periods []int     `json:"periods"`
+	Scores  []float64 `json:"scores"`
+}
+
+// AutoTune 在给定范围内按目标函数搜索最优周期
+// 参数：
+//   - klineData: K 线数据
+//   - minPeriod: 最小周期
+//   - maxPeriod: 最大周期
+//   - objective: 目标函数，如 EMAHitRateObjective(1)
+//
+// 返回值：
+//   - *TaTune: 寻优结果
+//   - error: 参数无效或所有周期都无法计算时返回错误，后者包含最后一个周期的错误
+//
+// 说明/注意事项：
+//
+//	不小于 K 线数量的周期不参与评估，目标函数返回错误的周期（如数据不足）会被跳过。
+//	寻优结果基于历史数据，存在过拟合风险，建议配合样本外验证使用。
+//
+// 示例：
+//
+//	tune, err := AutoTune(klineData, 5, 50, EMAHitRateObjective(1))
+//	ema, err := klineData.EMA(tune.Period, "close")
+func AutoTune(klineData KlineDatas, minPeriod, maxPeriod int, objective TuneObjective) (*TaTune, error) {
+	if minPeriod <= 0 || maxPeriod < minPeriod {
+		return nil, fmt.Errorf("周期范围无效: %d-%d", minPeriod, maxPeriod)
+	}
+
+	if maxPeriod >= len(klineData) {
+		maxPeriod = len(klineData) - 1
+	}
+	if maxPeriod < minPeriod {
+		return nil, fmt.Errorf("计算数据不足: 数据长度%d, 最小周期%d", len(klineData), minPeriod)
+	}
+
+	result := &TaTune{}
+	found := false
+	var lastErr error
+	for period := minPeriod; period <= maxPeriod; period++ {
+		score, err := objective(klineData, period)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		result.Periods = append(result.Periods, period)
+		result.Scores = append(result.Scores, score)
+		if !found || score > result.Score {
+			result.Period = period
+			result.Score = score
+			found = true
+		}
+	}
+	if !found {
+		return nil, fmt.Errorf("所有周期都无法计算: %v", lastErr)
+	}
+	return result, nil
+}
+
+// EMAHitRateObjective 以 EMA 方向预测的命中率作为目标函数
+// 参数：
+//   - horizon: 预测的前瞻 K 线数量，必须大于0
+//
+// 返回值：
+//   - TuneObjective: 目标函数，得分为收盘价位于 EMA 同侧时未来 horizon 根收益同向的比例
+func EMAHitRateObjective(horizon int) TuneObjective {
+	return func(klineData KlineDatas, period int) (float64, error) {
+		if horizon <= 0 {
+			return 0, fmt.Errorf("前瞻K线数量必须大于0")
+		}
+		closes, err := klineData.ExtractSlice("close")
+		if err != nil {
+			return 0, err
+		}
+		ema, err := CalculateEMA(closes, period)
+		if err != nil {
+			return 0, err
+		}
+		var hits, total int
+		for i := period - 1; i+horizon < len(closes); i++ {
+			diff := closes[i] - ema.Values[i]
+			ret := closes[i+horizon] - closes[i]
+			if diff == 0 || ret == 0 {
+				continue
+			}
+			total++
+			if (diff > 0) == (ret > 0) {
+				hits++
+			}
+		}
+		if total == 0 {
+			return 0, fmt.Errorf("计算数据不足")
+		}
+		return float64(hits) / float64(total), nil
+	}
+}
+
+// RSIReversionObjective 以 RSI 超买超卖反转的命中率作为目标函数
+// 参数：
+//   - horizon: 预测的前瞻 K 线数量，必须大于0
+//   - lower: 超卖阈值，如 30
+//   - upper: 超买阈值，如 70
+//
+// 返回值：
+//   - TuneObjective: 目标函数，得分为超卖后上涨、超买后下跌的比例
+func RSIReversionObjective(horizon int, lower, upper float64) TuneObjective {
+	return func(klineData KlineDatas, period int) (float64, error) {
+		if horizon <= 0 {
+			return 0, fmt.Errorf("前瞻K线数量必须大于0")
+		}
+		closes, err := klineData.ExtractSlice("close")
+		if err != nil {
+			return 0, err
+		}
+		rsi, err := CalculateRSI(closes, period)
+		if err != nil {
+			return 0, err
+		}
+		var hits, total int
+		for i := period; i+horizon < len(closes); i++ {
+			ret := closes[i+horizon] - closes[i]
+			switch {
+			case rsi.Values[i] < lower:
+				total++
+				if ret > 0 {
+					hits++
+				}
+			case rsi.Values[i] > upper:
+				total++
+				if ret < 0 {
+					hits++
+				}
+			}
+		}
+		if total == 0 {
+			return 0, fmt.Errorf("没有触发超买超卖信号")
+		}
+		return float64(hits) / float64(total), nil
+	}
+}
+
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
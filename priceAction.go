@@ -0,0 +1,203 @@
+package ta
+
+import (
+	"fmt"
+	"math"
+)
+
+// TaPriceAction 价格行为形态的计算结果
+// 字段：
+//   - Streak: 连续收涨（正数）或收跌（负数）的 K 线数量，平收时为 0
+//   - InsideBar: 是否为内包线（高点不高于、低点不低于前一根）
+//   - OutsideBar: 是否为外包线（高点高于且低点低于前一根）
+//   - NR4: 是否为最近 4 根中振幅最小的 K 线
+//   - NR7: 是否为最近 7 根中振幅最小的 K 线
+type TaPriceAction struct {
+	Streak     []int  `json:"streak"`
+	InsideBar  []bool `json:"inside_bar"`
+	OutsideBar []bool `json:"outside_bar"`
+	NR4        []bool `json:"nr4"`
+	NR7        []bool `json:"nr7"`
+}
+
+// TaPatternStats 形态出现后的前瞻收益统计
+// 字段：
+//   - Count: 形态出现次数（仅统计有足够前瞻数据的样本）
+//   - MeanReturn: 平均前瞻收益
+//   - StdDev: 前瞻收益标准差
+//   - WinRate: 前瞻收益为正的比例
+//   - Horizon: 前瞻 K 线数量
+type TaPatternStats struct {
+	Count      int     `json:"count"`
+	MeanReturn float64 `json:"mean_return"`
+	StdDev     float64 `json:"std_dev"`
+	WinRate    float64 `json:"win_rate"`
+	Horizon    int     `json:"horizon"`
+}
+
+// CalculatePriceAction 计算价格行为形态
+// 参数：
+//   - klineData: K 线数据
+//
+// 返回值：
+//   - *TaPriceAction: 计算结果
+//   - error: 数据不足时返回错误
+//
+// 示例：
+//
+//	pa, err := CalculatePriceAction(klineData)
+//	if pa.NR7[len(pa.NR7)-1] {
+//	    // 窄幅整理，关注突破
+//	}
+func CalculatePriceAction(klineData KlineDatas) (*TaPriceAction, error) {
+	length := len(klineData)
+	if length < 2 {
+		return nil, fmt.Errorf("计算数据不足")
+	}
+
+	result := &TaPriceAction{
+		Streak:     make([]int, length),
+		InsideBar:  make([]bool, length),
+		OutsideBar: make([]bool, length),
+		NR4:        make([]bool, length),
+		NR7:        make([]bool, length),
+	}
+
+	isNarrowest := func(i, n int) bool {
+		if i < n-1 {
+			return false
+		}
+		r := klineData[i].High - klineData[i].Low
+		for j := i - n + 1; j < i; j++ {
+			if klineData[j].High-klineData[j].Low <= r {
+				return false
+			}
+		}
+		return true
+	}
+
+	for i := 1; i < length; i++ {
+		cur, prev := klineData[i], klineData[i-1]
+		switch {
+		case cur.Close > prev.Close:
+			if result.Streak[i-1] > 0 {
+				result.Streak[i] = result.Streak[i-1] + 1
+			} else {
+				result.Streak[i] = 1
+			}
+		case cur.Close < prev.Close:
+			if result.Streak[i-1] < 0 {
+				result.Streak[i] = result.Streak[i-1] - 1
+			} else {
+				result.Streak[i] = -1
+			}
+		}
+		result.InsideBar[i] = cur.High <= prev.High && cur.Low >= prev.Low
+		result.OutsideBar[i] = cur.High > prev.High && cur.Low < prev.Low
+		result.NR4[i] = isNarrowest(i, 4)
+		result.NR7[i] = isNarrowest(i, 7)
+	}
+
+	return result, nil
+}
+
+// PriceAction 计算 K 线数据的价格行为形态
+func (k *KlineDatas) PriceAction() (*TaPriceAction, error) {
+	return CalculatePriceAction(*k)
+}
+
+// CalculatePatternStats 统计形态出现后 horizon 根 K 线的收盘收益
+// 参数：
+//   - klineData: K 线数据
+//   - flags: 形态标记序列，长度与 K 线一致
+//   - horizon: 前瞻 K 线数量
+//
+// 返回值：
+//   - *TaPatternStats: 统计结果
+//   - error: 参数无效或长度不一致时返回错误
+//
+// 示例：
+//
+//	stats, err := CalculatePatternStats(klineData, pa.NR7, 5)
+func CalculatePatternStats(klineData KlineDatas, flags []bool, horizon int) (*TaPatternStats, error) {
+	if len(flags) != len(klineData) {
+		return nil, fmt.Errorf("输入数据长度不一致")
+	}
+	if horizon <= 0 {
+		return nil, fmt.Errorf("前瞻数量必须大于0")
+	}
+
+	var returns []float64
+	for i := 0; i+horizon < len(klineData); i++ {
+		if !flags[i] || klineData[i].Close == 0 {
+			continue
+		}
+		returns = append(returns, klineData[i+horizon].Close/klineData[i].Close-1)
+	}
+
+	stats := &TaPatternStats{Count: len(returns), Horizon: horizon}
+	if len(returns) == 0 {
+		return stats, nil
+	}
+	var sum float64
+	var wins int
+	for _, r := range returns {
+		sum += r
+		if r > 0 {
+			wins++
+		}
+	}
+	stats.MeanReturn = sum / float64(len(returns))
+	stats.WinRate = float64(wins) / float64(len(returns))
+	var sumSquares float64
+	for _, r := range returns {
+		diff := r - stats.MeanReturn
+		sumSquares += diff * diff
+	}
+	stats.StdDev = math.Sqrt(sumSquares / float64(len(returns)))
+	return stats, nil
+}
+
+// StreakFlags 返回连续收涨（n>0）或收跌（n<0）至少 |n| 根的位置标记，可用于 CalculatePatternStats
+func (t *TaPriceAction) StreakFlags(n int) []bool {
+	flags := make([]bool, len(t.Streak))
+	for i, s := range t.Streak {
+		if n > 0 {
+			flags[i] = s >= n
+		} else if n < 0 {
+			flags[i] = s <= n
+		}
+	}
+	return flags
+}
+
+// FeatureNames 返回 Features 各列的名称
+func (t *TaPriceAction) FeatureNames() []string {
+	return []string{"streak", "inside_bar", "outside_bar", "nr4", "nr7"}
+}
+
+// Features 将形态转换为特征矩阵，rows[i] 为第 i 根 K 线的特征，布尔值以 1/0 表示
+func (t *TaPriceAction) Features() [][]float64 {
+	rows := make([][]float64, len(t.Streak))
+	for i := range rows {
+		rows[i] = []float64{
+			float64(t.Streak[i]),
+			boolValue(t.InsideBar[i]),
+			boolValue(t.OutsideBar[i]),
+			boolValue(t.NR4[i]),
+			boolValue(t.NR7[i]),
+		}
+	}
+	return rows
+}
+
+// Value 返回最新一根 K 线的连续涨跌数量
+func (t *TaPriceAction) Value() int {
+	return t.Streak[len(t.Streak)-1]
+}
+
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
@@ -0,0 +1,130 @@
+package ta
+
+import (
+	"fmt"
+	"math"
+)
+
+// TaProjection 未收盘 K 线的指标投影结果
+// 字段：
+//   - Confirmed: 已确认值，只使用已收盘 K 线计算
+//   - Provisional: 临时值，假设未收盘 K 线此刻收盘（"if closed now"），会随行情变化
+//   - Projected: 推算值，将未收盘 K 线按简单模型推算到收盘后计算；未要求推算时为 nil
+//   - Progress: 当前 K 线已经过的时间比例，取值 (0, 1]
+//
+// 说明：
+//
+//	三组结果的键名均与 SnapshotConfig 中的指标名一致，只有 Confirmed 可作为确认信号使用。
+type TaProjection struct {
+	Confirmed   map[string]float64 `json:"confirmed"`
+	Provisional map[string]float64 `json:"provisional"`
+	Projected   map[string]float64 `json:"projected"`
+	Progress    float64            `json:"progress"`
+}
+
+// ProjectBar 将未收盘 K 线按简单模型推算到收盘
+// 参数：
+//   - partial: 未收盘 K 线
+//   - progress: 已经过的时间比例，取值 (0, 1]
+//
+// 返回值：
+//   - *KlineData: 推算的收盘 K 线，原 K 线不变
+//
+// 说明/注意事项：
+//
+//	收盘价保持当前价（随机游走下的期望），成交量按时间比例线性外推，
+//	价格区间按 1/√progress 放大，扩展部分以当前价为中心向上下两侧等分，且不小于已出现的最高价和最低价。
+func ProjectBar(partial *KlineData, progress float64) *KlineData {
+	projected := *partial
+	if progress <= 0 || progress >= 1 {
+		return &projected
+	}
+	extra := (partial.High - partial.Low) * (1/math.Sqrt(progress) - 1) / 2
+	projected.High = math.Max(partial.High, partial.Close+extra)
+	projected.Low = math.Min(partial.Low, partial.Close-extra)
+	projected.Volume = partial.Volume / progress
+	return &projected
+}
+
+// Project 计算未收盘 K 线的已确认值、临时值和推算值
+// 参数：
+//   - partial: 实时推送的未收盘 K 线
+//   - config: 指标快照配置
+//   - interval: K 线周期的毫秒数，可由 ParseInterval 得到
+//   - now: 当前时间（毫秒）
+//   - extrapolate: 是否额外计算推算到收盘的值
+//
+// 返回值：
+//   - *TaProjection: 投影结果
+//   - error: 参数无效、未收盘 K 线早于已有数据或指标计算失败时返回错误
+//
+// 说明/注意事项：
+//
+//	k 应只包含已收盘 K 线；若最后一根与 partial 的开始时间相同，则视为旧的未收盘数据并在计算时替换。
+//	now 早于 partial 开始时间时按刚开盘处理（progress 取最小值 1/interval），超过结束时间时 progress 为 1。
+//
+// 示例：
+//
+//	interval, _ := ParseInterval("1h")
+//	proj, err := klineData.Project(live, config, interval, time.Now().UnixMilli(), true)
+//	if proj.Confirmed["rsi14"] < 30 {
+//	    // 已收盘 K 线确认的超卖
+//	} else if proj.Provisional["rsi14"] < 30 {
+//	    // 尚未确认，收盘前可能消失
+//	}
+func (k *KlineDatas) Project(partial *KlineData, config SnapshotConfig, interval, now int64, extrapolate bool) (*TaProjection, error) {
+	if partial == nil {
+		return nil, fmt.Errorf("K线为空")
+	}
+	if interval <= 0 {
+		return nil, fmt.Errorf("周期必须大于0")
+	}
+
+	closed := *k
+	if n := len(closed); n > 0 {
+		last := closed[n-1].StartTime
+		if last == partial.StartTime {
+			closed = closed[:n-1]
+		} else if last > partial.StartTime {
+			return nil, fmt.Errorf("K线开始时间 %d 早于最后一根 %d", partial.StartTime, last)
+		}
+	}
+
+	progress := float64(now-partial.StartTime) / float64(interval)
+	progress = math.Min(math.Max(progress, 1/float64(interval)), 1)
+	proj := &TaProjection{Progress: progress}
+
+	if len(closed) > 0 {
+		confirmed, err := closed.Snapshot(config)
+		if err != nil {
+			return nil, err
+		}
+		proj.Confirmed = confirmed
+	}
+
+	live := make(KlineDatas, len(closed)+1)
+	copy(live, closed)
+	current := *partial
+	live[len(closed)] = &current
+	provisional, err := live.Snapshot(config)
+	if err != nil {
+		return nil, err
+	}
+	proj.Provisional = provisional
+
+	if extrapolate {
+		live[len(closed)] = ProjectBar(partial, progress)
+		projected, err := live.Snapshot(config)
+		if err != nil {
+			return nil, err
+		}
+		proj.Projected = projected
+	}
+	return proj, nil
+}
+
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
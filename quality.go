@@ -0,0 +1,158 @@
+package ta
+
+import (
+	"fmt"
+	"math"
+)
+
+// K 线数据质量问题类型
+const (
+	// QualityInvalidPrice 价格无效：非正数，或最高/最低价未包含开盘与收盘价
+	QualityInvalidPrice = iota
+	// QualityDuplicate 开始时间与前一根相同或更早
+	QualityDuplicate
+	// QualityGap 与前一根之间缺失 K 线
+	QualityGap
+	// QualityZeroVolume 成交量为 0
+	QualityZeroVolume
+	// QualityOutlierWick 影线长度超过此前 K 线振幅中位数的 WickMult 倍
+	QualityOutlierWick
+)
+
+// qualityPenalty 各类问题对单根 K 线得分的扣分
+var qualityPenalty = map[int]float64{
+	QualityInvalidPrice: 100,
+	QualityDuplicate:    50,
+	QualityGap:          30,
+	QualityZeroVolume:   30,
+	QualityOutlierWick:  40,
+}
+
+// qualityWickWindow 判断异常影线时统计振幅中位数的 K 线数量
+const qualityWickWindow = 50
+
+// QualityIssue 一个数据质量问题
+// 字段：
+//   - Index: 问题所在 K 线的下标
+//   - Type: 问题类型，如 QualityGap
+//   - Message: 问题说明
+type QualityIssue struct {
+	Index   int    `json:"index"`
+	Type    int    `json:"type"`
+	Message string `json:"message"`
+}
+
+// TaQuality K 线数据质量评分
+// 字段：
+//   - Score: 整体得分，0 到 100，为单根得分的均值乘以实际 K 线数占应有 K 线数的比例
+//   - BarScores: 每根 K 线的得分，0 到 100
+//   - Issues: 按下标排列的问题列表
+//   - Missing: 缺失的 K 线总数
+type TaQuality struct {
+	Score     float64        `json:"score"`
+	BarScores []float64      `json:"bar_scores"`
+	Issues    []QualityIssue `json:"issues"`
+	Missing   int            `json:"missing"`
+}
+
+// Quality 检查 K 线数据质量并打分
+// 参数：
+//   - interval: K 线周期的毫秒数，可由 ParseInterval 得到，用于检查缺口
+//   - wickMult: 异常影线倍数，影线长度超过此前 50 根 K 线振幅中位数的 wickMult 倍视为异常，如 10；0 表示不检查
+//
+// 返回值：
+//   - *TaQuality: 质量评分与问题列表
+//   - error: 参数无效或没有数据时返回错误
+//
+// 说明/注意事项：
+//
+//	每根 K 线从 100 分开始，按问题类型扣分（价格无效 100、时间重复 50、异常影线 40、缺口 30、零成交量 30），最低为 0。
+//	可在生成交易信号前跳过得分过低的交易对；缺口可配合 Reconcile 重新拉取补齐。
+//
+// 示例：
+//
+//	interval, _ := ParseInterval("1m")
+//	quality, err := klineData.Quality(interval, 10)
+//	if quality.Score < 90 {
+//	    // 数据源不可靠，跳过该交易对
+//	}
+func (k *KlineDatas) Quality(interval int64, wickMult float64) (*TaQuality, error) {
+	if interval <= 0 {
+		return nil, fmt.Errorf("周期必须大于0")
+	}
+	if wickMult < 0 {
+		return nil, fmt.Errorf("异常影线倍数不能为负数")
+	}
+	length := len(*k)
+	if length == 0 {
+		return nil, fmt.Errorf("没有K线数据")
+	}
+
+	q := &TaQuality{BarScores: make([]float64, length)}
+	report := func(i, typ int, format string, args ...interface{}) {
+		q.Issues = append(q.Issues, QualityIssue{Index: i, Type: typ, Message: fmt.Sprintf(format, args...)})
+		q.BarScores[i] = math.Max(q.BarScores[i]-qualityPenalty[typ], 0)
+	}
+
+	var medians []float64
+	if wickMult > 0 {
+		ranges := make([]float64, length)
+		for i, kline := range *k {
+			ranges[i] = kline.High - kline.Low
+		}
+		medians, _ = MedianFilter(ranges, qualityWickWindow)
+	}
+
+	for i, kline := range *k {
+		q.BarScores[i] = 100
+		bodyHigh, bodyLow := math.Max(kline.Open, kline.Close), math.Min(kline.Open, kline.Close)
+		if kline.Open <= 0 || kline.Close <= 0 || kline.Low <= 0 || kline.High < bodyHigh || kline.Low > bodyLow {
+			report(i, QualityInvalidPrice, "价格无效: O=%v H=%v L=%v C=%v", kline.Open, kline.High, kline.Low, kline.Close)
+		}
+		if kline.Volume == 0 {
+			report(i, QualityZeroVolume, "成交量为0")
+		}
+		if i == 0 {
+			continue
+		}
+
+		prev := (*k)[i-1]
+		if kline.StartTime <= prev.StartTime {
+			report(i, QualityDuplicate, "开始时间 %d 不晚于前一根 %d", kline.StartTime, prev.StartTime)
+		} else if missing := int((kline.StartTime-prev.StartTime)/interval) - 1; missing > 0 {
+			q.Missing += missing
+			report(i, QualityGap, "之前缺失%d根K线", missing)
+		}
+
+		if medians != nil && medians[i-1] > 0 {
+			wick := math.Max(kline.High-bodyHigh, bodyLow-kline.Low)
+			if wick > wickMult*medians[i-1] {
+				report(i, QualityOutlierWick, "影线长度为振幅中位数的%.1f倍", wick/medians[i-1])
+			}
+		}
+	}
+
+	var sum float64
+	for _, s := range q.BarScores {
+		sum += s
+	}
+	q.Score = sum / float64(length+q.Missing)
+	return q, nil
+}
+
+// Count 返回指定类型的问题数量
+func (t *TaQuality) Count(typ int) int {
+	count := 0
+	for _, issue := range t.Issues {
+		if issue.Type == typ {
+			count++
+		}
+	}
+	return count
+}
+
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
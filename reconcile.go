@@ -0,0 +1,112 @@
+package ta
+
+import (
+	"fmt"
+	"sort"
+)
+
+// ReconcileReport 历史数据与实时流合并的检查结果
+// 字段：
+//   - Overlap: 两者开始时间相同的 K 线数量
+//   - Replaced: 重叠部分中数值不一致、以实时流为准替换的数量
+//   - Duplicates: 同一来源内开始时间重复而被丢弃的数量
+//   - Missing: 合并后仍缺失的 K 线开始时间
+type ReconcileReport struct {
+	Overlap    int     `json:"overlap"`
+	Replaced   int     `json:"replaced"`
+	Duplicates int     `json:"duplicates"`
+	Missing    []int64 `json:"missing"`
+}
+
+// dedupeKlines 按开始时间排序并去重，同一时间保留最后出现的一根，返回去重数量
+func dedupeKlines(klines KlineDatas) (KlineDatas, int) {
+	sorted := make(KlineDatas, len(klines))
+	copy(sorted, klines)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].StartTime < sorted[j].StartTime
+	})
+	out := sorted[:0]
+	duplicates := 0
+	for _, kline := range sorted {
+		if len(out) > 0 && out[len(out)-1].StartTime == kline.StartTime {
+			out[len(out)-1] = kline
+			duplicates++
+			continue
+		}
+		out = append(out, kline)
+	}
+	return out, duplicates
+}
+
+// Reconcile 合并 REST 拉取的历史 K 线与 websocket 实时 K 线
+// 参数：
+//   - history: REST 接口拉取的历史 K 线
+//   - stream: websocket 推送的 K 线，可包含与历史重叠的部分和未收盘的 K 线
+//   - interval: K 线周期的毫秒数，可由 ParseInterval 得到
+//
+// 返回值：
+//   - KlineDatas: 按开始时间升序、无重复的合并结果
+//   - *ReconcileReport: 重叠、替换、去重和缺失情况
+//   - error: 周期无效时返回错误
+//
+// 说明/注意事项：
+//
+//	开始时间相同时以实时流为准，因为历史数据的最后一根通常是拉取时尚未收盘的 K 线。
+//	缺失的 K 线不会被填充，调用方可根据 Missing 重新拉取对应区间后再次合并。
+//
+// 示例：
+//
+//	interval, _ := ParseInterval("1m")
+//	merged, report, err := Reconcile(history, stream, interval)
+//	if len(report.Missing) > 0 {
+//	    // 重新拉取缺失区间
+//	}
+func Reconcile(history, stream KlineDatas, interval int64) (KlineDatas, *ReconcileReport, error) {
+	if interval <= 0 {
+		return nil, nil, fmt.Errorf("周期必须大于0")
+	}
+	report := &ReconcileReport{}
+
+	history, dupHistory := dedupeKlines(history)
+	stream, dupStream := dedupeKlines(stream)
+	report.Duplicates = dupHistory + dupStream
+
+	merged := make(KlineDatas, 0, len(history)+len(stream))
+	i, j := 0, 0
+	for i < len(history) || j < len(stream) {
+		switch {
+		case j == len(stream) || (i < len(history) && history[i].StartTime < stream[j].StartTime):
+			merged = append(merged, history[i])
+			i++
+		case i == len(history) || stream[j].StartTime < history[i].StartTime:
+			merged = append(merged, stream[j])
+			j++
+		default:
+			report.Overlap++
+			if *history[i] != *stream[j] {
+				report.Replaced++
+			}
+			merged = append(merged, stream[j])
+			i++
+			j++
+		}
+	}
+
+	for n := 1; n < len(merged); n++ {
+		for t := merged[n-1].StartTime + interval; t < merged[n].StartTime; t += interval {
+			report.Missing = append(report.Missing, t)
+		}
+	}
+	return merged, report, nil
+}
+
+// Reconcile 将实时 K 线合并到当前 K 线之后，含义同 Reconcile 函数
+func (k *KlineDatas) Reconcile(stream KlineDatas, interval int64) (KlineDatas, *ReconcileReport, error) {
+	return Reconcile(*k, stream, interval)
+}
+
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
@@ -0,0 +1,184 @@
+package ta
+
+import (
+	"fmt"
+	"math"
+)
+
+// 砖块大小的计算方式
+const (
+	RenkoFixed = iota // 固定价格
+	RenkoATR          // ATR 倍数，每块砖使用形成时的 ATR
+)
+
+// renkoMinBrickRatio 砖块大小相对价格的下限，低于该值的 K 线不加砖，
+// 避免横盘时 ATR 持续衰减后砖块小到加不动价格（top+s == top）而无限加砖
+const renkoMinBrickRatio = 1e-9
+
+// TaRenko 砖形图（Renko）的计算结果
+// 字段：
+//   - Bricks: 砖块序列，可直接调用任意指标方法；阳砖 Open 为下沿、Close 为上沿，阴砖相反，High/Low 为上下沿
+//   - Direction: 每块砖的方向，1 为阳砖，-1 为阴砖
+//   - SourceIndex: 每块砖形成时的原始 K 线下标
+//   - Size: 每块砖的大小
+//   - Mode: 砖块大小的计算方式
+//
+// 说明：
+//
+//	同一根 K 线内形成的多块砖 StartTime 相同，该 K 线及之前未成砖 K 线的成交量计入其中第一块砖。
+type TaRenko struct {
+	Bricks      KlineDatas `json:"bricks"`
+	Direction   []int      `json:"direction"`
+	SourceIndex []int      `json:"source_index"`
+	Size        []float64  `json:"size"`
+	Mode        int        `json:"mode"`
+}
+
+// CalculateRenko 将 K 线按收盘价转换为砖形图
+// 参数：
+//   - klineData: K 线数据
+//   - mode: 砖块大小的计算方式，RenkoFixed 或 RenkoATR
+//   - size: RenkoFixed 时为砖块价格大小，RenkoATR 时为 ATR 倍数
+//   - atrPeriod: RenkoATR 时的 ATR 周期，RenkoFixed 时忽略
+//
+// 返回值：
+//   - *TaRenko: 砖形图结果
+//   - error: 参数无效、数据不足或没有形成任何砖块时返回错误
+//
+// 说明/注意事项：
+//
+//	收盘价超出最后一块砖的上沿（或下沿）一个砖块大小时顺势加砖，反向超出另一侧一个砖块大小（即两块砖的距离）时反转。
+//	RenkoATR 模式从 ATR 预热结束后开始，每块砖的大小取形成时 K 线的 ATR 乘以倍数，不使用未来数据，因此砖块大小不完全相同。
+//	砖块不含时间均匀性，基于砖块计算的指标周期表示砖块数量而非时间长度。
+//	砖块大小小于价格的十亿分之一时（如长时间完全横盘后的 ATR）该 K 线不加砖，成交量累计到下一块砖。
+//
+// 示例：
+//
+//	renko, err := CalculateRenko(klineData, RenkoATR, 1, 14)
+//	if err != nil {
+//	    // 处理错误
+//	}
+//	ema, err := renko.Bricks.EMA(10, "close")
+func CalculateRenko(klineData KlineDatas, mode int, size float64, atrPeriod int) (*TaRenko, error) {
+	if size <= 0 {
+		return nil, fmt.Errorf("砖块大小必须大于0")
+	}
+	length := len(klineData)
+	if length < 2 {
+		return nil, fmt.Errorf("计算数据不足")
+	}
+
+	start := 0
+	brickSize := func(int) float64 { return size }
+	switch mode {
+	case RenkoFixed:
+	case RenkoATR:
+		if atrPeriod <= 0 {
+			return nil, fmt.Errorf("周期必须大于0")
+		}
+		if length <= atrPeriod {
+			return nil, fmt.Errorf("计算数据不足")
+		}
+		atr, err := CalculateATR(klineData, atrPeriod)
+		if err != nil {
+			return nil, err
+		}
+		start = atrPeriod
+		brickSize = func(i int) float64 { return atr.Values[i] * size }
+	default:
+		return nil, fmt.Errorf("未知的砖块模式: %d", mode)
+	}
+
+	result := &TaRenko{Mode: mode}
+	var volume float64
+	addBrick := func(i, direction int, from, to, s float64) {
+		result.Bricks = append(result.Bricks, &KlineData{
+			StartTime: klineData[i].StartTime,
+			Open:      from,
+			High:      math.Max(from, to),
+			Low:       math.Min(from, to),
+			Close:     to,
+			Volume:    volume,
+		})
+		volume = 0
+		result.Direction = append(result.Direction, direction)
+		result.SourceIndex = append(result.SourceIndex, i)
+		result.Size = append(result.Size, s)
+	}
+
+	// top/bottom 为最后一块砖的上下沿，没有砖时均为基准价
+	top, bottom := klineData[start].Close, klineData[start].Close
+	for i := start + 1; i < length; i++ {
+		volume += klineData[i].Volume
+		s := brickSize(i)
+		price := klineData[i].Close
+		if !(s > renkoMinBrickRatio*math.Max(math.Abs(price), math.Abs(top))) || top+s <= top || bottom-s >= bottom {
+			continue
+		}
+		for {
+			if price >= top+s {
+				addBrick(i, 1, top, top+s, s)
+				bottom, top = top, top+s
+			} else if price <= bottom-s {
+				addBrick(i, -1, bottom, bottom-s, s)
+				top, bottom = bottom, bottom-s
+			} else {
+				break
+			}
+		}
+	}
+
+	if len(result.Bricks) == 0 {
+		return nil, fmt.Errorf("价格波动不足以形成砖块")
+	}
+	return result, nil
+}
+
+// Renko 将 K 线转换为砖形图，参数含义同 CalculateRenko
+func (k *KlineDatas) Renko(mode int, size float64, atrPeriod int) (*TaRenko, error) {
+	return CalculateRenko(*k, mode, size, atrPeriod)
+}
+
+// Value 返回最后一块砖的方向，1 为阳砖，-1 为阴砖
+func (t *TaRenko) Value() int {
+	return t.Direction[len(t.Direction)-1]
+}
+
+// Streak 返回截至最后一块砖的连续同向砖块数量，阳砖为正、阴砖为负
+func (t *TaRenko) Streak() int {
+	last := len(t.Direction) - 1
+	count := 0
+	for i := last; i >= 0 && t.Direction[i] == t.Direction[last]; i-- {
+		count++
+	}
+	return count * t.Direction[last]
+}
+
+// IsReversal 判断最后一块砖是否与前一块方向相反
+func (t *TaRenko) IsReversal() bool {
+	last := len(t.Direction) - 1
+	return last >= 1 && t.Direction[last] != t.Direction[last-1]
+}
+
+// DirectionSeries 将砖块方向展开为与原始 K 线对齐的序列，每根 K 线取截至该 K 线最后一块砖的方向，之前为 0
+// 参数：
+//   - length: 原始 K 线数量
+func (t *TaRenko) DirectionSeries(length int) []float64 {
+	out := make([]float64, length)
+	b := 0
+	current := 0.0
+	for i := 0; i < length; i++ {
+		for b < len(t.SourceIndex) && t.SourceIndex[b] <= i {
+			current = float64(t.Direction[b])
+			b++
+		}
+		out[i] = current
+	}
+	return out
+}
+
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
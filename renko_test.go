@@ -0,0 +1,63 @@
+package ta
+
+import (
+	"testing"
+)
+
+func TestCalculateRenkoFixed(t *testing.T) {
+	closes := []float64{10, 11, 12.5, 13, 11.9, 10.4, 10}
+	klineData := make(KlineDatas, len(closes))
+	for i, c := range closes {
+		klineData[i] = &KlineData{StartTime: int64(i), Open: c, High: c, Low: c, Close: c, Volume: 1}
+	}
+	renko, err := CalculateRenko(klineData, RenkoFixed, 1, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// 11、12.5、13 各加一块阳砖，11.9 未跌破 12−1 不动，10.4 反转一块阴砖到 11，10 再加一块到 10
+	want := []struct {
+		direction   int
+		open, close float64
+		source      int
+	}{
+		{1, 10, 11, 1},
+		{1, 11, 12, 2},
+		{1, 12, 13, 3},
+		{-1, 12, 11, 5},
+		{-1, 11, 10, 6},
+	}
+	if len(renko.Bricks) != len(want) {
+		t.Fatalf("砖块数量 = %d, want %d", len(renko.Bricks), len(want))
+	}
+	for i, w := range want {
+		b := renko.Bricks[i]
+		if renko.Direction[i] != w.direction || b.Open != w.open || b.Close != w.close || renko.SourceIndex[i] != w.source {
+			t.Errorf("砖块 %d = {%d %v %v %d}, want %v", i, renko.Direction[i], b.Open, b.Close, renko.SourceIndex[i], w)
+		}
+	}
+}
+
+func TestCalculateRenkoATRFlatMarket(t *testing.T) {
+	// 长时间完全横盘使 ATR 衰减到远小于价格精度，之后的波动不应无限加砖
+	var klineData KlineDatas
+	price := 100.0
+	for i := 0; i < 40; i++ {
+		price += 1
+		klineData = append(klineData, &KlineData{StartTime: int64(i), Open: price - 1, High: price, Low: price - 1, Close: price, Volume: 1})
+	}
+	for i := 0; i < 500; i++ {
+		klineData = append(klineData, &KlineData{StartTime: int64(40 + i), Open: price, High: price, Low: price, Close: price, Volume: 1})
+	}
+	klineData = append(klineData, &KlineData{StartTime: 540, Open: price, High: price + 1, Low: price, Close: price + 1, Volume: 1})
+
+	renko, err := CalculateRenko(klineData, RenkoATR, 1, 14)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, s := range renko.Size {
+		if s < renkoMinBrickRatio*price {
+			t.Fatalf("砖块 %d 大小 %v 低于下限", i, s)
+		}
+	}
+}
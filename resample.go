@@ -0,0 +1,190 @@
+package ta
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ParseInterval 将周期字符串解析为毫秒数
+// 参数：
+//   - interval: 周期字符串，支持 s/m/h/d/w 单位，如 "15m"、"4h"、"1d"
+//
+// 返回值：
+//   - int64: 周期对应的毫秒数
+//   - error: 格式无效时返回错误
+//
+// 示例：
+//
+//	ms, err := ParseInterval("4h") // 14400000
+func ParseInterval(interval string) (int64, error) {
+	interval = strings.TrimSpace(interval)
+	if len(interval) < 2 {
+		return 0, fmt.Errorf("无效的周期: %q", interval)
+	}
+	n, err := strconv.ParseInt(interval[:len(interval)-1], 10, 64)
+	if err != nil || n <= 0 {
+		return 0, fmt.Errorf("无效的周期: %q", interval)
+	}
+	var unit int64
+	switch interval[len(interval)-1] {
+	case 's':
+		unit = 1000
+	case 'm':
+		unit = 60 * 1000
+	case 'h':
+		unit = 60 * 60 * 1000
+	case 'd':
+		unit = 24 * 60 * 60 * 1000
+	case 'w':
+		unit = 7 * 24 * 60 * 60 * 1000
+	default:
+		return 0, fmt.Errorf("无效的周期单位: %q", interval)
+	}
+	return n * unit, nil
+}
+
+// Resample 将 K 线聚合为更大周期的 K 线
+// 参数：
+//   - interval: 目标周期的毫秒数，可由 ParseInterval 得到
+//
+// 返回值：
+//   - KlineDatas: 聚合后的 K 线，StartTime 为所在周期的起始时间
+//   - error: 参数无效或没有数据时返回错误
+//
+// 说明/注意事项：
+//
+//	要求输入 K 线按时间升序排列，以 StartTime 对 interval 取整分桶（UTC 对齐）。
+//	1 周按 UTC 自然周（周一开始）分桶，与 ResampleWeekly(time.UTC, time.Monday) 相同，
+//	避免按 1970-01-01（周四）取整使每周从周四开始；多周无法按自然周对齐，返回错误。
+//	开盘价取桶内第一根，收盘价取最后一根，最高/最低取极值，成交量求和。
+//
+// 示例：
+//
+//	interval, _ := ParseInterval("1h")
+//	hourly, err := klineData.Resample(interval)
+func (k *KlineDatas) Resample(interval int64) (KlineDatas, error) {
+	bucket, err := intervalBucket(interval)
+	if err != nil {
+		return nil, err
+	}
+	return k.resampleBy(bucket)
+}
+
+// intervalBucket 返回毫秒周期的分桶函数，1 周按 UTC 自然周（周一开始）对齐，多周返回错误
+func intervalBucket(interval int64) (func(t int64) int64, error) {
+	if interval <= 0 {
+		return nil, fmt.Errorf("周期必须大于0")
+	}
+	week, _ := ParseInterval("1w")
+	switch {
+	case interval == week:
+		return weekBucket(time.UTC, time.Monday), nil
+	case interval > week && interval%week == 0:
+		return nil, fmt.Errorf("不支持多周重采样: %d 毫秒，请使用 ResampleWeekly", interval)
+	}
+	return func(t int64) int64 {
+		return t - ((t%interval)+interval)%interval
+	}, nil
+}
+
+// weekBucket 返回按自然周分桶的函数，桶起始时间为该周第一天 0 点（所在时区）
+func weekBucket(loc *time.Location, weekStart time.Weekday) func(t int64) int64 {
+	return func(t int64) int64 {
+		date := time.UnixMilli(t).In(loc)
+		offset := (int(date.Weekday()) - int(weekStart) + 7) % 7
+		return time.Date(date.Year(), date.Month(), date.Day()-offset, 0, 0, 0, 0, loc).UnixMilli()
+	}
+}
+
+// ResampleWeekly 将 K 线按日历周聚合
+// 参数：
+//   - loc: 划分自然日使用的时区，为 nil 时使用 UTC
+//   - weekStart: 每周的第一天，ISO 周和币安等交易所的周线为 time.Monday，部分美股工具为 time.Sunday
+//
+// 返回值：
+//   - KlineDatas: 周 K 线，StartTime 为该周第一天 0 点（所在时区）的毫秒时间戳
+//   - error: 没有数据时返回错误
+//
+// 说明/注意事项：
+//
+//	按日历而非固定毫秒数分桶，夏令时切换所在的周也能正确对齐。
+//	输入 K 线的周期应能整除一天（如 1m、1h、1d），否则跨越 0 点的 K 线整根计入开始时间所在的周。
+//
+// 示例：
+//
+//	weekly, err := klineData.ResampleWeekly(time.UTC, time.Monday)
+func (k *KlineDatas) ResampleWeekly(loc *time.Location, weekStart time.Weekday) (KlineDatas, error) {
+	if loc == nil {
+		loc = time.UTC
+	}
+	return k.resampleBy(weekBucket(loc, weekStart))
+}
+
+// ResampleMonthly 将 K 线按日历月聚合
+// 参数：
+//   - months: 每根 K 线包含的月数，1 为月线，3 为季线，12 为年线，需能整除 12
+//   - loc: 划分自然日使用的时区，为 nil 时使用 UTC
+//
+// 返回值：
+//   - KlineDatas: 月 K 线，StartTime 为该周期第一天 0 点（所在时区）的毫秒时间戳
+//   - error: 参数无效或没有数据时返回错误
+//
+// 说明/注意事项：
+//
+//	多月周期从每年 1 月开始对齐，如季线为 1、4、7、10 月。
+//
+// 示例：
+//
+//	monthly, err := klineData.ResampleMonthly(1, nil)
+//	coppock, err := monthly.Coppock(14, 11, 10, "close")
+func (k *KlineDatas) ResampleMonthly(months int, loc *time.Location) (KlineDatas, error) {
+	if months <= 0 || 12%months != 0 {
+		return nil, fmt.Errorf("月数必须能整除12: %d", months)
+	}
+	if loc == nil {
+		loc = time.UTC
+	}
+	return k.resampleBy(func(t int64) int64 {
+		date := time.UnixMilli(t).In(loc)
+		month := date.Month() - (date.Month()-1)%time.Month(months)
+		return time.Date(date.Year(), month, 1, 0, 0, 0, 0, loc).UnixMilli()
+	})
+}
+
+// resampleBy 按 bucket 函数返回的桶起始时间聚合 K 线
+func (k *KlineDatas) resampleBy(bucket func(t int64) int64) (KlineDatas, error) {
+	if len(*k) == 0 {
+		return nil, fmt.Errorf("没有K线数据")
+	}
+
+	var result KlineDatas
+	var current *KlineData
+	for _, kline := range *k {
+		start := bucket(kline.StartTime)
+		if current == nil || start != current.StartTime {
+			current = &KlineData{
+				StartTime: start,
+				Open:      kline.Open,
+				High:      kline.High,
+				Low:       kline.Low,
+				Close:     kline.Close,
+				Volume:    kline.Volume,
+			}
+			result = append(result, current)
+			continue
+		}
+		current.High = max(current.High, kline.High)
+		current.Low = min(current.Low, kline.Low)
+		current.Close = kline.Close
+		current.Volume += kline.Volume
+	}
+	return result, nil
+}
+
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
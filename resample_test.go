@@ -0,0 +1,57 @@
+package ta
+
+import (
+	"testing"
+	"time"
+)
+
+func TestResampleWeekly(t *testing.T) {
+	// 2024-01-03 为周三，共 14 根日线
+	base := time.Date(2024, 1, 3, 0, 0, 0, 0, time.UTC)
+	var klineData KlineDatas
+	for i := 0; i < 14; i++ {
+		klineData = append(klineData, &KlineData{
+			StartTime: base.AddDate(0, 0, i).UnixMilli(),
+			Open:      float64(i), High: float64(i) + 1, Low: float64(i) - 1, Close: float64(i) + 0.5, Volume: 1,
+		})
+	}
+	week, _ := ParseInterval("1w")
+
+	weekly, err := klineData.Resample(week)
+	if err != nil {
+		t.Fatal(err)
+	}
+	calendar, err := klineData.ResampleWeekly(time.UTC, time.Monday)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// 周三到周日 5 根、下一周 7 根、最后一周周一周二 2 根
+	want := []struct {
+		start  time.Time
+		open   float64
+		close  float64
+		volume float64
+	}{
+		{time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), 0, 4.5, 5},
+		{time.Date(2024, 1, 8, 0, 0, 0, 0, time.UTC), 5, 11.5, 7},
+		{time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC), 12, 13.5, 2},
+	}
+	if len(weekly) != len(want) {
+		t.Fatalf("周线数量 = %d, want %d", len(weekly), len(want))
+	}
+	for i, w := range want {
+		got := weekly[i]
+		if got.StartTime != w.start.UnixMilli() || got.Open != w.open || got.Close != w.close || got.Volume != w.volume {
+			t.Errorf("周线 %d = {%v %v %v %v}, want {%v %v %v %v}", i,
+				time.UnixMilli(got.StartTime).UTC(), got.Open, got.Close, got.Volume, w.start, w.open, w.close, w.volume)
+		}
+		if *got != *calendar[i] {
+			t.Errorf("周线 %d 与 ResampleWeekly 不一致: %+v != %+v", i, *got, *calendar[i])
+		}
+	}
+
+	if _, err := klineData.Resample(2 * week); err == nil {
+		t.Error("多周重采样应返回错误")
+	}
+}
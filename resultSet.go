@@ -0,0 +1,358 @@
+package ta
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"path"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// ResultSet 与 K 线时间轴对齐的多通道时间序列容器
+// 说明：
+//
+//	通道名为 "指标.输出"，如 "macd.dif"、"boll.upper"，所有通道与 StartTime 等长。
+//	用于多指标结果的统一后处理：按名称选择、按时间合并不同来源的结果、导出 CSV/JSON 或绘图。
+//
+// 字段：
+//   - StartTime: 每根 K 线的开始时间
+//   - Names: 通道名，按添加顺序排列
+//   - Channels: 通道名到序列的映射
+type ResultSet struct {
+	StartTime []int64              `json:"start_time"`
+	Names     []string             `json:"names"`
+	Channels  map[string][]float64 `json:"channels"`
+}
+
+// NewResultSet 以 K 线的时间轴创建空的结果集
+// 参数：
+//   - klineData: K 线数据，只使用开始时间
+//
+// 返回值：
+//   - *ResultSet: 没有通道的结果集
+//
+// 示例：
+//
+//	rs := NewResultSet(klineData)
+//	macd, _ := klineData.MACD("close", 12, 26, 9)
+//	boll, _ := klineData.Boll(20, 2, "close")
+//	rs.AddResult("macd", macd)
+//	rs.AddResult("boll", boll)
+//	rs.Add("close", closes)
+//	rs.Select("macd.*", "boll.upper").WriteCSV(os.Stdout)
+func NewResultSet(klineData KlineDatas) *ResultSet {
+	rs := &ResultSet{
+		StartTime: make([]int64, len(klineData)),
+		Channels:  make(map[string][]float64),
+	}
+	for i, kline := range klineData {
+		rs.StartTime[i] = kline.StartTime
+	}
+	return rs
+}
+
+// Len 返回时间轴长度
+func (r *ResultSet) Len() int {
+	return len(r.StartTime)
+}
+
+// Add 添加或替换一个通道
+// 参数：
+//   - name: 通道名，如 "rsi.values"
+//   - values: 序列，长度必须与时间轴一致
+//
+// 返回值：
+//   - error: 名称为空或长度不一致时返回错误
+func (r *ResultSet) Add(name string, values []float64) error {
+	if name == "" {
+		return fmt.Errorf("通道名不能为空")
+	}
+	if len(values) != len(r.StartTime) {
+		return fmt.Errorf("通道 %s 的长度 %d 与时间轴长度 %d 不一致", name, len(values), len(r.StartTime))
+	}
+	if _, ok := r.Channels[name]; !ok {
+		r.Names = append(r.Names, name)
+	}
+	r.Channels[name] = values
+	return nil
+}
+
+// AddResult 将指标结果结构体的序列字段添加为 "indicator.输出" 通道
+// 参数：
+//   - indicator: 指标名，作为通道名前缀
+//   - result: 指标结果，如 *TaMacd、*TaBoll，可为结构体或其指针
+//
+// 返回值：
+//   - error: result 不是结构体或没有与时间轴等长的序列字段时返回错误
+//
+// 说明/注意事项：
+//
+//	输出名取字段的 json 标签，没有标签时使用小写字段名。
+//	[]float64、[]int、[]bool（1/0）类型且与时间轴等长的字段会被添加；
+//	[][]float64 字段按下标展开为 "indicator.输出.0"、"indicator.输出.1"；周期等标量字段被忽略。
+func (r *ResultSet) AddResult(indicator string, result interface{}) error {
+	v := reflect.ValueOf(result)
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return fmt.Errorf("指标 %s 的结果为空", indicator)
+		}
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return fmt.Errorf("指标 %s 的结果不是结构体", indicator)
+	}
+
+	added := 0
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		output := strings.Split(field.Tag.Get("json"), ",")[0]
+		if output == "-" {
+			continue
+		}
+		if output == "" {
+			output = strings.ToLower(field.Name)
+		}
+		name := indicator + "." + output
+
+		fv := v.Field(i)
+		if fv.Kind() != reflect.Slice {
+			continue
+		}
+		if elem := fv.Type().Elem(); elem.Kind() == reflect.Slice && elem.Elem().Kind() == reflect.Float64 {
+			for j := 0; j < fv.Len(); j++ {
+				if values := fv.Index(j).Interface().([]float64); len(values) == len(r.StartTime) {
+					r.Add(name+"."+strconv.Itoa(j), values)
+					added++
+				}
+			}
+			continue
+		}
+		if values, ok := sliceToFloats(fv); ok && len(values) == len(r.StartTime) {
+			r.Add(name, values)
+			added++
+		}
+	}
+	if added == 0 {
+		return fmt.Errorf("指标 %s 的结果没有与时间轴等长的序列", indicator)
+	}
+	return nil
+}
+
+// sliceToFloats 将 []float64、[]int、[]bool 转换为 []float64，其他类型返回 false
+func sliceToFloats(v reflect.Value) ([]float64, bool) {
+	switch values := v.Interface().(type) {
+	case []float64:
+		return values, true
+	case []int:
+		out := make([]float64, len(values))
+		for i, n := range values {
+			out[i] = float64(n)
+		}
+		return out, true
+	case []bool:
+		out := make([]float64, len(values))
+		for i, b := range values {
+			out[i] = boolValue(b)
+		}
+		return out, true
+	}
+	return nil, false
+}
+
+// Series 返回指定通道的序列，不存在时返回 nil
+func (r *ResultSet) Series(name string) []float64 {
+	return r.Channels[name]
+}
+
+// Value 返回指定通道的最新值，通道不存在或为空时返回 0
+func (r *ResultSet) Value(name string) float64 {
+	values := r.Channels[name]
+	if len(values) == 0 {
+		return 0
+	}
+	return values[len(values)-1]
+}
+
+// Select 按名称或通配符模式选择通道，返回共享底层序列的新结果集
+// 参数：
+//   - patterns: 通道名或 path.Match 模式，如 "macd.*"、"*.upper"；为空时选择全部通道
+//
+// 返回值：
+//   - *ResultSet: 按模式顺序（同一模式内按添加顺序）排列的通道，不含重复
+func (r *ResultSet) Select(patterns ...string) *ResultSet {
+	out := &ResultSet{StartTime: r.StartTime, Channels: make(map[string][]float64)}
+	if len(patterns) == 0 {
+		patterns = []string{"*"}
+	}
+	for _, pattern := range patterns {
+		for _, name := range r.Names {
+			if _, ok := out.Channels[name]; ok {
+				continue
+			}
+			if matched, _ := path.Match(pattern, name); matched || pattern == name {
+				out.Names = append(out.Names, name)
+				out.Channels[name] = r.Channels[name]
+			}
+		}
+	}
+	return out
+}
+
+// Join 按开始时间将另一个结果集的通道合并到当前时间轴（左连接）
+// 参数：
+//   - other: 另一个结果集，如另一交易对或另一周期的指标结果
+//   - prefix: 合并通道名的前缀，非空时通道名为 "prefix.原名"
+//   - fillForward: 为 true 时缺失的时间使用 other 中不晚于该时间的最近一个值，适合合并大周期结果；
+//     为 false 时填充 NaN
+//
+// 返回值：
+//   - *ResultSet: 新结果集，包含当前全部通道和合并的通道
+//   - error: 通道名冲突时返回错误
+//
+// 说明/注意事项：
+//
+//	两个时间轴都必须按开始时间升序排列。合并大周期结果时，大周期 K 线的值在其开始时间即可见，
+//	若该 K 线在小周期时间点尚未收盘，会引入未来数据，此时应先将大周期结果整体后移一根（见 Shift）。
+func (r *ResultSet) Join(other *ResultSet, prefix string, fillForward bool) (*ResultSet, error) {
+	out := &ResultSet{
+		StartTime: r.StartTime,
+		Names:     append([]string(nil), r.Names...),
+		Channels:  make(map[string][]float64, len(r.Channels)+len(other.Channels)),
+	}
+	for name, values := range r.Channels {
+		out.Channels[name] = values
+	}
+
+	// positions[i] 为 other 中开始时间不晚于 r.StartTime[i] 的最后一根的下标，-1 表示不存在
+	positions := make([]int, len(r.StartTime))
+	j := -1
+	for i, t := range r.StartTime {
+		for j+1 < len(other.StartTime) && other.StartTime[j+1] <= t {
+			j++
+		}
+		positions[i] = j
+	}
+
+	for _, name := range other.Names {
+		joined := name
+		if prefix != "" {
+			joined = prefix + "." + name
+		}
+		if _, ok := out.Channels[joined]; ok {
+			return nil, fmt.Errorf("通道名冲突: %s", joined)
+		}
+		source := other.Channels[name]
+		values := make([]float64, len(r.StartTime))
+		for i, p := range positions {
+			if p < 0 || (!fillForward && other.StartTime[p] != r.StartTime[i]) {
+				values[i] = math.NaN()
+				continue
+			}
+			values[i] = source[p]
+		}
+		out.Names = append(out.Names, joined)
+		out.Channels[joined] = values
+	}
+	return out, nil
+}
+
+// Tail 返回最近 n 根的结果集，n 超过长度时返回全部，序列与原结果集共享底层数组
+func (r *ResultSet) Tail(n int) *ResultSet {
+	start := len(r.StartTime) - n
+	if start < 0 {
+		start = 0
+	}
+	out := &ResultSet{
+		StartTime: r.StartTime[start:],
+		Names:     r.Names,
+		Channels:  make(map[string][]float64, len(r.Channels)),
+	}
+	for name, values := range r.Channels {
+		out.Channels[name] = values[start:]
+	}
+	return out
+}
+
+// Rows 返回按行排列的矩阵，rows[i][j] 为第 i 根 K 线第 j 个通道的值，通道顺序与 Names 一致
+func (r *ResultSet) Rows() [][]float64 {
+	rows := make([][]float64, len(r.StartTime))
+	for i := range rows {
+		rows[i] = make([]float64, len(r.Names))
+		for j, name := range r.Names {
+			rows[i][j] = r.Channels[name][i]
+		}
+	}
+	return rows
+}
+
+// WriteCSV 以 CSV 格式写出全部通道，首列为 start_time，NaN 写为空字符串
+func (r *ResultSet) WriteCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(append([]string{"start_time"}, r.Names...)); err != nil {
+		return err
+	}
+	row := make([]string, len(r.Names)+1)
+	for i, t := range r.StartTime {
+		row[0] = strconv.FormatInt(t, 10)
+		for j, name := range r.Names {
+			if v := r.Channels[name][i]; math.IsNaN(v) {
+				row[j+1] = ""
+			} else {
+				row[j+1] = strconv.FormatFloat(v, 'f', -1, 64)
+			}
+		}
+		if err := cw.Write(row); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// WriteJSON 以 JSON 格式写出全部通道，NaN 与 ±Inf 写为 null
+func (r *ResultSet) WriteJSON(w io.Writer) error {
+	channels := make(map[string][]*float64, len(r.Names))
+	for _, name := range r.Names {
+		values := make([]*float64, len(r.StartTime))
+		for i, v := range r.Channels[name] {
+			if !math.IsNaN(v) && !math.IsInf(v, 0) {
+				value := v
+				values[i] = &value
+			}
+		}
+		channels[name] = values
+	}
+	return json.NewEncoder(w).Encode(struct {
+		StartTime []int64               `json:"start_time"`
+		Names     []string              `json:"names"`
+		Channels  map[string][]*float64 `json:"channels"`
+	}{r.StartTime, r.Names, channels})
+}
+
+// ResultSet 将流水线结果转换为结果集，通道名与列名相同
+func (r *PipelineResult) ResultSet() *ResultSet {
+	rs := &ResultSet{StartTime: r.StartTime, Channels: make(map[string][]float64, len(r.Columns))}
+	for _, name := range r.Names {
+		rs.Add(name, r.Columns[name])
+	}
+	for _, source := range []string{"open", "high", "low", "close", "volume"} {
+		if values, ok := r.Columns[source]; ok {
+			rs.Add(source, values)
+		}
+	}
+	return rs
+}
+
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
@@ -0,0 +1,158 @@
+package ta
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/phrynus/ta/returns"
+)
+
+// SimpleReturns 计算简单收益率序列，同 returns.Simple
+func SimpleReturns(prices []float64) []float64 {
+	return returns.Simple(prices)
+}
+
+// LogReturns 计算对数收益率序列，同 returns.Log
+func LogReturns(prices []float64) []float64 {
+	return returns.Log(prices)
+}
+
+// CumulativeReturns 将收益率序列复利累计为累计收益曲线，同 returns.Cumulative
+func CumulativeReturns(r []float64, isLog bool) []float64 {
+	return returns.Cumulative(r, isLog)
+}
+
+// Rebase 将价格序列归一化为以 base 为起点的序列，同 returns.Rebase
+func Rebase(prices []float64, base float64) ([]float64, error) {
+	return returns.Rebase(prices, base)
+}
+
+// AggregateReturns 将高频收益率按周期复利合成为低频收益率
+// 参数：
+//   - times: 每个收益对应的时间戳（毫秒），升序
+//   - r: 收益率序列
+//   - interval: 目标周期的毫秒数，可由 ParseInterval 得到，分桶方式与 Resample 一致（1 周按自然周对齐）
+//   - isLog: 输入是否为对数收益（对数收益直接求和，简单收益连乘）
+//
+// 返回值：
+//   - []int64: 每个目标周期的起始时间
+//   - []float64: 每个目标周期的合成收益，类型与输入一致
+//   - error: 参数无效或长度不一致时返回错误
+func AggregateReturns(times []int64, r []float64, interval int64, isLog bool) ([]int64, []float64, error) {
+	bucket, err := intervalBucket(interval)
+	if err != nil {
+		return nil, nil, err
+	}
+	return returns.Aggregate(times, r, bucket, isLog)
+}
+
+// Returns 从 KlineDatas 的收盘价计算收益率序列
+// 参数：
+//   - isLog: true 返回对数收益，false 返回简单收益
+func (k *KlineDatas) Returns(isLog bool) ([]float64, error) {
+	closes, err := k.ExtractSlice("close")
+	if err != nil {
+		return nil, err
+	}
+	if isLog {
+		return LogReturns(closes), nil
+	}
+	return SimpleReturns(closes), nil
+}
+
+// Rebased 将 KlineDatas 的收盘价归一化为以 base 为起点的序列
+func (k *KlineDatas) Rebased(base float64) ([]float64, error) {
+	closes, err := k.ExtractSlice("close")
+	if err != nil {
+		return nil, err
+	}
+	return Rebase(closes, base)
+}
+
+// relativeCandle 将一根 K 线的 OHLC 转换为相对参考价的涨跌幅（百分比）或对数收益
+func relativeCandle(kline *KlineData, ref float64, isLog bool) *KlineData {
+	convert := func(price float64) float64 {
+		if isLog {
+			if price <= 0 {
+				return 0
+			}
+			return math.Log(price / ref)
+		}
+		return (price/ref - 1) * 100
+	}
+	return &KlineData{
+		StartTime: kline.StartTime,
+		Open:      convert(kline.Open),
+		High:      convert(kline.High),
+		Low:       convert(kline.Low),
+		Close:     convert(kline.Close),
+		Volume:    kline.Volume,
+	}
+}
+
+// ReturnCandles 将 K 线转换为相对前一根收盘价的收益率 K 线
+// 参数：
+//   - isLog: true 时 OHLC 为 ln(价格/前收盘)，false 时为相对前收盘的涨跌幅百分比
+//
+// 返回值：
+//   - KlineDatas: 比输入少第一根的新 K 线，成交量保持不变
+//   - error: 数据不足或前收盘价非正时返回错误
+//
+// 说明/注意事项：
+//
+//	转换后的 K 线与价格水平无关，不同价格量级的品种可以直接共用形态识别规则或机器学习特征。
+//
+// 示例：
+//
+//	candles, err := klineData.ReturnCandles(false)
+//	stats, err := candles.PriceAction()
+func (k *KlineDatas) ReturnCandles(isLog bool) (KlineDatas, error) {
+	if len(*k) < 2 {
+		return nil, fmt.Errorf("计算数据不足")
+	}
+	out := make(KlineDatas, 0, len(*k)-1)
+	for i := 1; i < len(*k); i++ {
+		ref := (*k)[i-1].Close
+		if ref <= 0 {
+			return nil, fmt.Errorf("第%d根K线收盘价非正，无法计算收益率", i)
+		}
+		out = append(out, relativeCandle((*k)[i], ref, isLog))
+	}
+	return out, nil
+}
+
+// RelativeCandles 将 K 线转换为相对基准时间收盘价的收益率 K 线
+// 参数：
+//   - baseTime: 基准时间，使用开始时间不早于该时间的第一根 K 线的收盘价作为基准
+//   - isLog: true 时 OHLC 为 ln(价格/基准价)，false 时为相对基准价的涨跌幅百分比
+//
+// 返回值：
+//   - KlineDatas: 与输入等长的新 K 线，基准之前的 K 线为负的相对值
+//   - error: 找不到基准 K 线或基准价非正时返回错误
+func (k *KlineDatas) RelativeCandles(baseTime int64, isLog bool) (KlineDatas, error) {
+	base := -1
+	for i, kline := range *k {
+		if kline.StartTime >= baseTime {
+			base = i
+			break
+		}
+	}
+	if base < 0 {
+		return nil, fmt.Errorf("没有开始时间不早于 %d 的K线", baseTime)
+	}
+	ref := (*k)[base].Close
+	if ref <= 0 {
+		return nil, fmt.Errorf("基准K线收盘价非正")
+	}
+	out := make(KlineDatas, len(*k))
+	for i, kline := range *k {
+		out[i] = relativeCandle(kline, ref, isLog)
+	}
+	return out, nil
+}
+
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
@@ -0,0 +1,145 @@
+// Package returns 提供收益率序列工具：简单/对数收益、复利累计、归一化与周期合成
+//
+// 只依赖价格与时间序列，不依赖 ta 包，ta 包中的收益率函数与 KlineDatas 方法
+// （Returns、Rebased、ReturnCandles 等）均基于本包实现。
+// K 线方法只能定义在 KlineDatas 所在的 ta 包中，因此仍保留在 ta 包。
+package returns
+
+import (
+	"fmt"
+	"math"
+)
+
+// Simple 计算简单收益率序列
+// 参数：
+//   - prices: 价格序列
+//
+// 返回值：
+//   - []float64: 与输入等长，r[i] = p[i]/p[i-1] − 1，r[0] 为 0；前值为 0 时该位置为 0
+func Simple(prices []float64) []float64 {
+	out := make([]float64, len(prices))
+	for i := 1; i < len(prices); i++ {
+		if prices[i-1] != 0 {
+			out[i] = prices[i]/prices[i-1] - 1
+		}
+	}
+	return out
+}
+
+// Log 计算对数收益率序列
+// 参数：
+//   - prices: 价格序列
+//
+// 返回值：
+//   - []float64: 与输入等长，r[i] = ln(p[i]/p[i-1])，r[0] 为 0；价格非正时该位置为 0
+func Log(prices []float64) []float64 {
+	out := make([]float64, len(prices))
+	for i := 1; i < len(prices); i++ {
+		if prices[i-1] > 0 && prices[i] > 0 {
+			out[i] = math.Log(prices[i] / prices[i-1])
+		}
+	}
+	return out
+}
+
+// Cumulative 将收益率序列复利累计为累计收益曲线
+// 参数：
+//   - returns: 收益率序列
+//   - isLog: 输入是否为对数收益
+//
+// 返回值：
+//   - []float64: 累计简单收益，c[i] = Π(1+r) − 1（对数收益为 exp(Σr) − 1）
+func Cumulative(returns []float64, isLog bool) []float64 {
+	cumulative := make([]float64, len(returns))
+	if isLog {
+		var sum float64
+		for i, r := range returns {
+			sum += r
+			cumulative[i] = math.Exp(sum) - 1
+		}
+		return cumulative
+	}
+	growth := 1.0
+	for i, r := range returns {
+		growth *= 1 + r
+		cumulative[i] = growth - 1
+	}
+	return cumulative
+}
+
+// Rebase 将价格序列归一化为以 base 为起点的序列
+// 参数：
+//   - prices: 价格序列
+//   - base: 起点数值，通常为 100
+//
+// 返回值：
+//   - []float64: out[i] = prices[i] / prices[0] × base
+//   - error: 序列为空或首个价格为 0 时返回错误
+//
+// 示例：
+//
+//	btc, _ := returns.Rebase(btcCloses, 100)
+//	eth, _ := returns.Rebase(ethCloses, 100) // 两者可直接在同一坐标系中比较
+func Rebase(prices []float64, base float64) ([]float64, error) {
+	if len(prices) == 0 {
+		return nil, fmt.Errorf("计算数据不足")
+	}
+	if prices[0] == 0 {
+		return nil, fmt.Errorf("首个价格为0，无法归一化")
+	}
+	out := make([]float64, len(prices))
+	scale := base / prices[0]
+	for i, p := range prices {
+		out[i] = p * scale
+	}
+	return out, nil
+}
+
+// Aggregate 将高频收益率按周期复利合成为低频收益率
+// 参数：
+//   - times: 每个收益对应的时间戳（毫秒），升序
+//   - returns: 收益率序列
+//   - bucket: 返回时间戳所属周期起始时间的函数，如按固定毫秒数取整或按自然周对齐
+//   - isLog: 输入是否为对数收益（对数收益直接求和，简单收益连乘）
+//
+// 返回值：
+//   - []int64: 每个目标周期的起始时间
+//   - []float64: 每个目标周期的合成收益，类型与输入一致
+//   - error: 参数无效或长度不一致时返回错误
+//
+// 示例：
+//
+//	hour := int64(60 * 60 * 1000)
+//	times, hourly, err := returns.Aggregate(times, minutely, func(t int64) int64 { return t - t%hour }, false)
+func Aggregate(times []int64, returns []float64, bucket func(t int64) int64, isLog bool) ([]int64, []float64, error) {
+	if len(times) != len(returns) {
+		return nil, nil, fmt.Errorf("输入数据长度不一致")
+	}
+	if bucket == nil {
+		return nil, nil, fmt.Errorf("分桶函数不能为空")
+	}
+
+	var outTimes []int64
+	var outReturns []float64
+	for i, t := range times {
+		start := bucket(t)
+		if len(outTimes) == 0 || outTimes[len(outTimes)-1] != start {
+			outTimes = append(outTimes, start)
+			outReturns = append(outReturns, returns[i])
+			continue
+		}
+		last := len(outReturns) - 1
+		if isLog {
+			outReturns[last] += returns[i]
+		} else {
+			outReturns[last] = (1+outReturns[last])*(1+returns[i]) - 1
+		}
+	}
+	return outTimes, outReturns, nil
+}
+
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
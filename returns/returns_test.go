@@ -0,0 +1,96 @@
+package returns
+
+import (
+	"math"
+	"testing"
+)
+
+func almostEqual(a, b []float64) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if math.Abs(a[i]-b[i]) > 1e-12 {
+			return false
+		}
+	}
+	return true
+}
+
+func TestSimpleAndLog(t *testing.T) {
+	prices := []float64{100, 110, 99, 0, 50}
+	if got, want := Simple(prices), []float64{0, 0.1, -0.1, -1, 0}; !almostEqual(got, want) {
+		t.Errorf("Simple = %v, want %v", got, want)
+	}
+	if got, want := Log(prices), []float64{0, math.Log(1.1), math.Log(0.9), 0, 0}; !almostEqual(got, want) {
+		t.Errorf("Log = %v, want %v", got, want)
+	}
+}
+
+func TestCumulative(t *testing.T) {
+	tests := []struct {
+		name    string
+		returns []float64
+		isLog   bool
+		want    []float64
+	}{
+		{"简单收益", []float64{0.1, -0.1, 0.2}, false, []float64{0.1, -0.01, 0.188}},
+		{"对数收益", []float64{math.Log(1.1), math.Log(0.9)}, true, []float64{0.1, -0.01}},
+		{"空序列", nil, false, []float64{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Cumulative(tt.returns, tt.isLog); !almostEqual(got, tt.want) {
+				t.Errorf("Cumulative = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRebase(t *testing.T) {
+	got, err := Rebase([]float64{50, 55, 45}, 100)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []float64{100, 110, 90}; !almostEqual(got, want) {
+		t.Errorf("Rebase = %v, want %v", got, want)
+	}
+	if _, err := Rebase([]float64{0, 1}, 100); err == nil {
+		t.Error("首个价格为 0 时应返回错误")
+	}
+	if _, err := Rebase(nil, 100); err == nil {
+		t.Error("空序列应返回错误")
+	}
+}
+
+func TestAggregate(t *testing.T) {
+	bucket := func(t int64) int64 { return t - t%10 }
+	times := []int64{0, 5, 10, 15, 25}
+	r := []float64{0.1, 0.1, -0.5, 0.2, 0.3}
+
+	gotTimes, gotReturns, err := Aggregate(times, r, bucket, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantTimes := []int64{0, 10, 20}
+	for i := range wantTimes {
+		if gotTimes[i] != wantTimes[i] {
+			t.Fatalf("times = %v, want %v", gotTimes, wantTimes)
+		}
+	}
+	if want := []float64{0.21, -0.4, 0.3}; !almostEqual(gotReturns, want) {
+		t.Errorf("简单收益合成 = %v, want %v", gotReturns, want)
+	}
+
+	_, logReturns, err := Aggregate(times, r, bucket, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []float64{0.2, -0.3, 0.3}; !almostEqual(logReturns, want) {
+		t.Errorf("对数收益合成 = %v, want %v", logReturns, want)
+	}
+
+	if _, _, err := Aggregate(times, r[:2], bucket, false); err == nil {
+		t.Error("长度不一致时应返回错误")
+	}
+}
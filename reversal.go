@@ -0,0 +1,140 @@
+package ta
+
+import (
+	"fmt"
+)
+
+// ReversalTrade 止损反手产生的一笔交易
+// 字段：
+//   - Side: 方向，1 做多，-1 做空
+//   - EntryIndex: 开仓 K 线下标
+//   - EntryTime: 开仓 K 线开始时间
+//   - EntryPrice: 开仓价格
+//   - ExitIndex: 平仓 K 线下标，仍持仓时为 -1
+//   - ExitTime: 平仓 K 线开始时间，仍持仓时为 0
+//   - ExitPrice: 平仓价格，仍持仓时为 0
+//   - Return: 按方向计算的简单收益率，仍持仓时为 0
+type ReversalTrade struct {
+	Side       int     `json:"side"`
+	EntryIndex int     `json:"entry_index"`
+	EntryTime  int64   `json:"entry_time"`
+	EntryPrice float64 `json:"entry_price"`
+	ExitIndex  int     `json:"exit_index"`
+	ExitTime   int64   `json:"exit_time"`
+	ExitPrice  float64 `json:"exit_price"`
+	Return     float64 `json:"return"`
+}
+
+// TaReversal 趋势方向序列转换得到的止损反手交易
+// 字段：
+//   - Trades: 按开仓顺序排列的交易，最后一笔可能仍在持仓
+//   - Position: 每根 K 线收盘时确定的持仓方向，1 多、-1 空、0 空仓；NextOpen 时在下一根开盘才实际成交
+//   - NextOpen: 是否在信号出现后的下一根 K 线开盘价成交
+type TaReversal struct {
+	Trades   []ReversalTrade `json:"trades"`
+	Position []int           `json:"position"`
+	NextOpen bool            `json:"next_open"`
+}
+
+// StopAndReverse 将趋势方向序列转换为止损反手的开平仓记录
+// 参数：
+//   - direction: 与 K 线等长的趋势方向，正数看多、负数看空、0 表示无方向（空仓）
+//   - nextOpen: 为 true 时在方向变化后的下一根 K 线开盘价成交，否则在当根收盘价成交
+//
+// 返回值：
+//   - *TaReversal: 交易记录与持仓序列
+//   - error: 长度不一致时返回错误
+//
+// 说明/注意事项：
+//
+//	方向由多变空（或由空变多）时平掉原仓位并立即反向开仓；方向变为 0 时只平仓。
+//	方向序列通常在当根收盘后才确定，回测时使用 nextOpen 可避免以无法成交的价格入场。
+//	可直接使用 TaSuperTrend.Direction()、TaSuperTrendPivot.Trend、TaSuperTrendPivotHl2.Direction、TaStructure.Trend 等序列。
+//
+// 示例：
+//
+//	st, _ := klineData.SuperTrend(10, 3)
+//	sar, err := klineData.StopAndReverse(st.Direction(), true)
+//	for _, trade := range sar.Trades {
+//	    // 交给回测或统计
+//	}
+func (k *KlineDatas) StopAndReverse(direction []int, nextOpen bool) (*TaReversal, error) {
+	klines := *k
+	if len(direction) != len(klines) {
+		return nil, fmt.Errorf("输入数据长度不一致")
+	}
+
+	result := &TaReversal{Position: make([]int, len(klines)), NextOpen: nextOpen}
+	side, open := 0, -1
+	for i, d := range direction {
+		target := sign(d)
+		if target != side {
+			fill := i
+			if nextOpen {
+				fill = i + 1
+			}
+			if fill >= len(klines) {
+				// 最后一根 K 线上的信号没有下一根可以成交，只记录目标方向
+				result.Position[i] = target
+				continue
+			}
+			price := klines[fill].Close
+			if nextOpen {
+				price = klines[fill].Open
+			}
+			if side != 0 {
+				trade := &result.Trades[open]
+				trade.ExitIndex, trade.ExitTime, trade.ExitPrice = fill, klines[fill].StartTime, price
+				if trade.EntryPrice != 0 {
+					trade.Return = float64(side) * (price - trade.EntryPrice) / trade.EntryPrice
+				}
+			}
+			if target != 0 {
+				result.Trades = append(result.Trades, ReversalTrade{
+					Side:       target,
+					EntryIndex: fill,
+					EntryTime:  klines[fill].StartTime,
+					EntryPrice: price,
+					ExitIndex:  -1,
+				})
+				open = len(result.Trades) - 1
+			}
+			side = target
+		}
+		result.Position[i] = side
+	}
+	return result, nil
+}
+
+// Value 返回最新的持仓方向
+func (t *TaReversal) Value() int {
+	return t.Position[len(t.Position)-1]
+}
+
+// Direction 返回 SuperTrend 的趋势方向序列，上升为 1、下降为 -1，预热期为 0
+func (t *TaSuperTrend) Direction() []int {
+	direction := make([]int, len(t.Trend))
+	for i := t.Period; i < len(t.Trend); i++ {
+		direction[i] = -1
+		if t.Trend[i] {
+			direction[i] = 1
+		}
+	}
+	return direction
+}
+
+func sign(n int) int {
+	switch {
+	case n > 0:
+		return 1
+	case n < 0:
+		return -1
+	}
+	return 0
+}
+
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
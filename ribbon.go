@@ -0,0 +1,274 @@
+package ta
+
+import (
+	"fmt"
+	"math"
+)
+
+// GMMA 顾比复合移动平均线的短期组与长期组周期
+var (
+	GMMAShortPeriods = []int{3, 5, 8, 10, 12, 15}
+	GMMALongPeriods  = []int{30, 35, 40, 45, 50, 60}
+)
+
+// TaRibbon 多周期指标带（均线带）
+// 字段：
+//   - Values: 各周期的指标序列，Values[p][i] 与 Periods[p] 对应
+//   - Periods: 周期列表，按传入顺序排列
+type TaRibbon struct {
+	Values  [][]float64 `json:"values"`
+	Periods []int       `json:"periods"`
+}
+
+// checkRibbonPeriods 检查周期列表，每个周期需要至少 period+extra 个数据，与对应的单周期函数一致
+func checkRibbonPeriods(length int, periods []int, extra int) error {
+	if len(periods) == 0 {
+		return fmt.Errorf("周期列表不能为空")
+	}
+	for _, p := range periods {
+		if p <= 0 {
+			return fmt.Errorf("周期必须大于0")
+		}
+		if length < p+extra {
+			return fmt.Errorf("计算数据不足")
+		}
+	}
+	return nil
+}
+
+// CalculateEMAs 一次遍历计算多个周期的 EMA
+// 参数：
+//   - prices: 价格序列
+//   - periods: 周期列表，建议按从短到长排列，便于判断排列方向
+//
+// 返回值：
+//   - *TaRibbon: 各周期的 EMA，结果与逐个调用 CalculateEMA 一致
+//   - error: 周期无效或数据不足时返回错误
+//
+// 示例：
+//
+//	ribbon, err := CalculateEMAs(closes, GMMAShortPeriods)
+func CalculateEMAs(prices []float64, periods []int) (*TaRibbon, error) {
+	length := len(prices)
+	if err := checkRibbonPeriods(length, periods, 0); err != nil {
+		return nil, err
+	}
+
+	values := preallocateSlices(length, len(periods))
+	sums := make([]float64, len(periods))
+	multipliers := make([]float64, len(periods))
+	for p, period := range periods {
+		multipliers[p] = 2.0 / float64(period+1)
+	}
+	for i := 0; i < length; i++ {
+		for p, period := range periods {
+			switch {
+			case i < period-1:
+				sums[p] += prices[i]
+			case i == period-1:
+				values[p][i] = (sums[p] + prices[i]) / float64(period)
+			default:
+				values[p][i] = prices[i]*multipliers[p] + values[p][i-1]*(1-multipliers[p])
+			}
+		}
+	}
+	return &TaRibbon{Values: values, Periods: periods}, nil
+}
+
+// CalculateSMAs 一次遍历计算多个周期的 SMA
+// 参数：
+//   - prices: 价格序列
+//   - periods: 周期列表
+//
+// 返回值：
+//   - *TaRibbon: 各周期的 SMA，周期之前的位置为 0
+//   - error: 周期无效或数据不足时返回错误
+func CalculateSMAs(prices []float64, periods []int) (*TaRibbon, error) {
+	length := len(prices)
+	if err := checkRibbonPeriods(length, periods, 0); err != nil {
+		return nil, err
+	}
+
+	values := preallocateSlices(length, len(periods))
+	sums := make([]float64, len(periods))
+	for i := 0; i < length; i++ {
+		for p, period := range periods {
+			sums[p] += prices[i]
+			if i >= period {
+				sums[p] -= prices[i-period]
+			}
+			if i >= period-1 {
+				values[p][i] = sums[p] / float64(period)
+			}
+		}
+	}
+	return &TaRibbon{Values: values, Periods: periods}, nil
+}
+
+// CalculateRSIs 一次遍历计算多个周期的 RSI
+// 参数：
+//   - prices: 价格序列
+//   - periods: 周期列表
+//
+// 返回值：
+//   - *TaRibbon: 各周期的 RSI，结果与逐个调用 CalculateRSI 一致
+//   - error: 周期无效或数据不足时返回错误
+func CalculateRSIs(prices []float64, periods []int) (*TaRibbon, error) {
+	length := len(prices)
+	if err := checkRibbonPeriods(length, periods, 1); err != nil {
+		return nil, err
+	}
+
+	values := preallocateSlices(length, len(periods))
+	avgGains := make([]float64, len(periods))
+	avgLosses := make([]float64, len(periods))
+	for i := 1; i < length; i++ {
+		change := prices[i] - prices[i-1]
+		gain, loss := math.Max(0, change), math.Max(0, -change)
+		for p, period := range periods {
+			n := float64(period)
+			switch {
+			case i < period:
+				avgGains[p] += gain
+				avgLosses[p] += loss
+				continue
+			case i == period:
+				avgGains[p] = (avgGains[p] + gain) / n
+				avgLosses[p] = (avgLosses[p] + loss) / n
+			default:
+				avgGains[p] = (avgGains[p]*(n-1) + gain) / n
+				avgLosses[p] = (avgLosses[p]*(n-1) + loss) / n
+			}
+			if avgLosses[p] == 0 {
+				values[p][i] = 100
+			} else {
+				values[p][i] = 100 - 100/(1+avgGains[p]/avgLosses[p])
+			}
+		}
+	}
+	return &TaRibbon{Values: values, Periods: periods}, nil
+}
+
+// EMAs 从 KlineDatas 中提取数据并计算多周期 EMA
+func (k *KlineDatas) EMAs(periods []int, source string) (*TaRibbon, error) {
+	prices, err := k.ExtractSlice(source)
+	if err != nil {
+		return nil, err
+	}
+	return CalculateEMAs(prices, periods)
+}
+
+// SMAs 从 KlineDatas 中提取数据并计算多周期 SMA
+func (k *KlineDatas) SMAs(periods []int, source string) (*TaRibbon, error) {
+	prices, err := k.ExtractSlice(source)
+	if err != nil {
+		return nil, err
+	}
+	return CalculateSMAs(prices, periods)
+}
+
+// RSIs 从 KlineDatas 中提取数据并计算多周期 RSI
+func (k *KlineDatas) RSIs(periods []int, source string) (*TaRibbon, error) {
+	prices, err := k.ExtractSlice(source)
+	if err != nil {
+		return nil, err
+	}
+	return CalculateRSIs(prices, periods)
+}
+
+// AlignmentAt 判断指定位置指标带的排列方向
+// 参数：
+//   - index: K 线下标
+//
+// 返回值：
+//   - int: 1 表示按传入顺序严格递减（短周期在上，多头排列），-1 表示严格递增（空头排列），0 表示交织
+func (t *TaRibbon) AlignmentAt(index int) int {
+	up, down := true, true
+	for p := 1; p < len(t.Values); p++ {
+		prev, cur := t.Values[p-1][index], t.Values[p][index]
+		if prev <= cur {
+			up = false
+		}
+		if prev >= cur {
+			down = false
+		}
+	}
+	switch {
+	case len(t.Values) < 2:
+		return 0
+	case up:
+		return 1
+	case down:
+		return -1
+	}
+	return 0
+}
+
+// WidthAt 返回指定位置指标带的宽度，即最大值与最小值之差相对均值的百分比
+func (t *TaRibbon) WidthAt(index int) float64 {
+	low, high := math.Inf(1), math.Inf(-1)
+	var sum float64
+	for _, v := range t.Values {
+		low = math.Min(low, v[index])
+		high = math.Max(high, v[index])
+		sum += v[index]
+	}
+	mean := sum / float64(len(t.Values))
+	if mean == 0 {
+		return 0
+	}
+	return (high - low) / math.Abs(mean) * 100
+}
+
+// Widths 返回指标带宽度序列
+func (t *TaRibbon) Widths() []float64 {
+	widths := make([]float64, len(t.Values[0]))
+	for i := range widths {
+		widths[i] = t.WidthAt(i)
+	}
+	return widths
+}
+
+// IsCompressed 判断最新的指标带是否处于压缩（收敛）状态
+// 参数：
+//   - lookback: 比较的历史窗口
+//   - ratio: 压缩阈值，当前宽度不大于窗口内平均宽度的 ratio 倍时视为压缩，如 0.5
+//
+// 返回值：
+//   - bool: 是否压缩，数据不足时返回 false
+//
+// 说明/注意事项：
+//
+//	均线带压缩常出现在趋势启动之前，可与 Alignment 结合判断突破方向。
+func (t *TaRibbon) IsCompressed(lookback int, ratio float64) bool {
+	last := len(t.Values[0]) - 1
+	if lookback <= 0 || last-lookback < 0 {
+		return false
+	}
+	var sum float64
+	for i := last - lookback; i < last; i++ {
+		sum += t.WidthAt(i)
+	}
+	return t.WidthAt(last) <= sum/float64(lookback)*ratio
+}
+
+// Alignment 返回最新的排列方向，含义同 AlignmentAt
+func (t *TaRibbon) Alignment() int {
+	return t.AlignmentAt(len(t.Values[0]) - 1)
+}
+
+// Value 返回各周期的最新值
+func (t *TaRibbon) Value() []float64 {
+	last := len(t.Values[0]) - 1
+	out := make([]float64, len(t.Values))
+	for p, v := range t.Values {
+		out[p] = v[last]
+	}
+	return out
+}
+
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
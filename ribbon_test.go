@@ -0,0 +1,40 @@
+package ta
+
+import (
+	"math"
+	"testing"
+)
+
+func TestCalculateRSIs(t *testing.T) {
+	prices := []float64{44, 44.3, 44.1, 43.6, 44.3, 44.8, 45.1, 45.4, 45.8, 46.1, 45.9, 46.2}
+	periods := []int{3, 5, 11}
+	ribbon, err := CalculateRSIs(prices, periods)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for p, period := range periods {
+		rsi, err := CalculateRSI(prices, period)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for i, want := range rsi.Values {
+			if math.Abs(ribbon.Values[p][i]-want) > 1e-9 {
+				t.Errorf("周期 %d 位置 %d = %v, want %v", period, i, ribbon.Values[p][i], want)
+			}
+		}
+	}
+}
+
+func TestCalculateRSIsInsufficientData(t *testing.T) {
+	// 与 CalculateRSI 一致，数据长度等于周期时返回错误
+	prices := []float64{1, 2, 3, 4, 5}
+	if _, err := CalculateRSI(prices, 5); err == nil {
+		t.Fatal("CalculateRSI 应返回错误")
+	}
+	if _, err := CalculateRSIs(prices, []int{2, 5}); err == nil {
+		t.Error("CalculateRSIs 应返回错误")
+	}
+	if _, err := CalculateRSIs(prices, []int{2, 4}); err != nil {
+		t.Errorf("CalculateRSIs 返回错误: %v", err)
+	}
+}
@@ -0,0 +1,317 @@
+package ta
+
+import (
+	"fmt"
+	"math"
+	"sync"
+	"time"
+)
+
+// 风控限制类型
+const (
+	RiskDailyLoss     = iota // 当日亏损超过上限
+	RiskOpenPositions        // 持仓数量超过上限
+	RiskLeverage             // 杠杆超过上限
+	RiskCooldown             // 连续亏损后的冷却期
+)
+
+// riskLimitNames 风控限制类型的名称，用于通知消息
+var riskLimitNames = map[int]string{
+	RiskDailyLoss:     "当日亏损",
+	RiskOpenPositions: "持仓数量",
+	RiskLeverage:      "杠杆",
+	RiskCooldown:      "连亏冷却",
+}
+
+// RiskLimits 声明式风控限制，值为 0 的限制不生效
+// 字段：
+//   - MaxDailyLoss: 当日最大亏损金额（正数），当日已实现盈亏 ≤ −MaxDailyLoss 后禁止开仓和加仓
+//   - MaxOpenPositions: 最大同时持仓的交易对数量
+//   - MaxLeverage: 最大杠杆，全部持仓名义价值之和 / 权益
+//   - MaxConsecutiveLosses: 连续亏损笔数达到该值后进入冷却期
+//   - Cooldown: 冷却期时长，从最后一笔亏损交易的平仓时间开始计算
+//   - Location: 划分交易日的时区，为空时使用 UTC
+type RiskLimits struct {
+	MaxDailyLoss         float64        `json:"max_daily_loss"`
+	MaxOpenPositions     int            `json:"max_open_positions"`
+	MaxLeverage          float64        `json:"max_leverage"`
+	MaxConsecutiveLosses int            `json:"max_consecutive_losses"`
+	Cooldown             time.Duration  `json:"cooldown"`
+	Location             *time.Location `json:"-"`
+}
+
+// RiskOrder 提交给券商接口前待检查的信号
+// 字段：
+//   - Symbol: 交易对
+//   - Time: 信号时间（毫秒）
+//   - Notional: 成交后该交易对的目标持仓名义价值（带方向，空头为负，0 为平仓）
+//   - Equity: 当前权益
+type RiskOrder struct {
+	Symbol   string  `json:"symbol"`
+	Time     int64   `json:"time"`
+	Notional float64 `json:"notional"`
+	Equity   float64 `json:"equity"`
+}
+
+// RiskBreach 风控拦截事件，实现 error 接口
+// 字段：
+//   - Symbol: 交易对
+//   - Time: 信号时间（毫秒）
+//   - Limit: 触发的限制类型，如 RiskDailyLoss
+//   - Value: 成交后将达到的值，冷却期时为剩余毫秒数
+//   - Threshold: 限制值
+type RiskBreach struct {
+	Symbol    string  `json:"symbol"`
+	Time      int64   `json:"time"`
+	Limit     int     `json:"limit"`
+	Value     float64 `json:"value"`
+	Threshold float64 `json:"threshold"`
+}
+
+// Error 返回拦截原因
+func (b *RiskBreach) Error() string {
+	if b.Limit == RiskCooldown {
+		return fmt.Sprintf("%s 风控拦截: 连亏冷却中，剩余 %v", b.Symbol, time.Duration(b.Value)*time.Millisecond)
+	}
+	return fmt.Sprintf("%s 风控拦截: %s %.6g 超过上限 %.6g", b.Symbol, riskLimitNames[b.Limit], b.Value, b.Threshold)
+}
+
+// RiskGuard 风控检查器，在每个信号调用券商接口之前检查风控限制
+// 说明：
+//
+//	平仓和减仓（目标持仓的绝对值不增加且方向不变）总是放行，只拦截开仓、加仓和反手。
+//	成交后需调用 SetPosition 更新持仓，平仓后调用 RecordTrade 记录盈亏，
+//	检查器据此维护当日盈亏、持仓数量和连续亏损笔数。方法可在多个协程中并发调用。
+//
+// 字段：
+//   - Limits: 风控限制
+//   - Notifiers: 拦截时发送通知的渠道，可为空
+//   - OnBreach: 拦截时的回调，可为空
+//   - OnError: 通知发送失败时的回调，可为空
+type RiskGuard struct {
+	Limits    RiskLimits
+	Notifiers []Notifier
+	OnBreach  func(breach *RiskBreach)
+	OnError   func(err error)
+
+	mu            sync.Mutex
+	day           string
+	dailyPnL      float64
+	losses        int
+	cooldownUntil int64
+	positions     map[string]float64
+}
+
+// NewRiskGuard 创建风控检查器
+// 参数：
+//   - limits: 风控限制
+//
+// 返回值：
+//   - *RiskGuard: 没有持仓和交易记录的检查器
+//   - error: 限制值为负或设置了连亏笔数但冷却期为 0 时返回错误
+//
+// 示例：
+//
+//	guard, err := NewRiskGuard(RiskLimits{
+//	    MaxDailyLoss:         500,
+//	    MaxOpenPositions:     3,
+//	    MaxLeverage:          2,
+//	    MaxConsecutiveLosses: 3,
+//	    Cooldown:             4 * time.Hour,
+//	})
+//	guard.Notifiers = []Notifier{&TelegramNotifier{Token: token, ChatID: chatID}}
+//	if err := guard.Check(RiskOrder{Symbol: "BTCUSDT", Time: now, Notional: 5000, Equity: equity}); err != nil {
+//	    return err // 被拦截，不调用券商接口
+//	}
+//	// 调用券商接口下单，成交后：
+//	guard.SetPosition("BTCUSDT", 5000)
+func NewRiskGuard(limits RiskLimits) (*RiskGuard, error) {
+	if limits.MaxDailyLoss < 0 || limits.MaxOpenPositions < 0 || limits.MaxLeverage < 0 ||
+		limits.MaxConsecutiveLosses < 0 || limits.Cooldown < 0 {
+		return nil, fmt.Errorf("风控限制不能为负数")
+	}
+	if limits.MaxConsecutiveLosses > 0 && limits.Cooldown == 0 {
+		return nil, fmt.Errorf("设置连续亏损笔数时冷却期必须大于0")
+	}
+	return &RiskGuard{Limits: limits, positions: make(map[string]float64)}, nil
+}
+
+// tradingDay 返回时间所在的交易日
+func (g *RiskGuard) tradingDay(t int64) string {
+	loc := g.Limits.Location
+	if loc == nil {
+		loc = time.UTC
+	}
+	return time.UnixMilli(t).In(loc).Format("2006-01-02")
+}
+
+// rollDay 进入新交易日时清零当日盈亏，调用方需持有锁
+func (g *RiskGuard) rollDay(t int64) {
+	if day := g.tradingDay(t); day != g.day {
+		g.day = day
+		g.dailyPnL = 0
+	}
+}
+
+// Check 检查信号是否满足风控限制
+// 参数：
+//   - order: 待检查的信号
+//
+// 返回值：
+//   - error: 通过时为 nil；被拦截时为 *RiskBreach，并触发 OnBreach 和通知；权益非正时返回普通错误
+//
+// 说明/注意事项：
+//
+//	按冷却期、当日亏损、持仓数量、杠杆的顺序检查，返回第一个被触发的限制。
+func (g *RiskGuard) Check(order RiskOrder) error {
+	g.mu.Lock()
+	breach, err := g.check(order)
+	g.mu.Unlock()
+	if err != nil || breach == nil {
+		return err
+	}
+	if g.OnBreach != nil {
+		g.OnBreach(breach)
+	}
+	if len(g.Notifiers) > 0 {
+		dispatchNotification(g.Notifiers, Notification{
+			Symbol: breach.Symbol,
+			Event:  breach.Error(),
+			Time:   breach.Time,
+			Values: map[string]float64{"value": breach.Value, "threshold": breach.Threshold},
+		}, 10*time.Second, g.OnError)
+	}
+	return breach
+}
+
+// check 执行风控检查，调用方需持有锁
+func (g *RiskGuard) check(order RiskOrder) (*RiskBreach, error) {
+	current := g.positions[order.Symbol]
+	reducing := math.Abs(order.Notional) <= math.Abs(current) && order.Notional*current >= 0
+	if reducing {
+		return nil, nil
+	}
+	if order.Equity <= 0 {
+		return nil, fmt.Errorf("权益必须大于0")
+	}
+	g.rollDay(order.Time)
+	limits := g.Limits
+	breach := func(limit int, value, threshold float64) *RiskBreach {
+		return &RiskBreach{Symbol: order.Symbol, Time: order.Time, Limit: limit, Value: value, Threshold: threshold}
+	}
+
+	if order.Time < g.cooldownUntil {
+		return breach(RiskCooldown, float64(g.cooldownUntil-order.Time), float64(limits.Cooldown.Milliseconds())), nil
+	}
+	if limits.MaxDailyLoss > 0 && -g.dailyPnL >= limits.MaxDailyLoss {
+		return breach(RiskDailyLoss, -g.dailyPnL, limits.MaxDailyLoss), nil
+	}
+
+	open := 0
+	exposure := math.Abs(order.Notional)
+	if order.Notional != 0 {
+		open++
+	}
+	for symbol, notional := range g.positions {
+		if symbol == order.Symbol || notional == 0 {
+			continue
+		}
+		open++
+		exposure += math.Abs(notional)
+	}
+	if limits.MaxOpenPositions > 0 && open > limits.MaxOpenPositions {
+		return breach(RiskOpenPositions, float64(open), float64(limits.MaxOpenPositions)), nil
+	}
+	if leverage := exposure / order.Equity; limits.MaxLeverage > 0 && leverage > limits.MaxLeverage {
+		return breach(RiskLeverage, leverage, limits.MaxLeverage), nil
+	}
+	return nil, nil
+}
+
+// SetPosition 更新成交后的持仓名义价值（带方向），0 表示已平仓
+func (g *RiskGuard) SetPosition(symbol string, notional float64) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if notional == 0 {
+		delete(g.positions, symbol)
+		return
+	}
+	g.positions[symbol] = notional
+}
+
+// RecordTrade 记录一笔平仓交易的盈亏
+// 参数：
+//   - closeTime: 平仓时间（毫秒）
+//   - pnl: 扣除手续费后的已实现盈亏
+//
+// 说明/注意事项：
+//
+//	盈利交易清零连续亏损笔数；连续亏损达到 MaxConsecutiveLosses 时从 closeTime 开始进入冷却期并清零计数。
+func (g *RiskGuard) RecordTrade(closeTime int64, pnl float64) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.rollDay(closeTime)
+	g.dailyPnL += pnl
+	if pnl >= 0 {
+		g.losses = 0
+		return
+	}
+	g.losses++
+	if g.Limits.MaxConsecutiveLosses > 0 && g.losses >= g.Limits.MaxConsecutiveLosses {
+		g.cooldownUntil = closeTime + g.Limits.Cooldown.Milliseconds()
+		g.losses = 0
+	}
+}
+
+// DailyPnL 返回指定时间所在交易日的已实现盈亏
+func (g *RiskGuard) DailyPnL(at int64) float64 {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if g.tradingDay(at) != g.day {
+		return 0
+	}
+	return g.dailyPnL
+}
+
+// CooldownUntil 返回冷却期结束时间（毫秒），不在冷却期时为之前的值或 0
+func (g *RiskGuard) CooldownUntil() int64 {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.cooldownUntil
+}
+
+// PaperSignal 在风控检查通过后向模拟盘发送信号，并自动更新持仓和记录平仓盈亏
+// 参数：
+//   - p: 模拟盘跟踪器，对应 symbol 的持仓
+//   - symbol: 交易对
+//   - at: 信号时间（毫秒）
+//   - side/fraction/price: 同 PaperTrader.Signal
+//
+// 返回值：
+//   - error: 被拦截时为 *RiskBreach，此时不调用 p.Signal
+func (g *RiskGuard) PaperSignal(p *PaperTrader, symbol string, at int64, side int, fraction, price float64) error {
+	if price <= 0 {
+		return fmt.Errorf("价格必须大于0")
+	}
+	p.Mark(price)
+	equity := p.Equity()
+	if err := g.Check(RiskOrder{Symbol: symbol, Time: at, Notional: float64(side) * fraction * equity, Equity: equity}); err != nil {
+		return err
+	}
+	realized, fees := p.Realized, p.Fees
+	wasOpen := p.Position != 0
+	if err := p.Signal(side, fraction, price); err != nil {
+		return err
+	}
+	g.SetPosition(symbol, p.Position*price)
+	if wasOpen && p.Realized != realized {
+		g.RecordTrade(at, p.Realized-realized-(p.Fees-fees))
+	}
+	return nil
+}
+
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
@@ -80,6 +80,19 @@ func (k *KlineDatas) RMA(period int, source string) (*TaRMA, error) {
 	return CalculateRMA(prices, period)
 }
 
+// RMA_ 计算并返回最新的 RMA 值，数据不足时返回 0
+func (k *KlineDatas) RMA_(period int, source string) float64 {
+	_k, err := k.Keep(quickKeep("rma", period))
+	if err != nil {
+		_k = *k
+	}
+	rma, err := _k.RMA(period, source)
+	if err != nil {
+		return 0
+	}
+	return rma.Value()
+}
+
 // Value 获取RMA的最新值
 // 返回值：
 //   - float64: RMA数组中的最后一个值
@@ -87,6 +100,21 @@ func (t *TaRMA) Value() float64 {
 	return t.Values[len(t.Values)-1]
 }
 
+// Slope 返回最近 n 根 K 线 RMA 的平均每根变化量，数据不足或落在预热期时返回 0
+func (t *TaRMA) Slope(n int) float64 {
+	return seriesSlope(t.Values, n)
+}
+
+// Acceleration 返回最近 n 根 K 线 RMA 斜率相对再之前 n 根的变化，正数表示加速上行或减速下行
+func (t *TaRMA) Acceleration(n int) float64 {
+	return seriesAcceleration(t.Values, n)
+}
+
+// GetTrend 按最近 n 根 K 线 RMA 的斜率返回 TrendUp、TrendDown 或 TrendFlat
+func (t *TaRMA) GetTrend(n int) int {
+	return seriesTrend(t.Values, n)
+}
+
 // ----------------------------------------------------------------------------
 // ----------------------------------------------------------------------------
 // ----------------------------------------------------------------------------
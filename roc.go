@@ -0,0 +1,133 @@
+package ta
+
+import (
+	"fmt"
+)
+
+// ROC 的输出方式
+const (
+	// ROCPercent 百分比输出，100 × (当前价 − n 根前价格) / n 根前价格
+	ROCPercent = iota
+	// ROCRatio 比例输出，(当前价 − n 根前价格) / n 根前价格，即百分比输出的 1/100
+	ROCRatio
+)
+
+// TaROC 变动率指标（Rate of Change）的计算结果
+// 字段：
+//   - Values: 变动率，前 period 个位置为 0；两种输出方式均以 0 为中轴
+//   - Period: 比较的 K 线间隔
+//   - Mode: 输出方式，ROCPercent 或 ROCRatio
+type TaROC struct {
+	Values []float64 `json:"values"`
+	Period int       `json:"period"`
+	Mode   int       `json:"mode"`
+}
+
+// CalculateROC 计算变动率
+// 参数：
+//   - prices: 价格序列
+//   - period: 比较的 K 线间隔，如 12
+//   - mode: 输出方式，ROCPercent 或 ROCRatio
+//
+// 返回值：
+//   - *TaROC: 变动率结果，n 根前价格为 0 的位置为 0
+//   - error: 参数无效或数据不足时返回错误
+//
+// 示例：
+//
+//	roc, err := CalculateROC(closes, 12, ROCPercent)
+//	if err != nil {
+//	    // 处理错误
+//	}
+//	if roc.IsCrossAboveZero() {
+//	    // 动量由负转正
+//	}
+func CalculateROC(prices []float64, period, mode int) (*TaROC, error) {
+	if period <= 0 {
+		return nil, fmt.Errorf("周期必须大于0")
+	}
+	var scale float64
+	switch mode {
+	case ROCPercent:
+		scale = 100
+	case ROCRatio:
+		scale = 1
+	default:
+		return nil, fmt.Errorf("无效的输出方式: %d", mode)
+	}
+	if len(prices) <= period {
+		return nil, fmt.Errorf("计算数据不足")
+	}
+
+	length := len(prices)
+	values := make([]float64, length)
+	for i := period; i < length; i++ {
+		if prev := prices[i-period]; prev != 0 {
+			values[i] = (prices[i] - prev) / prev * scale
+		}
+	}
+
+	return &TaROC{
+		Values: values,
+		Period: period,
+		Mode:   mode,
+	}, nil
+}
+
+// ROC 从 KlineDatas 中提取数据并计算百分比变动率
+// 参数：
+//   - period: 比较的 K 线间隔
+//   - source: 数据源，如 "close"
+//
+// 返回值：
+//   - *TaROC: 变动率结果
+//   - error: 提取数据或计算过程中的错误
+func (k *KlineDatas) ROC(period int, source string) (*TaROC, error) {
+	prices, err := k.ExtractSlice(source)
+	if err != nil {
+		return nil, err
+	}
+	return CalculateROC(prices, period, ROCPercent)
+}
+
+// ROC_ 计算并返回最新的百分比变动率，数据不足时返回 0
+func (k *KlineDatas) ROC_(period int, source string) float64 {
+	_k, err := k.Keep(quickKeep("roc", period))
+	if err != nil {
+		_k = *k
+	}
+	roc, err := _k.ROC(period, source)
+	if err != nil {
+		return 0
+	}
+	return roc.Value()
+}
+
+// Value 返回最新的变动率
+func (t *TaROC) Value() float64 {
+	return t.Values[len(t.Values)-1]
+}
+
+// IsCrossAboveZero 判断最新一根K线变动率是否上穿 0 轴（前一根 ≤ 0，当前 > 0）
+func (t *TaROC) IsCrossAboveZero() bool {
+	lastIndex := len(t.Values) - 1
+	if lastIndex-1 < t.Period {
+		return false
+	}
+	return t.Values[lastIndex-1] <= 0 && t.Values[lastIndex] > 0
+}
+
+// IsCrossBelowZero 判断最新一根K线变动率是否下穿 0 轴（前一根 ≥ 0，当前 < 0）
+func (t *TaROC) IsCrossBelowZero() bool {
+	lastIndex := len(t.Values) - 1
+	if lastIndex-1 < t.Period {
+		return false
+	}
+	return t.Values[lastIndex-1] >= 0 && t.Values[lastIndex] < 0
+}
+
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
@@ -0,0 +1,274 @@
+package ta
+
+import (
+	"fmt"
+)
+
+// TaRolling 自定义滚动窗口统计的计算结果
+// 说明：
+//
+//	对输入序列的每个长度为 Window 的窗口调用用户提供的函数，
+//	结果与内置指标一致：预热期（前 Window-1 个位置）填充为 0。
+//
+// 字段：
+//   - Values: 每个时间点的统计值
+//   - Window: 窗口长度
+type TaRolling struct {
+	Values []float64 `json:"values"`
+	Window int       `json:"window"`
+
+	fn  func(window []float64) float64
+	buf []float64
+}
+
+// TaRollingMulti 多输出自定义滚动窗口统计的计算结果
+// 字段：
+//   - Values: 每个输出对应一条序列，Values[j][i] 为第 j 个输出在 i 处的值
+//   - Window: 窗口长度
+type TaRollingMulti struct {
+	Values [][]float64 `json:"values"`
+	Window int         `json:"window"`
+
+	fn  func(window []float64) []float64
+	buf []float64
+}
+
+// Rolling 使用自定义函数计算滚动窗口统计
+// 参数：
+//   - prices: 输入序列
+//   - window: 窗口长度
+//   - fn: 窗口统计函数，传入的切片与 prices 共享底层数组，不应被修改或保留
+//
+// 返回值：
+//   - *TaRolling: 计算结果
+//   - error: 窗口无效或数据不足时返回错误
+//
+// 示例：
+//
+//	maxHigh, err := Rolling(highs, 20, func(w []float64) float64 {
+//	    m := w[0]
+//	    for _, v := range w {
+//	        m = max(m, v)
+//	    }
+//	    return m
+//	})
+func Rolling(prices []float64, window int, fn func(window []float64) float64) (*TaRolling, error) {
+	if window <= 0 {
+		return nil, fmt.Errorf("窗口长度必须大于0")
+	}
+	if fn == nil {
+		return nil, fmt.Errorf("统计函数不能为空")
+	}
+	if len(prices) < window {
+		return nil, fmt.Errorf("计算数据不足")
+	}
+
+	length := len(prices)
+	values := make([]float64, length)
+	w, _ := NewWindow(length, window, 1, 0)
+	for w.Next() {
+		values[w.Index()] = fn(prices[w.Start():w.End()])
+	}
+
+	buf := make([]float64, window, window*2)
+	copy(buf, prices[length-window:])
+
+	return &TaRolling{
+		Values: values,
+		Window: window,
+		fn:     fn,
+		buf:    buf,
+	}, nil
+}
+
+// RollingMulti 使用返回多个值的自定义函数计算滚动窗口统计
+// 参数：
+//   - prices: 输入序列
+//   - window: 窗口长度
+//   - outputs: 函数返回值的个数
+//   - fn: 窗口统计函数，返回长度必须等于 outputs
+//
+// 返回值：
+//   - *TaRollingMulti: 计算结果
+//   - error: 参数无效、数据不足或函数返回长度不符时返回错误
+//
+// 示例：
+//
+//	hl, err := RollingMulti(prices, 20, 2, func(w []float64) []float64 {
+//	    ...
+//	    return []float64{highest, lowest}
+//	})
+func RollingMulti(prices []float64, window, outputs int, fn func(window []float64) []float64) (*TaRollingMulti, error) {
+	if window <= 0 {
+		return nil, fmt.Errorf("窗口长度必须大于0")
+	}
+	if outputs <= 0 {
+		return nil, fmt.Errorf("输出数量必须大于0")
+	}
+	if fn == nil {
+		return nil, fmt.Errorf("统计函数不能为空")
+	}
+	if len(prices) < window {
+		return nil, fmt.Errorf("计算数据不足")
+	}
+
+	length := len(prices)
+	values := preallocateSlices(length, outputs)
+	w, _ := NewWindow(length, window, 1, 0)
+	for w.Next() {
+		out := fn(prices[w.Start():w.End()])
+		if len(out) != outputs {
+			return nil, fmt.Errorf("第%d个窗口返回%d个值，期望%d个", w.Index()+1, len(out), outputs)
+		}
+		for j := range out {
+			values[j][w.Index()] = out[j]
+		}
+	}
+
+	buf := make([]float64, window, window*2)
+	copy(buf, prices[length-window:])
+
+	return &TaRollingMulti{
+		Values: values,
+		Window: window,
+		fn:     fn,
+		buf:    buf,
+	}, nil
+}
+
+// Rolling 从 KlineDatas 中提取指定数据源并计算自定义滚动窗口统计
+// 参数：
+//   - window: 窗口长度
+//   - source: 数据源，如 "close"
+//   - fn: 窗口统计函数
+//
+// 返回值：
+//   - *TaRolling: 计算结果
+//   - error: 提取数据或计算过程中的错误
+func (k *KlineDatas) Rolling(window int, source string, fn func(window []float64) float64) (*TaRolling, error) {
+	prices, err := k.ExtractSlice(source)
+	if err != nil {
+		return nil, err
+	}
+	return Rolling(prices, window, fn)
+}
+
+// pushWindow 将新值追加到窗口缓冲区，缓冲区满时整体前移以复用底层数组
+func pushWindow(buf []float64, window int, price float64) []float64 {
+	if len(buf) == cap(buf) {
+		n := copy(buf, buf[len(buf)-window+1:])
+		buf = buf[:n]
+	}
+	return append(buf, price)
+}
+
+// Update 追加一个新数据并计算最新窗口的统计值
+// 参数：
+//   - price: 新数据
+//
+// 返回值：
+//   - float64: 最新窗口的统计值
+//   - error: 增量状态未初始化时返回错误
+//
+// 说明/注意事项：
+//
+//	用于实时行情的增量计算，结果同时追加到 Values 末尾。
+//	统计函数与窗口数据不在导出字段中，只有 Rolling 或 RestoreRolling 得到的结果可以增量更新。
+func (t *TaRolling) Update(price float64) (float64, error) {
+	if !t.ready() {
+		return 0, fmt.Errorf("滚动统计的增量状态未初始化，请使用 Rolling 或 RestoreRolling")
+	}
+	t.buf = pushWindow(t.buf, t.Window, price)
+	value := t.fn(t.buf[len(t.buf)-t.Window:])
+	t.Values = append(t.Values, value)
+	return value, nil
+}
+
+// ready 判断统计函数与窗口数据是否已初始化
+func (t *TaRolling) ready() bool {
+	return t.fn != nil && t.Window > 0 && len(t.buf) >= t.Window
+}
+
+// ValueAt 获取指定位置的统计值
+// 参数：
+//   - index: 位置索引，负数表示从末尾倒数（-1 为最新值）
+//
+// 返回值：
+//   - float64: 统计值
+//   - bool: 索引越界或仍处于预热期时返回 false
+func (t *TaRolling) ValueAt(index int) (float64, bool) {
+	if index < 0 {
+		index += len(t.Values)
+	}
+	if index < t.Window-1 || index >= len(t.Values) {
+		return 0, false
+	}
+	return t.Values[index], true
+}
+
+// Value 获取最新的统计值
+func (t *TaRolling) Value() float64 {
+	return t.Values[len(t.Values)-1]
+}
+
+// Update 追加一个新数据并计算最新窗口的各个输出
+// 参数：
+//   - price: 新数据
+//
+// 返回值：
+//   - []float64: 最新窗口的各个输出值
+//   - error: 增量状态未初始化时返回错误，只有 RollingMulti 或 RestoreRollingMulti 得到的结果可以增量更新
+func (t *TaRollingMulti) Update(price float64) ([]float64, error) {
+	if !t.ready() {
+		return nil, fmt.Errorf("滚动统计的增量状态未初始化，请使用 RollingMulti 或 RestoreRollingMulti")
+	}
+	t.buf = pushWindow(t.buf, t.Window, price)
+	out := t.fn(t.buf[len(t.buf)-t.Window:])
+	for j := range t.Values {
+		var v float64
+		if j < len(out) {
+			v = out[j]
+		}
+		t.Values[j] = append(t.Values[j], v)
+	}
+	return out, nil
+}
+
+// ready 判断统计函数、输出序列与窗口数据是否已初始化
+func (t *TaRollingMulti) ready() bool {
+	return t.fn != nil && t.Window > 0 && len(t.Values) > 0 && len(t.buf) >= t.Window
+}
+
+// ValueAt 获取指定位置的各个输出值
+// 参数：
+//   - index: 位置索引，负数表示从末尾倒数（-1 为最新值）
+//
+// 返回值：
+//   - []float64: 各个输出值
+//   - bool: 索引越界或仍处于预热期时返回 false
+func (t *TaRollingMulti) ValueAt(index int) ([]float64, bool) {
+	length := len(t.Values[0])
+	if index < 0 {
+		index += length
+	}
+	if index < t.Window-1 || index >= length {
+		return nil, false
+	}
+	out := make([]float64, len(t.Values))
+	for j := range t.Values {
+		out[j] = t.Values[j][index]
+	}
+	return out, true
+}
+
+// Value 获取最新的各个输出值
+func (t *TaRollingMulti) Value() []float64 {
+	out, _ := t.ValueAt(-1)
+	return out
+}
+
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
@@ -10,10 +10,16 @@ type TaRSI struct {
 	Period int       `json:"period"`
 	Gains  []float64 `json:"gains"`
 	Losses []float64 `json:"losses"`
+
+	avgGain   float64
+	avgLoss   float64
+	prevPrice float64
+	// ready 表示增量状态已由 CalculateRSI 或 RestoreRSI 初始化
+	ready bool
 }
 
 func CalculateRSI(prices []float64, period int) (*TaRSI, error) {
-	if len(prices) < period {
+	if len(prices) <= period {
 		return nil, fmt.Errorf("计算数据不足")
 	}
 
@@ -51,10 +57,14 @@ func CalculateRSI(prices []float64, period int) (*TaRSI, error) {
 	}
 
 	return &TaRSI{
-		Values: rsi,
-		Period: period,
-		Gains:  gains,
-		Losses: losses,
+		Values:    rsi,
+		Period:    period,
+		Gains:     gains,
+		Losses:    losses,
+		avgGain:   avgGain,
+		avgLoss:   avgLoss,
+		prevPrice: prices[length-1],
+		ready:     true,
 	}, nil
 }
 
@@ -66,6 +76,45 @@ func (k *KlineDatas) RSI(period int, source string) (*TaRSI, error) {
 	return CalculateRSI(prices, period)
 }
 
+// RSI_ 计算并返回最新的 RSI 值，数据不足时返回 0
+func (k *KlineDatas) RSI_(period int, source string) float64 {
+	_k, err := k.Keep(quickKeep("rsi", period))
+	if err != nil {
+		_k = *k
+	}
+	rsi, err := _k.RSI(period, source)
+	if err != nil {
+		return 0
+	}
+	return rsi.Value()
+}
+
+// Update 追加一个新价格并增量计算最新的 RSI，结果同时追加到 Values 末尾
+// 说明/注意事项：
+//
+//	平均涨跌幅与前一个价格不在导出字段中，只有 CalculateRSI 或 RestoreRSI 得到的结果可以增量更新，
+//	从 JSON 解码或手工构造的 TaRSI 调用时返回错误。
+func (t *TaRSI) Update(price float64) (float64, error) {
+	if !t.ready {
+		return 0, fmt.Errorf("RSI 增量状态未初始化，请使用 CalculateRSI 或 RestoreRSI")
+	}
+	change := price - t.prevPrice
+	gain, loss := math.Max(0, change), math.Max(0, -change)
+	n := float64(t.Period)
+	t.avgGain = (t.avgGain*(n-1) + gain) / n
+	t.avgLoss = (t.avgLoss*(n-1) + loss) / n
+	t.prevPrice = price
+
+	value := 100.0
+	if t.avgLoss != 0 {
+		value = 100 - (100 / (1 + t.avgGain/t.avgLoss))
+	}
+	t.Values = append(t.Values, value)
+	t.Gains = append(t.Gains, gain)
+	t.Losses = append(t.Losses, loss)
+	return value, nil
+}
+
 func (t *TaRSI) Value() float64 {
 	return t.Values[len(t.Values)-1]
 }
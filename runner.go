@@ -0,0 +1,281 @@
+package ta
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// StrategyRunner 实时运行 Strategy 的运行器
+// 说明：
+//
+//	接收单个交易对的流式 K 线，开始时间与最后一根相同时视为未收盘 K 线的更新并替换，
+//	收到开始时间更晚的 K 线时认为上一根已收盘，对其调用 OnBar，再将策略设置的目标持仓与当前持仓之差
+//	作为市价单经风控检查后提交给券商，成交后调用 OnTrade。策略看到的都是已收盘 K 线，与 Backtest 一致；
+//	区别在于回测按下一根开盘价成交，实时运行按收盘时的参考价（收盘价）下单。
+//	券商为 PaperBroker 时每根 K 线都会撮合挂起的限价单，成交同样调用 OnTrade。方法可在多个协程中调用，回调依次执行。
+//
+// 字段：
+//   - Symbol: 交易对
+//   - Strategy: 策略
+//   - Broker: 券商接口
+//   - Guard: 风控，为空时不检查
+//   - Engine: 指标引擎，不为空时每根 K 线同时送入引擎，OnBar 的 StrategyContext.Values 为其快照
+//   - Window: 传给策略的最大 K 线数量，0 表示全部保留的 K 线
+//   - Equity: 返回当前权益的函数，券商为 PaperBroker 时默认使用其 Equity
+//   - OnError: Run 中处理 K 线失败时的回调，为空时忽略错误
+type StrategyRunner struct {
+	Symbol   string
+	Strategy Strategy
+	Broker   Broker
+	Guard    *RiskGuard
+	Engine   *Engine
+	Window   int
+	Equity   func() float64
+	OnError  func(symbol string, err error)
+
+	mu      sync.Mutex
+	maxBars int
+	klines  KlineDatas
+	closed  int64
+	book    *PaperTrader
+	stopped bool
+}
+
+// NewStrategyRunner 创建实时运行器并调用策略的 OnInit
+// 参数：
+//   - ctx: 查询券商持仓使用的上下文
+//   - symbol: 交易对
+//   - strategy: 策略
+//   - broker: 券商接口，当前持仓从 Positions 读取
+//   - history: 已收盘的历史 K 线，用于策略预热，超过 maxBars 时只保留最近部分
+//   - maxBars: 保留的最大 K 线数量
+//
+// 返回值：
+//   - *StrategyRunner: 运行器
+//   - error: 参数无效、查询持仓失败或 OnInit 返回错误时返回错误
+//
+// 示例：
+//
+//	broker, _ := NewPaperBroker(10000, TradingCosts{FeeRate: 0.0004})
+//	runner, err := NewStrategyRunner(ctx, "BTCUSDT", &myStrategy{}, broker, history, 1000)
+//	if err != nil {
+//	    // 处理错误
+//	}
+//	go runner.Run(ctx, klines) // klines 为交易所推送的 K 线通道
+func NewStrategyRunner(ctx context.Context, symbol string, strategy Strategy, broker Broker, history KlineDatas, maxBars int) (*StrategyRunner, error) {
+	r, err := newStrategyRunner(ctx, symbol, strategy, broker, history, maxBars)
+	if err != nil {
+		return nil, err
+	}
+	if err := strategy.OnInit(r.context(nil)); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// newStrategyRunner 创建运行器但不调用 OnInit
+func newStrategyRunner(ctx context.Context, symbol string, strategy Strategy, broker Broker, history KlineDatas, maxBars int) (*StrategyRunner, error) {
+	if strategy == nil || broker == nil {
+		return nil, fmt.Errorf("策略和券商接口不能为空")
+	}
+	if maxBars <= 0 {
+		return nil, fmt.Errorf("K线数量必须大于0")
+	}
+	positions, err := broker.Positions(ctx)
+	if err != nil {
+		return nil, err
+	}
+	r := &StrategyRunner{Symbol: symbol, Strategy: strategy, Broker: broker, maxBars: maxBars, closed: -1}
+	if paper, ok := broker.(*PaperBroker); ok {
+		r.Equity = paper.Equity
+	}
+	if len(history) > maxBars {
+		history = history[len(history)-maxBars:]
+	}
+	r.klines = make(KlineDatas, len(history))
+	copy(r.klines, history)
+	if n := len(r.klines); n > 0 {
+		r.closed = r.klines[n-1].StartTime
+	}
+	// 只记录持仓数量与均价，用于向风控报告平仓盈亏，手续费取自成交；已有持仓的均价未知，按最后收盘价估计
+	r.book = &PaperTrader{InitialEquity: 1, Position: positions[symbol]}
+	if r.book.Position != 0 && len(r.klines) > 0 {
+		r.book.EntryPrice = r.klines[len(r.klines)-1].Close
+	}
+	return r, nil
+}
+
+// context 创建截至最后一根 K 线的策略上下文，调用方需持有锁
+func (r *StrategyRunner) context(values map[string]float64) *StrategyContext {
+	klines := r.klines
+	if r.Window > 0 && len(klines) > r.Window {
+		klines = klines[len(klines)-r.Window:]
+	}
+	equity := 0.0
+	if r.Equity != nil {
+		equity = r.Equity()
+	}
+	ctx := newStrategyContext(r.Symbol, klines, r.book.Position, equity)
+	ctx.Values = values
+	return ctx
+}
+
+// OnKline 同步接收一根 K 线
+// 参数：
+//   - ctx: 下单使用的上下文
+//   - kline: 新 K 线或最后一根未收盘 K 线的更新
+//
+// 返回值：
+//   - error: K 线早于最后一根、运行器已停止、策略回调、风控拦截（*RiskBreach）或下单失败时返回错误；
+//     上一根 K 线的处理失败不影响新 K 线的保存
+func (r *StrategyRunner) OnKline(ctx context.Context, kline *KlineData) error {
+	if kline == nil {
+		return fmt.Errorf("K线为空")
+	}
+	copied := *kline
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.stopped {
+		return fmt.Errorf("运行器 %s 已停止", r.Symbol)
+	}
+	n := len(r.klines)
+	if n > 0 && r.klines[n-1].StartTime > copied.StartTime {
+		return fmt.Errorf("K线开始时间 %d 早于最后一根 %d", copied.StartTime, r.klines[n-1].StartTime)
+	}
+
+	var err error
+	if n > 0 && r.klines[n-1].StartTime < copied.StartTime && r.klines[n-1].StartTime != r.closed {
+		r.closed = r.klines[n-1].StartTime
+		err = r.bar(ctx)
+	}
+
+	if n > 0 && r.klines[n-1].StartTime == copied.StartTime {
+		r.klines[n-1] = &copied
+	} else {
+		r.klines = append(r.klines, &copied)
+		if len(r.klines) > r.maxBars {
+			r.klines = append(KlineDatas(nil), r.klines[len(r.klines)-r.maxBars:]...)
+		}
+	}
+	if r.Engine != nil {
+		if engineErr := r.Engine.Ingest(&copied); err == nil {
+			err = engineErr
+		}
+	}
+	if paper, ok := r.Broker.(*PaperBroker); ok {
+		for _, fill := range paper.Mark(r.Symbol, &copied) {
+			if tradeErr := r.trade(fill); err == nil {
+				err = tradeErr
+			}
+		}
+	}
+	return err
+}
+
+// bar 对最后一根已收盘 K 线调用 OnBar 并执行目标持仓，调用方需持有锁
+func (r *StrategyRunner) bar(ctx context.Context) error {
+	var values map[string]float64
+	if r.Engine != nil {
+		values = r.Engine.Snapshot()
+	}
+	sc := r.context(values)
+	if err := r.Strategy.OnBar(sc); err != nil {
+		return err
+	}
+	target, ok := sc.pendingTarget()
+	if !ok || target == r.book.Position {
+		return nil
+	}
+
+	last := r.klines[len(r.klines)-1]
+	if r.Guard != nil {
+		if err := r.Guard.Check(RiskOrder{Symbol: r.Symbol, Time: last.StartTime, Notional: target * last.Close, Equity: sc.Equity}); err != nil {
+			return err
+		}
+	}
+	status, err := r.Broker.PlaceOrder(ctx, Order{
+		Symbol:   r.Symbol,
+		Type:     OrderMarket,
+		Quantity: target - r.book.Position,
+		Price:    last.Close,
+		Time:     last.StartTime,
+	})
+	if err != nil {
+		return err
+	}
+	if status.Filled == 0 {
+		return nil
+	}
+	return r.trade(Fill{Symbol: r.Symbol, Time: last.StartTime, Quantity: status.Filled, Price: status.Price, Fee: status.Fee})
+}
+
+// trade 记录成交、更新风控并调用 OnTrade，调用方需持有锁
+func (r *StrategyRunner) trade(fill Fill) error {
+	realized := r.book.Realized
+	wasOpen := r.book.Position != 0
+	if err := r.book.Target(r.book.Position+fill.Quantity, fill.Price); err != nil {
+		return err
+	}
+	if r.Guard != nil {
+		r.Guard.SetPosition(r.Symbol, r.book.Position*fill.Price)
+		if wasOpen && r.book.Realized != realized {
+			r.Guard.RecordTrade(fill.Time, r.book.Realized-realized-fill.Fee)
+		}
+	}
+	return r.Strategy.OnTrade(r.context(nil), fill)
+}
+
+// Run 从通道接收 K 线直到通道关闭或 ctx 取消，然后调用 Stop
+// 返回值：
+//   - error: ctx 取消时返回 ctx.Err()，否则返回 Stop 的结果；处理单根 K 线的错误交给 OnError
+func (r *StrategyRunner) Run(ctx context.Context, input <-chan *KlineData) error {
+	for {
+		select {
+		case <-ctx.Done():
+			r.Stop()
+			return ctx.Err()
+		case kline, ok := <-input:
+			if !ok {
+				return r.Stop()
+			}
+			if err := r.OnKline(ctx, kline); err != nil && r.OnError != nil {
+				r.OnError(r.Symbol, err)
+			}
+		}
+	}
+}
+
+// Stop 停止运行器并调用一次 OnStop，之后的 OnKline 返回错误；不会自动平仓
+func (r *StrategyRunner) Stop() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.stopped {
+		return nil
+	}
+	r.stopped = true
+	return r.Strategy.OnStop(r.context(nil))
+}
+
+// Position 返回运行器记录的当前持仓数量
+func (r *StrategyRunner) Position() float64 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.book.Position
+}
+
+// Klines 返回当前 K 线的副本
+func (r *StrategyRunner) Klines() KlineDatas {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	out := make(KlineDatas, len(r.klines))
+	copy(out, r.klines)
+	return out
+}
+
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
@@ -0,0 +1,278 @@
+package ta
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// StrategyState 策略自身需要持久化的状态
+// 字段：
+//   - Indicators: 增量指标状态，由 TaEMA.State 等方法导出，按名称保存
+//   - Models: 模型等其他状态，由策略自行序列化
+type StrategyState struct {
+	Indicators map[string]IndicatorState  `json:"indicators,omitempty"`
+	Models     map[string]json.RawMessage `json:"models,omitempty"`
+}
+
+// StatefulStrategy 可保存与恢复自身状态的策略
+// 说明：
+//
+//	StrategyRunner.State 调用 SaveState 保存策略状态；RestoreStrategyRunner 在调用 OnInit 之前调用 RestoreState，
+//	策略可在 OnInit 中据此跳过已恢复指标的预热和模型的重新训练。
+type StatefulStrategy interface {
+	Strategy
+	SaveState() (*StrategyState, error)
+	RestoreState(state *StrategyState) error
+}
+
+// PaperBrokerState 模拟盘券商的状态
+// 字段：
+//   - Costs/InitialEquity: 同 PaperBroker
+//   - Traders: 各交易对的记账器
+//   - Orders: 挂起的限价单
+//   - NextID: 已使用的订单编号
+type PaperBrokerState struct {
+	Costs         TradingCosts           `json:"costs"`
+	InitialEquity float64                `json:"initial_equity"`
+	Traders       map[string]PaperTrader `json:"traders"`
+	Orders        []PendingOrder         `json:"orders,omitempty"`
+	NextID        int                    `json:"next_id"`
+}
+
+// State 导出模拟盘券商的状态
+func (b *PaperBroker) State() PaperBrokerState {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	state := PaperBrokerState{
+		Costs:         b.Costs,
+		InitialEquity: b.InitialEquity,
+		Traders:       make(map[string]PaperTrader, len(b.traders)),
+		Orders:        append([]PendingOrder(nil), b.orders...),
+		NextID:        b.nextID,
+	}
+	for symbol, p := range b.traders {
+		state.Traders[symbol] = *p
+	}
+	return state
+}
+
+// RestorePaperBroker 从状态恢复模拟盘券商
+func RestorePaperBroker(state PaperBrokerState) (*PaperBroker, error) {
+	b, err := NewPaperBroker(state.InitialEquity, state.Costs)
+	if err != nil {
+		return nil, err
+	}
+	for symbol, p := range state.Traders {
+		b.traders[symbol] = &p
+	}
+	b.orders = append(b.orders, state.Orders...)
+	b.nextID = state.NextID
+	return b, nil
+}
+
+// RiskGuardState 风控检查器的运行状态，不含风控限制和回调
+// 字段：
+//   - Day: 当前交易日
+//   - DailyPnL: 当日已实现盈亏
+//   - Losses: 连续亏损笔数
+//   - CooldownUntil: 冷却期结束时间（毫秒）
+//   - Positions: 各交易对的持仓名义价值
+type RiskGuardState struct {
+	Day           string             `json:"day"`
+	DailyPnL      float64            `json:"daily_pnl"`
+	Losses        int                `json:"losses"`
+	CooldownUntil int64              `json:"cooldown_until"`
+	Positions     map[string]float64 `json:"positions,omitempty"`
+}
+
+// State 导出风控检查器的运行状态
+func (g *RiskGuard) State() RiskGuardState {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	positions := make(map[string]float64, len(g.positions))
+	for symbol, notional := range g.positions {
+		positions[symbol] = notional
+	}
+	return RiskGuardState{Day: g.day, DailyPnL: g.dailyPnL, Losses: g.losses, CooldownUntil: g.cooldownUntil, Positions: positions}
+}
+
+// RestoreRiskGuard 使用风控限制和运行状态恢复风控检查器，Notifiers 等回调需重新设置
+func RestoreRiskGuard(limits RiskLimits, state RiskGuardState) (*RiskGuard, error) {
+	g, err := NewRiskGuard(limits)
+	if err != nil {
+		return nil, err
+	}
+	g.day, g.dailyPnL, g.losses, g.cooldownUntil = state.Day, state.DailyPnL, state.Losses, state.CooldownUntil
+	for symbol, notional := range state.Positions {
+		if notional != 0 {
+			g.positions[symbol] = notional
+		}
+	}
+	return g, nil
+}
+
+// RunnerState 实时运行器的完整状态，可 JSON 序列化到磁盘，重启后用 RestoreStrategyRunner 恢复
+// 字段：
+//   - Symbol: 交易对
+//   - Klines: 保留的滚动 K 线，最后一根可能未收盘
+//   - Closed: 最后一根已调用 OnBar 的 K 线开始时间，-1 表示没有
+//   - Position: 运行器记录的持仓数量
+//   - EntryPrice: 持仓均价
+//   - Strategy: 策略状态，策略未实现 StatefulStrategy 时为空
+//   - Broker: 模拟盘券商状态，券商不是 PaperBroker 时为空，实盘持仓以交易所为准
+//   - Guard: 风控状态，未设置风控时为空
+type RunnerState struct {
+	Symbol     string            `json:"symbol"`
+	Klines     KlineDatas        `json:"klines"`
+	Closed     int64             `json:"closed"`
+	Position   float64           `json:"position"`
+	EntryPrice float64           `json:"entry_price"`
+	Strategy   *StrategyState    `json:"strategy,omitempty"`
+	Broker     *PaperBrokerState `json:"broker,omitempty"`
+	Guard      *RiskGuardState   `json:"guard,omitempty"`
+}
+
+// State 导出运行器的完整状态
+// 返回值：
+//   - *RunnerState: 运行器状态，K 线为副本
+//   - error: 策略 SaveState 返回错误时返回错误
+func (r *StrategyRunner) State() (*RunnerState, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	state := &RunnerState{
+		Symbol:     r.Symbol,
+		Klines:     make(KlineDatas, len(r.klines)),
+		Closed:     r.closed,
+		Position:   r.book.Position,
+		EntryPrice: r.book.EntryPrice,
+	}
+	for i, k := range r.klines {
+		copied := *k
+		state.Klines[i] = &copied
+	}
+	if stateful, ok := r.Strategy.(StatefulStrategy); ok {
+		s, err := stateful.SaveState()
+		if err != nil {
+			return nil, err
+		}
+		state.Strategy = s
+	}
+	if paper, ok := r.Broker.(*PaperBroker); ok {
+		s := paper.State()
+		state.Broker = &s
+	}
+	if r.Guard != nil {
+		s := r.Guard.State()
+		state.Guard = &s
+	}
+	return state, nil
+}
+
+// RestoreStrategyRunner 从状态恢复实时运行器，然后调用策略的 OnInit
+// 参数：
+//   - ctx: 查询券商持仓使用的上下文
+//   - state: LoadRunnerState 读取的状态
+//   - strategy: 新的策略实例，实现 StatefulStrategy 时先调用 RestoreState
+//   - broker: 券商接口，模拟盘应先用 RestorePaperBroker(*state.Broker) 恢复
+//   - maxBars: 保留的最大 K 线数量
+//
+// 返回值：
+//   - *StrategyRunner: 运行器，Guard、Engine 等字段需重新设置，风控可用 RestoreRiskGuard(limits, *state.Guard) 恢复
+//   - error: 参数无效、查询持仓失败、RestoreState 或 OnInit 返回错误时返回错误
+//
+// 说明/注意事项：
+//
+//	持仓以券商返回的为准；与状态中的持仓一致时沿用保存的均价，否则按最后收盘价估计。
+//	状态中的 K 线可直接作为 NewEngine 的历史数据重建指标引擎。
+//
+// 示例：
+//
+//	state, err := LoadRunnerState("btc.json")
+//	if err != nil {
+//	    // 首次启动，使用 NewStrategyRunner
+//	}
+//	broker, _ := RestorePaperBroker(*state.Broker)
+//	runner, err := RestoreStrategyRunner(ctx, state, &myStrategy{}, broker, 1000)
+//	defer func() {
+//	    if s, err := runner.State(); err == nil {
+//	        SaveRunnerState("btc.json", s)
+//	    }
+//	}()
+func RestoreStrategyRunner(ctx context.Context, state *RunnerState, strategy Strategy, broker Broker, maxBars int) (*StrategyRunner, error) {
+	if state == nil {
+		return nil, fmt.Errorf("运行器状态为空")
+	}
+	r, err := newStrategyRunner(ctx, state.Symbol, strategy, broker, state.Klines, maxBars)
+	if err != nil {
+		return nil, err
+	}
+	r.closed = state.Closed
+	if r.book.Position == state.Position {
+		r.book.EntryPrice = state.EntryPrice
+	}
+	if stateful, ok := strategy.(StatefulStrategy); ok && state.Strategy != nil {
+		if err := stateful.RestoreState(state.Strategy); err != nil {
+			return nil, err
+		}
+	}
+	if err := strategy.OnInit(r.context(nil)); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// SaveRunnerState 将运行器状态以 JSON 写入文件
+// 说明：
+//
+//	先写入同目录的临时文件再重命名，进程在写入中途退出时不会损坏已有的状态文件。
+func SaveRunnerState(path string, state *RunnerState) error {
+	if state == nil {
+		return fmt.Errorf("运行器状态为空")
+	}
+	data, err := json.Marshal(state)
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".tmp*")
+	if err != nil {
+		return fmt.Errorf("创建临时文件失败: %v", err)
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("写入状态失败: %v", err)
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return fmt.Errorf("写入状态失败: %v", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("关闭临时文件失败: %v", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("保存状态失败: %v", err)
+	}
+	return nil
+}
+
+// LoadRunnerState 从文件读取运行器状态，文件不存在时返回的错误满足 errors.Is(err, os.ErrNotExist)
+func LoadRunnerState(path string) (*RunnerState, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var state RunnerState
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, fmt.Errorf("解析状态失败: %v", err)
+	}
+	return &state, nil
+}
+
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
@@ -0,0 +1,172 @@
+package ta
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// emaStrategy 用增量 EMA 跟随趋势，状态可保存与恢复
+type emaStrategy struct {
+	BaseStrategy
+	ema      *TaEMA
+	bars     int
+	restored bool
+}
+
+func (s *emaStrategy) OnInit(ctx *StrategyContext) error {
+	if s.ema != nil {
+		return nil
+	}
+	ema, err := ctx.Klines.EMA(3, "close")
+	s.ema = ema
+	return err
+}
+
+func (s *emaStrategy) OnBar(ctx *StrategyContext) error {
+	s.bars++
+	v, err := s.ema.Update(ctx.Close())
+	if err != nil {
+		return err
+	}
+	if ctx.Close() > v {
+		ctx.Target(1)
+	} else {
+		ctx.Target(-1)
+	}
+	return nil
+}
+
+func (s *emaStrategy) SaveState() (*StrategyState, error) {
+	bars, _ := json.Marshal(s.bars)
+	return &StrategyState{
+		Indicators: map[string]IndicatorState{"ema": s.ema.State()},
+		Models:     map[string]json.RawMessage{"bars": bars},
+	}, nil
+}
+
+func (s *emaStrategy) RestoreState(state *StrategyState) error {
+	ema, err := RestoreEMA(state.Indicators["ema"])
+	if err != nil {
+		return err
+	}
+	s.ema, s.restored = ema, true
+	return json.Unmarshal(state.Models["bars"], &s.bars)
+}
+
+func TestRunnerStateWarmRestart(t *testing.T) {
+	ctx := context.Background()
+	prices := []float64{100, 101, 103, 102, 99, 97, 98, 101, 104, 103, 100, 98}
+	klineData := strategyTestKlines(prices)
+	history, stream := klineData[:4], klineData[4:]
+	guardLimits := RiskLimits{MaxLeverage: 10}
+
+	newRunner := func() *StrategyRunner {
+		broker, _ := NewPaperBroker(1000, TradingCosts{FeeRate: 0.001})
+		runner, err := NewStrategyRunner(ctx, "BTCUSDT", &emaStrategy{}, broker, history, 50)
+		if err != nil {
+			t.Fatal(err)
+		}
+		runner.Guard, _ = NewRiskGuard(guardLimits)
+		return runner
+	}
+
+	// 不中断运行作为基准
+	base := newRunner()
+	for _, k := range stream {
+		if err := base.OnKline(ctx, k); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if base.Position() == 0 {
+		t.Fatal("基准运行没有持仓")
+	}
+
+	// 运行一半后保存状态、重启并继续
+	first := newRunner()
+	for _, k := range stream[:4] {
+		if err := first.OnKline(ctx, k); err != nil {
+			t.Fatal(err)
+		}
+	}
+	state, err := first.State()
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(t.TempDir(), "runner.json")
+	if err := SaveRunnerState(path, state); err != nil {
+		t.Fatal(err)
+	}
+
+	loaded, err := LoadRunnerState(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	broker, err := RestorePaperBroker(*loaded.Broker)
+	if err != nil {
+		t.Fatal(err)
+	}
+	strategy := &emaStrategy{}
+	second, err := RestoreStrategyRunner(ctx, loaded, strategy, broker, 50)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if second.Guard, err = RestoreRiskGuard(guardLimits, *loaded.Guard); err != nil {
+		t.Fatal(err)
+	}
+	if !strategy.restored {
+		t.Error("RestoreState 未被调用")
+	}
+	for _, k := range stream[4:] {
+		if err := second.OnKline(ctx, k); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	baseStrategy := base.Strategy.(*emaStrategy)
+	if strategy.bars != baseStrategy.bars || strategy.ema.Value() != baseStrategy.ema.Value() {
+		t.Errorf("恢复后 OnBar 次数/EMA = %d/%v, want %d/%v", strategy.bars, strategy.ema.Value(), baseStrategy.bars, baseStrategy.ema.Value())
+	}
+	if second.Position() != base.Position() {
+		t.Errorf("持仓 = %v, want %v", second.Position(), base.Position())
+	}
+	if got, want := broker.Equity(), base.Broker.(*PaperBroker).Equity(); math.Abs(got-want) > 1e-9 {
+		t.Errorf("权益 = %v, want %v", got, want)
+	}
+	if got, want := second.Guard.State().Positions["BTCUSDT"], base.Guard.State().Positions["BTCUSDT"]; got != want {
+		t.Errorf("风控持仓 = %v, want %v", got, want)
+	}
+	if len(second.Klines()) != len(base.Klines()) {
+		t.Errorf("K线数量 = %d, want %d", len(second.Klines()), len(base.Klines()))
+	}
+}
+
+func TestLoadRunnerStateMissing(t *testing.T) {
+	_, err := LoadRunnerState(filepath.Join(t.TempDir(), "missing.json"))
+	if !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("err = %v, want os.ErrNotExist", err)
+	}
+}
+
+func TestSaveRunnerStateNoTempLeft(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "state.json")
+	for i := 0; i < 2; i++ {
+		if err := SaveRunnerState(path, &RunnerState{Symbol: "BTCUSDT", Closed: int64(i)}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	entries, _ := os.ReadDir(dir)
+	if len(entries) != 1 {
+		t.Errorf("目录中有 %d 个文件, want 1", len(entries))
+	}
+	state, err := LoadRunnerState(path)
+	if err != nil || state.Closed != 1 {
+		t.Errorf("state = %+v, err = %v", state, err)
+	}
+}
@@ -0,0 +1,125 @@
+package ta
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestStrategyRunnerClosedBars(t *testing.T) {
+	ctx := context.Background()
+	broker, _ := NewPaperBroker(1000, TradingCosts{})
+	var closes []float64
+	strategy := &funcStrategy{onBar: func(sc *StrategyContext) error {
+		closes = append(closes, sc.Close())
+		if sc.Close() >= 102 {
+			sc.Target(1)
+		}
+		return nil
+	}}
+	recorder := &targetStrategy{}
+	history := strategyTestKlines([]float64{100, 101})
+	runner, err := NewStrategyRunner(ctx, "BTCUSDT", &multiStrategy{strategy, recorder}, broker, history, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// 同一开始时间的多次更新只在下一根 K 线到达后按最终收盘价调用一次 OnBar
+	stream := []*KlineData{
+		{StartTime: 120000, Open: 101, High: 101, Low: 101, Close: 101},
+		{StartTime: 120000, Open: 101, High: 102, Low: 101, Close: 102},
+		{StartTime: 180000, Open: 102, High: 103, Low: 102, Close: 103},
+	}
+	for _, k := range stream {
+		if err := runner.OnKline(ctx, k); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if len(closes) != 1 || closes[0] != 102 {
+		t.Fatalf("OnBar 收盘价 = %v, want [102]", closes)
+	}
+	if len(recorder.fills) != 1 || recorder.fills[0].Price != 102 || recorder.fills[0].Quantity != 1 {
+		t.Errorf("成交 = %+v", recorder.fills)
+	}
+	if runner.Position() != 1 || len(runner.Klines()) != 4 {
+		t.Errorf("持仓 = %v, K线数量 = %d", runner.Position(), len(runner.Klines()))
+	}
+	positions, _ := broker.Positions(ctx)
+	if positions["BTCUSDT"] != 1 {
+		t.Errorf("券商持仓 = %v", positions)
+	}
+
+	if err := runner.OnKline(ctx, &KlineData{StartTime: 0}); err == nil {
+		t.Error("早于最后一根的 K 线应返回错误")
+	}
+	if err := runner.Stop(); err != nil || !recorder.stopped {
+		t.Errorf("Stop = %v, OnStop = %v", err, recorder.stopped)
+	}
+	if err := runner.OnKline(ctx, &KlineData{StartTime: 240000, Close: 104}); err == nil {
+		t.Error("停止后应返回错误")
+	}
+}
+
+func TestStrategyRunnerRiskGuard(t *testing.T) {
+	ctx := context.Background()
+	broker, _ := NewPaperBroker(1000, TradingCosts{})
+	strategy := &funcStrategy{onBar: func(sc *StrategyContext) error {
+		sc.Target(100)
+		return nil
+	}}
+	runner, err := NewStrategyRunner(ctx, "BTCUSDT", strategy, broker, strategyTestKlines([]float64{100}), 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	runner.Guard, _ = NewRiskGuard(RiskLimits{MaxLeverage: 2})
+
+	// 目标名义价值 100×101 远超 2 倍杠杆，被拦截且不下单
+	runner.OnKline(ctx, &KlineData{StartTime: 60000, Close: 101})
+	err = runner.OnKline(ctx, &KlineData{StartTime: 120000, Close: 102})
+	var breach *RiskBreach
+	if !errors.As(err, &breach) || breach.Limit != RiskLeverage {
+		t.Fatalf("err = %v, want 杠杆拦截", err)
+	}
+	if runner.Position() != 0 {
+		t.Errorf("被拦截后持仓 = %v, want 0", runner.Position())
+	}
+}
+
+// multiStrategy 依次调用多个策略的回调
+type multiStrategy []Strategy
+
+func (m multiStrategy) OnInit(ctx *StrategyContext) error {
+	for _, s := range m {
+		if err := s.OnInit(ctx); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m multiStrategy) OnBar(ctx *StrategyContext) error {
+	for _, s := range m {
+		if err := s.OnBar(ctx); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m multiStrategy) OnTrade(ctx *StrategyContext, fill Fill) error {
+	for _, s := range m {
+		if err := s.OnTrade(ctx, fill); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m multiStrategy) OnStop(ctx *StrategyContext) error {
+	for _, s := range m {
+		if err := s.OnStop(ctx); err != nil {
+			return err
+		}
+	}
+	return nil
+}
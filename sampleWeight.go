@@ -0,0 +1,138 @@
+package ta
+
+import (
+	"fmt"
+	"math"
+)
+
+// LabelConcurrency 计算每个时间点同时覆盖的标签数量
+// 参数：
+//   - labelEnds: 每个样本标签的结束位置，样本 i 的标签覆盖 [i, labelEnds[i]]
+//
+// 返回值：
+//   - []float64: 每个时间点的并发标签数量
+func LabelConcurrency(labelEnds []int) []float64 {
+	n := len(labelEnds)
+	diff := make([]float64, n+1)
+	for i, end := range labelEnds {
+		if end >= n {
+			end = n - 1
+		}
+		if end < i {
+			continue
+		}
+		diff[i]++
+		diff[end+1]--
+	}
+	concurrency := make([]float64, n)
+	var running float64
+	for t := 0; t < n; t++ {
+		running += diff[t]
+		concurrency[t] = running
+	}
+	return concurrency
+}
+
+// AverageUniqueness 计算每个样本标签的平均唯一性
+// 参数：
+//   - labelEnds: 每个样本标签的结束位置
+//
+// 返回值：
+//   - []float64: 样本 i 在其标签区间内 1/并发数 的平均值，取值 (0, 1]
+//
+// 说明/注意事项：
+//
+//	参见 López de Prado《Advances in Financial Machine Learning》第 4 章。
+//	标签区间重叠越多，唯一性越低，训练时应降低其权重。
+func AverageUniqueness(labelEnds []int) []float64 {
+	n := len(labelEnds)
+	concurrency := LabelConcurrency(labelEnds)
+
+	// 前缀和，便于 O(1) 求区间内 1/c 的和
+	prefix := make([]float64, n+1)
+	for t := 0; t < n; t++ {
+		if concurrency[t] > 0 {
+			prefix[t+1] = prefix[t] + 1/concurrency[t]
+		} else {
+			prefix[t+1] = prefix[t]
+		}
+	}
+
+	uniqueness := make([]float64, n)
+	for i, end := range labelEnds {
+		if end >= n {
+			end = n - 1
+		}
+		if end < i {
+			continue
+		}
+		uniqueness[i] = (prefix[end+1] - prefix[i]) / float64(end-i+1)
+	}
+	return uniqueness
+}
+
+// SampleWeights 计算基于收益归因和唯一性的样本权重
+// 参数：
+//   - labelEnds: 每个样本标签的结束位置
+//   - returns: 每个时间点的收益（建议使用对数收益），为 nil 时仅按平均唯一性加权
+//
+// 返回值：
+//   - []float64: 样本权重，已归一化为总和等于样本数量
+//   - error: 输入长度不一致时返回错误
+//
+// 说明/注意事项：
+//
+//	权重 w_i = |Σ r_t / c_t|，t 取样本 i 的标签区间，c_t 为并发标签数。
+//	这样重叠窗口不会主导拟合，同时收益贡献大的样本获得更高权重。
+//
+// 示例：
+//
+//	weights, err := SampleWeights(LabelEnds(len(closes), 10), logReturns)
+func SampleWeights(labelEnds []int, returns []float64) ([]float64, error) {
+	n := len(labelEnds)
+	if returns != nil && len(returns) != n {
+		return nil, fmt.Errorf("输入数据长度不一致")
+	}
+
+	var weights []float64
+	if returns == nil {
+		weights = AverageUniqueness(labelEnds)
+	} else {
+		concurrency := LabelConcurrency(labelEnds)
+		prefix := make([]float64, n+1)
+		for t := 0; t < n; t++ {
+			prefix[t+1] = prefix[t]
+			if concurrency[t] > 0 {
+				prefix[t+1] += returns[t] / concurrency[t]
+			}
+		}
+		weights = make([]float64, n)
+		for i, end := range labelEnds {
+			if end >= n {
+				end = n - 1
+			}
+			if end < i {
+				continue
+			}
+			weights[i] = math.Abs(prefix[end+1] - prefix[i])
+		}
+	}
+
+	var sum float64
+	for _, w := range weights {
+		sum += w
+	}
+	if sum > 0 {
+		scale := float64(n) / sum
+		for i := range weights {
+			weights[i] *= scale
+		}
+	}
+	return weights, nil
+}
+
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
@@ -0,0 +1,108 @@
+package ta
+
+// Shift 将序列整体平移 n 个位置
+// 参数：
+//   - series: 输入序列
+//   - n: 平移量，正数向后平移（取过去的值，即 lag），负数向前平移（取未来的值，即 lead）
+//
+// 返回值：
+//   - []float64: 与输入等长的新序列，空出的位置填充为 0
+//
+// 说明/注意事项：
+//
+//	Shift(series, 1)[i] == series[i-1]，用于表达“当根收盘产生信号、下一根开盘执行”。
+//	负数平移会引入未来数据，只应用于构造标签，不应用于特征或信号。
+//
+// 示例：
+//
+//	prevClose := Shift(closes, 1)
+func Shift(series []float64, n int) []float64 {
+	out := make([]float64, len(series))
+	ShiftInto(out, series, n)
+	return out
+}
+
+// ShiftInto 将 series 平移 n 个位置后写入 dst，避免额外分配
+// 参数：
+//   - dst: 输出序列，长度应与 series 一致，可复用
+//   - series: 输入序列，不能与 dst 是同一个切片
+//   - n: 平移量，含义同 Shift
+func ShiftInto(dst, series []float64, n int) {
+	size := len(dst)
+	if len(series) < size {
+		size = len(series)
+	}
+	for i := 0; i < size; i++ {
+		j := i - n
+		if j >= 0 && j < size {
+			dst[i] = series[j]
+		} else {
+			dst[i] = 0
+		}
+	}
+}
+
+// Lag 获取序列在 index 位置往前 n 根的值
+// 参数：
+//   - series: 输入序列
+//   - index: 当前位置
+//   - n: 回看数量，必须非负
+//
+// 返回值：
+//   - float64: series[index-n] 的值
+//   - bool: 越界时返回 false
+func Lag(series []float64, index, n int) (float64, bool) {
+	j := index - n
+	if n < 0 || index >= len(series) || j < 0 {
+		return 0, false
+	}
+	return series[j], true
+}
+
+// CrossOver 判断 a 是否在 index 处上穿 b（前一根 a<=b，当前 a>b）
+// 参数：
+//   - a, b: 两条等长序列
+//   - index: 当前位置
+//
+// 返回值：
+//   - bool: 发生上穿返回 true，index 越界或为 0 时返回 false
+//
+// 说明/注意事项：
+//
+//	只使用 index 及之前的数据，不会产生未来函数。
+func CrossOver(a, b []float64, index int) bool {
+	if index < 1 || index >= len(a) || index >= len(b) {
+		return false
+	}
+	return a[index-1] <= b[index-1] && a[index] > b[index]
+}
+
+// CrossUnder 判断 a 是否在 index 处下穿 b（前一根 a>=b，当前 a<b）
+// 参数：
+//   - a, b: 两条等长序列
+//   - index: 当前位置
+//
+// 返回值：
+//   - bool: 发生下穿返回 true，index 越界或为 0 时返回 false
+func CrossUnder(a, b []float64, index int) bool {
+	if index < 1 || index >= len(a) || index >= len(b) {
+		return false
+	}
+	return a[index-1] >= b[index-1] && a[index] < b[index]
+}
+
+// SignalToNextBar 将信号序列整体后移一根，使第 i 根的动作只依赖第 i-1 根收盘时的信号
+// 参数：
+//   - signal: 信号序列，如由比较表达式得到的 1/0 序列
+//
+// 返回值：
+//   - []float64: 平移后的动作序列，第 0 根为 0
+func SignalToNextBar(signal []float64) []float64 {
+	return Shift(signal, 1)
+}
+
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
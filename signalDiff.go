@@ -0,0 +1,128 @@
+package ta
+
+import (
+	"fmt"
+)
+
+// 信号规则类型
+const (
+	// SignalCross 交叉：指标 A 相对指标 B 的上下位置，A > B 为 1，否则为 -1
+	SignalCross = iota
+	// SignalDirection 方向：指标 A 的符号，A > 0 为 1，否则为 -1，适用于 supertrend_dir 等方向值
+	SignalDirection
+	// SignalThreshold 阈值：指标 A 相对 Level 的位置，A > Level 为 1，否则为 -1
+	SignalThreshold
+)
+
+// SignalRule 一个需要监控状态翻转的信号
+// 字段：
+//   - Name: 信号名称
+//   - Kind: 规则类型，SignalCross、SignalDirection 或 SignalThreshold
+//   - A: 快照中的指标名
+//   - B: 快照中与 A 比较的指标名，仅 SignalCross 使用
+//   - Level: 阈值，仅 SignalThreshold 使用
+type SignalRule struct {
+	Name  string  `json:"name"`
+	Kind  int     `json:"kind"`
+	A     string  `json:"a"`
+	B     string  `json:"b"`
+	Level float64 `json:"level"`
+}
+
+// SignalChange 一个信号在两次快照之间的状态翻转
+// 字段：
+//   - Name: 信号名称
+//   - Kind: 规则类型
+//   - From: 上一次快照的状态，1 或 -1
+//   - To: 当前快照的状态，1 或 -1；To 为 1 表示上穿/转多/升破阈值
+type SignalChange struct {
+	Name string `json:"name"`
+	Kind int    `json:"kind"`
+	From int    `json:"from"`
+	To   int    `json:"to"`
+}
+
+// state 返回信号在快照中的状态
+func (r SignalRule) state(values map[string]float64) (int, error) {
+	a, ok := values[r.A]
+	if !ok {
+		return 0, fmt.Errorf("信号 %s 引用的指标 %s 不在快照中", r.Name, r.A)
+	}
+	var ref float64
+	switch r.Kind {
+	case SignalCross:
+		b, ok := values[r.B]
+		if !ok {
+			return 0, fmt.Errorf("信号 %s 引用的指标 %s 不在快照中", r.Name, r.B)
+		}
+		ref = b
+	case SignalDirection:
+	case SignalThreshold:
+		ref = r.Level
+	default:
+		return 0, fmt.Errorf("信号 %s 的规则类型无效: %d", r.Name, r.Kind)
+	}
+	if a > ref {
+		return 1, nil
+	}
+	return -1, nil
+}
+
+// ChangedSince 比较两次快照，返回状态发生翻转的信号
+// 参数：
+//   - rules: 信号规则，通常为 SnapshotConfig.Signals
+//   - prev: 上一根 K 线的快照
+//   - curr: 当前 K 线的快照
+//
+// 返回值：
+//   - []SignalChange: 按规则顺序排列的翻转信号，没有翻转时为空
+//   - error: 规则类型无效或引用的指标不在快照中时返回错误
+//
+// 说明/注意事项：
+//
+//	相等视为下方（-1），与 CrossOver/CrossUnder 的判断一致，因此触及但未越过不会产生两次翻转。
+//
+// 示例：
+//
+//	rules := []SignalRule{
+//	    {Name: "golden", Kind: SignalCross, A: "ema12", B: "ema26"},
+//	    {Name: "trend", Kind: SignalDirection, A: "supertrend_dir"},
+//	    {Name: "overbought", Kind: SignalThreshold, A: "rsi14", Level: 70},
+//	}
+//	changes, err := ChangedSince(rules, prevSnap, snap)
+func ChangedSince(rules []SignalRule, prev, curr map[string]float64) ([]SignalChange, error) {
+	var changes []SignalChange
+	for _, rule := range rules {
+		from, err := rule.state(prev)
+		if err != nil {
+			return nil, err
+		}
+		to, err := rule.state(curr)
+		if err != nil {
+			return nil, err
+		}
+		if from != to {
+			changes = append(changes, SignalChange{Name: rule.Name, Kind: rule.Kind, From: from, To: to})
+		}
+	}
+	return changes, nil
+}
+
+// ChangedSince 按引擎配置的信号规则比较 prev 与最新快照，返回状态发生翻转的信号
+// 参数：
+//   - prev: 之前通过 Snapshot 或策略回调得到的快照
+//
+// 返回值：
+//   - []SignalChange: 翻转的信号
+//   - error: 规则无效或引用的指标不在快照中时返回错误
+func (e *Engine) ChangedSince(prev map[string]float64) ([]SignalChange, error) {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	return ChangedSince(e.Config.Signals, prev, e.values)
+}
+
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
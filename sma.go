@@ -44,10 +44,38 @@ func (k *KlineDatas) SMA(period int, source string) (*TaSMA, error) {
 	return CalculateSMA(prices, period)
 }
 
+// SMA_ 计算并返回最新的 SMA 值，数据不足时返回 0
+func (k *KlineDatas) SMA_(period int, source string) float64 {
+	_k, err := k.Keep(quickKeep("sma", period))
+	if err != nil {
+		_k = *k
+	}
+	sma, err := _k.SMA(period, source)
+	if err != nil {
+		return 0
+	}
+	return sma.Value()
+}
+
 func (t *TaSMA) Value() float64 {
 	return t.Values[len(t.Values)-1]
 }
 
+// Slope 返回最近 n 根 K 线 SMA 的平均每根变化量，数据不足或落在预热期时返回 0
+func (t *TaSMA) Slope(n int) float64 {
+	return seriesSlope(t.Values, n)
+}
+
+// Acceleration 返回最近 n 根 K 线 SMA 斜率相对再之前 n 根的变化，正数表示加速上行或减速下行
+func (t *TaSMA) Acceleration(n int) float64 {
+	return seriesAcceleration(t.Values, n)
+}
+
+// GetTrend 按最近 n 根 K 线 SMA 的斜率返回 TrendUp、TrendDown 或 TrendFlat
+func (t *TaSMA) GetTrend(n int) int {
+	return seriesTrend(t.Values, n)
+}
+
 // ----------------------------------------------------------------------------
 // ----------------------------------------------------------------------------
 // ----------------------------------------------------------------------------
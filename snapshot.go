@@ -0,0 +1,337 @@
+package ta
+
+import (
+	"fmt"
+	"strings"
+)
+
+// SnapshotIndicator 快照中的一个指标
+// 字段：
+//   - Name: 结果中的键名，如 "rsi14"，为空时使用 Type
+//   - Type: 指标类型，多输出指标以 "_" 选择字段：
+//     sma/ema/rma/rsi(period)、atr/cci/wr(period)、obv、
+//     adx/adx_plus_di/adx_minus_di(period)、aroon_up/aroon_down/aroon_oscillator(period)、
+//     boll_upper/boll_mid/boll_lower(period, stdDev)、
+//     atrbands_upper/atrbands_mid/atrbands_lower(period, atrPeriod, multiplier)，中轨为 SMA、
+//     macd/macd_dif/macd_dea(short, long, signal)、
+//     kdj_k/kdj_d/kdj_j(rsv, k, d)、
+//     stoch_k/stoch_d(kPeriod, slowing, dPeriod)、
+//     candlestats_body/candlestats_upper_wick/candlestats_lower_wick/candlestats_close_position 及对应的 candlestats_avg_*(period)、
+//     supertrend_dir/supertrend_upper/supertrend_lower(period, multiplier)，supertrend_dir 上升趋势为 1，否则为 -1
+//   - Args: 指标参数，顺序见 Type 的说明
+//   - Source: 价格数据源，如 "close"、"hlc3"，为空时使用 "close"，仅对基于单一价格序列的指标生效
+type SnapshotIndicator struct {
+	Name   string    `json:"name"`
+	Type   string    `json:"type"`
+	Args   []float64 `json:"args"`
+	Source string    `json:"source"`
+}
+
+// SnapshotConfig 指标快照配置
+// 字段：
+//   - Indicators: 指标列表
+//   - Window: 参与计算的最近 K 线数量，0 或超过现有数据量时使用全部数据
+//   - Signals: 信号规则，供 ChangedSince 比较两次快照时使用，不影响快照计算
+type SnapshotConfig struct {
+	Indicators []SnapshotIndicator `json:"indicators"`
+	Window     int                 `json:"window"`
+	Signals    []SignalRule        `json:"signals"`
+}
+
+// MinBars 返回计算全部指标所需的最少 K 线数量，即各指标 WarmupLength+1 的最大值
+// 说明：
+//
+//	实时场景下数据量少于该值时快照必然失败，可据此区分"尚在预热"与配置错误。Window 小于该值时同样无法计算。
+func (c SnapshotConfig) MinBars() int {
+	need := 1
+	for _, ind := range c.Indicators {
+		familyName, _, _ := strings.Cut(strings.ToLower(ind.Type), "_")
+		if n := WarmupLength(familyName, snapshotIntArgs(ind.Args)...) + 1; n > need {
+			need = n
+		}
+	}
+	return need
+}
+
+// snapshotIntArgs 将快照参数取整，浮点倍数参数取整后不影响预热长度
+func snapshotIntArgs(args []float64) []int {
+	out := make([]int, len(args))
+	for i, a := range args {
+		out[i] = int(a)
+	}
+	return out
+}
+
+// Validate 检查指标类型、参数数量与范围、数据源以及 Window 是否足够，不需要 K 线数据
+// 说明：
+//
+//	多输出指标的字段名（如 boll_upper 中的 upper）在计算时才检查。
+func (c SnapshotConfig) Validate() error {
+	for _, ind := range c.Indicators {
+		if _, _, _, _, err := resolveSnapshotIndicator(ind); err != nil {
+			return err
+		}
+	}
+	if need := c.MinBars(); c.Window > 0 && c.Window < need {
+		return fmt.Errorf("窗口%d根K线小于指标所需的%d根", c.Window, need)
+	}
+	return nil
+}
+
+// resolveSnapshotIndicator 解析指标类型并校验参数与数据源
+func resolveSnapshotIndicator(ind SnapshotIndicator) (familyName, field, source string, family snapshotFamily, err error) {
+	familyName, field, _ = strings.Cut(strings.ToLower(ind.Type), "_")
+	family, ok := snapshotFamilies[familyName]
+	if !ok {
+		return "", "", "", family, fmt.Errorf("不支持的指标类型: %s", ind.Type)
+	}
+	if len(ind.Args) != family.args {
+		return "", "", "", family, fmt.Errorf("指标 %s 需要%d个参数，实际为%d个", ind.Type, family.args, len(ind.Args))
+	}
+	if info, err := Describe(familyName); err == nil {
+		if err := info.Validate(ind.Args...); err != nil {
+			return "", "", "", family, err
+		}
+	}
+	source = strings.ToLower(ind.Source)
+	if source == "" {
+		source = "close"
+	}
+	if !exprSources[source] {
+		return "", "", "", family, fmt.Errorf("未知数据源: %s", ind.Source)
+	}
+	return familyName, field, source, family, nil
+}
+
+type snapshotFamily struct {
+	args    int
+	compute func(k KlineDatas, prices []float64, args []int, factor float64) (map[string]float64, error)
+}
+
+// snapshotFamilies 指标类型到计算函数的映射，返回各字段最新值，单输出指标的字段名为空
+var snapshotFamilies = map[string]snapshotFamily{
+	"sma": {1, func(_ KlineDatas, prices []float64, args []int, _ float64) (map[string]float64, error) {
+		t, err := CalculateSMA(prices, args[0])
+		if err != nil {
+			return nil, err
+		}
+		return map[string]float64{"": t.Value()}, nil
+	}},
+	"ema": {1, func(_ KlineDatas, prices []float64, args []int, _ float64) (map[string]float64, error) {
+		t, err := CalculateEMA(prices, args[0])
+		if err != nil {
+			return nil, err
+		}
+		return map[string]float64{"": t.Value()}, nil
+	}},
+	"rma": {1, func(_ KlineDatas, prices []float64, args []int, _ float64) (map[string]float64, error) {
+		t, err := CalculateRMA(prices, args[0])
+		if err != nil {
+			return nil, err
+		}
+		return map[string]float64{"": t.Value()}, nil
+	}},
+	"rsi": {1, func(_ KlineDatas, prices []float64, args []int, _ float64) (map[string]float64, error) {
+		t, err := CalculateRSI(prices, args[0])
+		if err != nil {
+			return nil, err
+		}
+		return map[string]float64{"": t.Value()}, nil
+	}},
+	"atr": {1, func(k KlineDatas, _ []float64, args []int, _ float64) (map[string]float64, error) {
+		t, err := CalculateATR(k, args[0])
+		if err != nil {
+			return nil, err
+		}
+		return map[string]float64{"": t.Value()}, nil
+	}},
+	"cci": {1, func(k KlineDatas, _ []float64, args []int, _ float64) (map[string]float64, error) {
+		t, err := CalculateCCI(k, args[0])
+		if err != nil {
+			return nil, err
+		}
+		return map[string]float64{"": t.Value()}, nil
+	}},
+	"wr": {1, func(k KlineDatas, _ []float64, args []int, _ float64) (map[string]float64, error) {
+		t, err := k.WilliamsR(args[0])
+		if err != nil {
+			return nil, err
+		}
+		return map[string]float64{"": t.Value()}, nil
+	}},
+	"obv": {0, func(k KlineDatas, prices []float64, _ []int, _ float64) (map[string]float64, error) {
+		volumes, _ := k.ExtractSlice("volume")
+		t, err := CalculateOBV(prices, volumes)
+		if err != nil {
+			return nil, err
+		}
+		return map[string]float64{"": t.Value()}, nil
+	}},
+	"adx": {1, func(k KlineDatas, _ []float64, args []int, _ float64) (map[string]float64, error) {
+		t, err := CalculateADX(k, args[0])
+		if err != nil {
+			return nil, err
+		}
+		adx, plusDI, minusDI := t.Value()
+		return map[string]float64{"": adx, "plus_di": plusDI, "minus_di": minusDI}, nil
+	}},
+	"aroon": {1, func(k KlineDatas, _ []float64, args []int, _ float64) (map[string]float64, error) {
+		t, err := k.Aroon(args[0])
+		if err != nil {
+			return nil, err
+		}
+		up, down, oscillator := t.Value()
+		return map[string]float64{"up": up, "down": down, "oscillator": oscillator}, nil
+	}},
+	"boll": {2, func(_ KlineDatas, prices []float64, args []int, factor float64) (map[string]float64, error) {
+		t, err := CalculateBoll(prices, args[0], factor)
+		if err != nil {
+			return nil, err
+		}
+		upper, mid, lower := t.Value()
+		return map[string]float64{"upper": upper, "mid": mid, "lower": lower}, nil
+	}},
+	"atrbands": {3, func(k KlineDatas, _ []float64, args []int, factor float64) (map[string]float64, error) {
+		t, err := CalculateATRBands(k, args[0], args[1], factor, ATRBandsSMA)
+		if err != nil {
+			return nil, err
+		}
+		upper, mid, lower := t.Value()
+		return map[string]float64{"upper": upper, "mid": mid, "lower": lower}, nil
+	}},
+	"macd": {3, func(_ KlineDatas, prices []float64, args []int, _ float64) (map[string]float64, error) {
+		t, err := CalculateMACD(prices, args[0], args[1], args[2])
+		if err != nil {
+			return nil, err
+		}
+		macd, dif, dea := t.Value()
+		return map[string]float64{"": macd, "dif": dif, "dea": dea}, nil
+	}},
+	"kdj": {3, func(k KlineDatas, _ []float64, args []int, _ float64) (map[string]float64, error) {
+		t, err := k.KDJ(args[0], args[1], args[2])
+		if err != nil {
+			return nil, err
+		}
+		kValue, dValue, jValue := t.Value()
+		return map[string]float64{"k": kValue, "d": dValue, "j": jValue}, nil
+	}},
+	"stoch": {3, func(k KlineDatas, _ []float64, args []int, _ float64) (map[string]float64, error) {
+		t, err := k.Stoch(args[0], args[1], args[2])
+		if err != nil {
+			return nil, err
+		}
+		kValue, dValue := t.Value()
+		return map[string]float64{"k": kValue, "d": dValue}, nil
+	}},
+	"candlestats": {1, func(k KlineDatas, _ []float64, args []int, _ float64) (map[string]float64, error) {
+		t, err := CalculateCandleStats(k, args[0])
+		if err != nil {
+			return nil, err
+		}
+		last := len(t.Body) - 1
+		return map[string]float64{
+			"body": t.Body[last], "upper_wick": t.UpperWick[last],
+			"lower_wick": t.LowerWick[last], "close_position": t.ClosePosition[last],
+			"avg_body": t.AvgBody[last], "avg_upper_wick": t.AvgUpperWick[last],
+			"avg_lower_wick": t.AvgLowerWick[last], "avg_close_position": t.AvgClosePosition[last],
+		}, nil
+	}},
+	"supertrend": {2, func(k KlineDatas, _ []float64, args []int, factor float64) (map[string]float64, error) {
+		t, err := CalculateSuperTrend(k, args[0], factor)
+		if err != nil {
+			return nil, err
+		}
+		upper, lower, isUpTrend := t.Value()
+		dir := -1.0
+		if isUpTrend {
+			dir = 1
+		}
+		return map[string]float64{"dir": dir, "upper": upper, "lower": lower}, nil
+	}},
+}
+
+// Snapshot 一次性计算一组指标在最后一根 K 线上的值
+// 参数：
+//   - config: 快照配置
+//
+// 返回值：
+//   - map[string]float64: 指标名到最新值的映射
+//   - error: 指标类型、参数无效或数据不足时返回错误
+//
+// 说明/注意事项：
+//
+//	每个指标计算前先按 WarmupLength 检查数据量，不足时返回错误而不调用计算函数，所需数量可由 config.MinBars 预先得到。
+//	价格序列只提取一次，参数相同的多输出指标（如 boll_upper 与 boll_lower）只计算一次。
+//	带倍数参数的指标（boll、supertrend）最后一个参数为浮点倍数，其余参数取整数。
+//
+// 示例：
+//
+//	snap, err := klineData.Snapshot(SnapshotConfig{
+//	    Indicators: []SnapshotIndicator{
+//	        {Name: "rsi14", Type: "rsi", Args: []float64{14}},
+//	        {Name: "atr14", Type: "atr", Args: []float64{14}},
+//	        {Type: "supertrend_dir", Args: []float64{10, 3}},
+//	        {Type: "boll_upper", Args: []float64{20, 2}},
+//	    },
+//	    Window: 500,
+//	})
+func (k *KlineDatas) Snapshot(config SnapshotConfig) (map[string]float64, error) {
+	if len(*k) == 0 {
+		return nil, fmt.Errorf("没有K线数据")
+	}
+	klines := *k
+	if config.Window > 0 && config.Window < len(klines) {
+		klines = klines[len(klines)-config.Window:]
+	}
+
+	sources := make(map[string][]float64)
+	computed := make(map[string]map[string]float64)
+	result := make(map[string]float64, len(config.Indicators))
+
+	for _, ind := range config.Indicators {
+		name := ind.Name
+		if name == "" {
+			name = strings.ToLower(ind.Type)
+		}
+		familyName, field, source, family, err := resolveSnapshotIndicator(ind)
+		if err != nil {
+			return nil, err
+		}
+
+		key := fmt.Sprintf("%s|%s|%v", familyName, source, ind.Args)
+		values, ok := computed[key]
+		if !ok {
+			prices, ok := sources[source]
+			if !ok {
+				prices, _ = klines.ExtractSlice(source)
+				sources[source] = prices
+			}
+			args := snapshotIntArgs(ind.Args)
+			if need := WarmupLength(familyName, args...) + 1; len(klines) < need {
+				return nil, fmt.Errorf("计算 %s 失败: 数据不足，需要至少%d根K线，实际为%d根", name, need, len(klines))
+			}
+			var factor float64
+			if familyName == "boll" || familyName == "supertrend" || familyName == "atrbands" {
+				factor = ind.Args[len(ind.Args)-1]
+			}
+			values, err = family.compute(klines, prices, args, factor)
+			if err != nil {
+				return nil, fmt.Errorf("计算 %s 失败: %v", name, err)
+			}
+			computed[key] = values
+		}
+
+		value, ok := values[field]
+		if !ok {
+			return nil, fmt.Errorf("不支持的指标类型: %s", ind.Type)
+		}
+		result[name] = value
+	}
+	return result, nil
+}
+
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
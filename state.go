@@ -0,0 +1,214 @@
+package ta
+
+import (
+	"fmt"
+)
+
+// IndicatorState 增量指标的最小内部状态
+// 说明：
+//
+//	只保存继续调用 Update 所需的数据，可 JSON 序列化后在进程或机器之间迁移，
+//	新的工作进程恢复状态后即可继续处理实时行情，无需重放完整历史。
+//	恢复得到的指标只保留最新值，历史序列不会被还原。
+//
+// 字段：
+//   - Kind: 指标类型，如 "ema"、"rsi"、"atr"、"rolling"、"anomaly"
+//   - Period: 周期或窗口长度
+//   - Last: 最新输出值
+//   - Vars: 递归计算的中间量，含义由 Kind 决定
+//   - Window: 滚动窗口内的原始数据
+type IndicatorState struct {
+	Kind   string    `json:"kind"`
+	Period int       `json:"period"`
+	Last   []float64 `json:"last"`
+	Vars   []float64 `json:"vars,omitempty"`
+	Window []float64 `json:"window,omitempty"`
+}
+
+func (s IndicatorState) check(kind string, last, vars int) error {
+	if s.Kind != kind {
+		return fmt.Errorf("状态类型不匹配: 期望 %s，实际为 %s", kind, s.Kind)
+	}
+	if s.Period <= 0 || len(s.Last) != last || len(s.Vars) != vars {
+		return fmt.Errorf("无效的 %s 状态", kind)
+	}
+	return nil
+}
+
+// State 导出 EMA 的增量计算状态
+func (t *TaEMA) State() IndicatorState {
+	return IndicatorState{Kind: "ema", Period: t.Period, Last: []float64{t.Value()}}
+}
+
+// RestoreEMA 从状态恢复 EMA，恢复后可继续调用 Update
+func RestoreEMA(state IndicatorState) (*TaEMA, error) {
+	if err := state.check("ema", 1, 0); err != nil {
+		return nil, err
+	}
+	return &TaEMA{Values: []float64{state.Last[0]}, Period: state.Period}, nil
+}
+
+// State 导出 RSI 的增量计算状态，Vars 为平均涨幅、平均跌幅和前一个价格；
+// 增量状态未初始化时 Vars 为空，RestoreRSI 会拒绝该状态
+func (t *TaRSI) State() IndicatorState {
+	state := IndicatorState{Kind: "rsi", Period: t.Period, Last: []float64{t.Value()}}
+	if t.ready {
+		state.Vars = []float64{t.avgGain, t.avgLoss, t.prevPrice}
+	}
+	return state
+}
+
+// RestoreRSI 从状态恢复 RSI，恢复后可继续调用 Update
+func RestoreRSI(state IndicatorState) (*TaRSI, error) {
+	if err := state.check("rsi", 1, 3); err != nil {
+		return nil, err
+	}
+	return &TaRSI{
+		Values:    []float64{state.Last[0]},
+		Period:    state.Period,
+		Gains:     []float64{0},
+		Losses:    []float64{0},
+		avgGain:   state.Vars[0],
+		avgLoss:   state.Vars[1],
+		prevPrice: state.Vars[2],
+		ready:     true,
+	}, nil
+}
+
+// State 导出 ATR 的增量计算状态，Last 为平滑后的真实波幅，Vars 为前一根收盘价；
+// 增量状态未初始化时 Vars 为空，RestoreATR 会拒绝该状态
+func (t *TaATR) State() IndicatorState {
+	state := IndicatorState{Kind: "atr", Period: t.Period, Last: []float64{t.Value()}}
+	if t.ready {
+		state.Vars = []float64{t.prevClose}
+	}
+	return state
+}
+
+// RestoreATR 从状态恢复 ATR，恢复后可继续调用 Update
+func RestoreATR(state IndicatorState) (*TaATR, error) {
+	if err := state.check("atr", 1, 1); err != nil {
+		return nil, err
+	}
+	return &TaATR{
+		Values:    []float64{state.Last[0]},
+		Period:    state.Period,
+		TrueRange: []float64{0},
+		prevClose: state.Vars[0],
+		ready:     true,
+	}, nil
+}
+
+// State 导出滚动窗口统计的增量计算状态，Window 为窗口内的原始数据；
+// 增量状态未初始化时 Last 与 Window 为空，RestoreRolling 会拒绝该状态
+func (t *TaRolling) State() IndicatorState {
+	state := IndicatorState{Kind: "rolling", Period: t.Window}
+	if t.ready() && len(t.Values) > 0 {
+		state.Last = []float64{t.Value()}
+		state.Window = append([]float64(nil), t.buf[len(t.buf)-t.Window:]...)
+	}
+	return state
+}
+
+// RestoreRolling 从状态恢复滚动窗口统计
+// 参数：
+//   - state: State 导出的状态
+//   - fn: 窗口统计函数，函数无法序列化，需要与导出时一致
+//
+// 返回值：
+//   - *TaRolling: 恢复的统计，Values 长度为窗口长度，只有最后一个位置有效
+//   - error: 状态无效或统计函数为空时返回错误
+func RestoreRolling(state IndicatorState, fn func(window []float64) float64) (*TaRolling, error) {
+	if err := state.check("rolling", 1, 0); err != nil {
+		return nil, err
+	}
+	if fn == nil {
+		return nil, fmt.Errorf("统计函数不能为空")
+	}
+	if len(state.Window) != state.Period {
+		return nil, fmt.Errorf("无效的 rolling 状态")
+	}
+	values := make([]float64, state.Period)
+	values[state.Period-1] = state.Last[0]
+	buf := make([]float64, state.Period, state.Period*2)
+	copy(buf, state.Window)
+	return &TaRolling{Values: values, Window: state.Period, fn: fn, buf: buf}, nil
+}
+
+// State 导出多输出滚动窗口统计的增量计算状态，增量状态未初始化时 Last 与 Window 为空，RestoreRollingMulti 会拒绝该状态
+func (t *TaRollingMulti) State() IndicatorState {
+	state := IndicatorState{Kind: "rolling_multi", Period: t.Window}
+	if t.ready() && len(t.Values[0]) > 0 {
+		state.Last = t.Value()
+		state.Window = append([]float64(nil), t.buf[len(t.buf)-t.Window:]...)
+	}
+	return state
+}
+
+// RestoreRollingMulti 从状态恢复多输出滚动窗口统计
+// 参数：
+//   - state: State 导出的状态
+//   - fn: 窗口统计函数，需要与导出时一致
+//
+// 返回值：
+//   - *TaRollingMulti: 恢复的统计，各输出只有最后一个位置有效
+//   - error: 状态无效或统计函数为空时返回错误
+func RestoreRollingMulti(state IndicatorState, fn func(window []float64) []float64) (*TaRollingMulti, error) {
+	if err := state.check("rolling_multi", len(state.Last), 0); err != nil {
+		return nil, err
+	}
+	if fn == nil {
+		return nil, fmt.Errorf("统计函数不能为空")
+	}
+	if len(state.Last) == 0 || len(state.Window) != state.Period {
+		return nil, fmt.Errorf("无效的 rolling_multi 状态")
+	}
+	values := preallocateSlices(state.Period, len(state.Last))
+	for j, v := range state.Last {
+		values[j][state.Period-1] = v
+	}
+	buf := make([]float64, state.Period, state.Period*2)
+	copy(buf, state.Window)
+	return &TaRollingMulti{Values: values, Window: state.Period, fn: fn, buf: buf}, nil
+}
+
+// State 导出异常检测器的状态，Vars 为平滑系数、判定阈值、并入基准的读数数量、EWMA 均值、方差和已输入的读数数量
+func (d *AnomalyDetector) State() IndicatorState {
+	return IndicatorState{
+		Kind:   "anomaly",
+		Period: d.Window,
+		Last:   []float64{},
+		Vars:   []float64{d.Alpha, d.Threshold, float64(d.count), d.mean, d.variance, float64(d.index)},
+		Window: append([]float64(nil), d.buf...),
+	}
+}
+
+// RestoreAnomalyDetector 从状态恢复异常检测器，OnAnomaly 回调需要重新设置
+func RestoreAnomalyDetector(state IndicatorState) (*AnomalyDetector, error) {
+	if err := state.check("anomaly", 0, 6); err != nil {
+		return nil, err
+	}
+	if len(state.Window) > state.Period {
+		return nil, fmt.Errorf("无效的 anomaly 状态")
+	}
+	d := &AnomalyDetector{
+		Window:    state.Period,
+		Alpha:     state.Vars[0],
+		Threshold: state.Vars[1],
+		count:     int(state.Vars[2]),
+		mean:      state.Vars[3],
+		variance:  state.Vars[4],
+		index:     int(state.Vars[5]),
+	}
+	if d.Alpha == 0 {
+		d.buf = make([]float64, len(state.Window), state.Period)
+		copy(d.buf, state.Window)
+	}
+	return d, nil
+}
+
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
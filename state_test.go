@@ -0,0 +1,111 @@
+package ta
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+)
+
+func stateTestPrices() []float64 {
+	prices := make([]float64, 60)
+	for i := range prices {
+		prices[i] = 100 + 10*math.Sin(float64(i)/3)
+	}
+	return prices
+}
+
+func TestRestoreRSIUpdate(t *testing.T) {
+	prices := stateTestPrices()
+	full, err := CalculateRSI(prices, 14)
+	if err != nil {
+		t.Fatal(err)
+	}
+	partial, err := CalculateRSI(prices[:50], 14)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := json.Marshal(partial.State())
+	if err != nil {
+		t.Fatal(err)
+	}
+	var state IndicatorState
+	if err := json.Unmarshal(data, &state); err != nil {
+		t.Fatal(err)
+	}
+	restored, err := RestoreRSI(state)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 50; i < len(prices); i++ {
+		got, err := restored.Update(prices[i])
+		if err != nil {
+			t.Fatal(err)
+		}
+		if math.Abs(got-full.Values[i]) > 1e-9 {
+			t.Errorf("位置 %d = %v, want %v", i, got, full.Values[i])
+		}
+	}
+}
+
+func TestUpdateUninitialised(t *testing.T) {
+	// 从 JSON 解码或手工构造的结果缺少增量状态，Update 应返回错误而不是错误的值或 panic
+	var rsi TaRSI
+	if err := json.Unmarshal([]byte(`{"values":[50],"period":14}`), &rsi); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := rsi.Update(1); err == nil {
+		t.Error("TaRSI.Update 应返回错误")
+	}
+	if _, err := RestoreRSI(rsi.State()); err == nil {
+		t.Error("RestoreRSI 应拒绝未初始化的状态")
+	}
+
+	atr := &TaATR{Values: []float64{1}, Period: 14}
+	if _, err := atr.Update(2, 1, 1.5); err == nil {
+		t.Error("TaATR.Update 应返回错误")
+	}
+
+	var rolling TaRolling
+	if _, err := rolling.Update(1); err == nil {
+		t.Error("TaRolling.Update 应返回错误")
+	}
+	if _, err := RestoreRolling(rolling.State(), func(w []float64) float64 { return w[0] }); err == nil {
+		t.Error("RestoreRolling 应拒绝未初始化的状态")
+	}
+
+	var multi TaRollingMulti
+	if _, err := multi.Update(1); err == nil {
+		t.Error("TaRollingMulti.Update 应返回错误")
+	}
+	if _, err := RestoreRollingMulti(multi.State(), func(w []float64) []float64 { return w }); err == nil {
+		t.Error("RestoreRollingMulti 应拒绝未初始化的状态")
+	}
+}
+
+func TestRestoreRollingNilFunc(t *testing.T) {
+	sum := func(w []float64) float64 {
+		var s float64
+		for _, v := range w {
+			s += v
+		}
+		return s
+	}
+	rolling, err := Rolling([]float64{1, 2, 3, 4}, 3, sum)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := RestoreRolling(rolling.State(), nil); err == nil {
+		t.Error("RestoreRolling 应拒绝空的统计函数")
+	}
+	if _, err := Rolling([]float64{1, 2, 3}, 3, nil); err == nil {
+		t.Error("Rolling 应拒绝空的统计函数")
+	}
+
+	restored, err := RestoreRolling(rolling.State(), sum)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, err := restored.Update(5); err != nil || got != 12 {
+		t.Errorf("Update(5) = %v, %v, want 12, nil", got, err)
+	}
+}
@@ -0,0 +1,179 @@
+package ta
+
+import (
+	"fmt"
+	"math"
+)
+
+// TaStdErr 均线估计值及其标准误差带
+// 说明：
+//
+//	标准误差衡量均线作为“当前价格水平”估计的不确定度，
+//	可区分“样本少导致的平坦”与“高度确定的平坦”。
+//
+// 字段：
+//   - Values: 均线值
+//   - StdErr: 每个时间点的标准误差
+//   - Upper: 均线 + Z × 标准误差
+//   - Lower: 均线 − Z × 标准误差
+//   - Period: 计算周期
+//   - Z: 标准误差倍数（1.96 约对应 95% 置信区间）
+type TaStdErr struct {
+	Values []float64 `json:"values"`
+	StdErr []float64 `json:"std_err"`
+	Upper  []float64 `json:"upper"`
+	Lower  []float64 `json:"lower"`
+	Period int       `json:"period"`
+	Z      float64   `json:"z"`
+}
+
+// CalculateSMAStdErr 计算 SMA 及其标准误差带
+// 参数：
+//   - prices: 价格序列
+//   - period: 计算周期，必须不小于2
+//   - z: 标准误差倍数
+//
+// 返回值：
+//   - *TaStdErr: 计算结果
+//   - error: 数据不足或周期无效时返回错误
+//
+// 说明/注意事项：
+//
+//	标准误差 = 窗口样本标准差 / √period。
+func CalculateSMAStdErr(prices []float64, period int, z float64) (*TaStdErr, error) {
+	if period < 2 {
+		return nil, fmt.Errorf("周期必须不小于2")
+	}
+	sma, err := CalculateSMA(prices, period)
+	if err != nil {
+		return nil, err
+	}
+
+	length := len(prices)
+	slices := preallocateSlices(length, 3)
+	stdErr, upper, lower := slices[0], slices[1], slices[2]
+
+	for i := period - 1; i < length; i++ {
+		var sumSquares float64
+		for j := i - period + 1; j <= i; j++ {
+			diff := prices[j] - sma.Values[i]
+			sumSquares += diff * diff
+		}
+		sd := math.Sqrt(sumSquares / float64(period-1))
+		stdErr[i] = sd / math.Sqrt(float64(period))
+		upper[i] = sma.Values[i] + z*stdErr[i]
+		lower[i] = sma.Values[i] - z*stdErr[i]
+	}
+
+	return &TaStdErr{
+		Values: sma.Values,
+		StdErr: stdErr,
+		Upper:  upper,
+		Lower:  lower,
+		Period: period,
+		Z:      z,
+	}, nil
+}
+
+// CalculateEMAStdErr 计算 EMA 及其标准误差带
+// 参数：
+//   - prices: 价格序列
+//   - period: 计算周期，必须不小于2
+//   - z: 标准误差倍数
+//
+// 返回值：
+//   - *TaStdErr: 计算结果
+//   - error: 数据不足或周期无效时返回错误
+//
+// 说明/注意事项：
+//
+//	使用指数加权方差 σ²，EMA 的标准误差 = σ × √(α / (2 − α))，α = 2/(period+1)。
+//	初始方差取前 period 个价格的样本方差，与 CalculateEMA 的 SMA 起点一致。
+func CalculateEMAStdErr(prices []float64, period int, z float64) (*TaStdErr, error) {
+	if period < 2 {
+		return nil, fmt.Errorf("周期必须不小于2")
+	}
+	ema, err := CalculateEMA(prices, period)
+	if err != nil {
+		return nil, err
+	}
+
+	length := len(prices)
+	slices := preallocateSlices(length, 3)
+	stdErr, upper, lower := slices[0], slices[1], slices[2]
+
+	alpha := 2.0 / float64(period+1)
+	factor := math.Sqrt(alpha / (2 - alpha))
+
+	var variance float64
+	for i := 0; i < period; i++ {
+		diff := prices[i] - ema.Values[period-1]
+		variance += diff * diff
+	}
+	variance /= float64(period - 1)
+
+	for i := period - 1; i < length; i++ {
+		if i >= period {
+			diff := prices[i] - ema.Values[i-1]
+			variance = (1 - alpha) * (variance + alpha*diff*diff)
+		}
+		stdErr[i] = math.Sqrt(variance) * factor
+		upper[i] = ema.Values[i] + z*stdErr[i]
+		lower[i] = ema.Values[i] - z*stdErr[i]
+	}
+
+	return &TaStdErr{
+		Values: ema.Values,
+		StdErr: stdErr,
+		Upper:  upper,
+		Lower:  lower,
+		Period: period,
+		Z:      z,
+	}, nil
+}
+
+// SMAStdErr 从 KlineDatas 中提取数据计算 SMA 及其标准误差带
+func (k *KlineDatas) SMAStdErr(period int, z float64, source string) (*TaStdErr, error) {
+	prices, err := k.ExtractSlice(source)
+	if err != nil {
+		return nil, err
+	}
+	return CalculateSMAStdErr(prices, period, z)
+}
+
+// EMAStdErr 从 KlineDatas 中提取数据计算 EMA 及其标准误差带
+func (k *KlineDatas) EMAStdErr(period int, z float64, source string) (*TaStdErr, error) {
+	prices, err := k.ExtractSlice(source)
+	if err != nil {
+		return nil, err
+	}
+	return CalculateEMAStdErr(prices, period, z)
+}
+
+// Value 返回最新的均线值及上下误差带
+func (t *TaStdErr) Value() (value, upper, lower float64) {
+	lastIndex := len(t.Values) - 1
+	return t.Values[lastIndex], t.Upper[lastIndex], t.Lower[lastIndex]
+}
+
+// IsSignificantChange 判断均线在 n 根 K 线内的变化是否超出误差带
+// 参数：
+//   - n: 回看数量
+//
+// 返回值：
+//   - bool: |MA[i] − MA[i−n]| 大于 Z 倍合成标准误差时返回 true，数据不足时返回 false
+func (t *TaStdErr) IsSignificantChange(n int) bool {
+	lastIndex := len(t.Values) - 1
+	prev := lastIndex - n
+	if n <= 0 || prev < t.Period-1 {
+		return false
+	}
+	se := math.Sqrt(t.StdErr[lastIndex]*t.StdErr[lastIndex] + t.StdErr[prev]*t.StdErr[prev])
+	return math.Abs(t.Values[lastIndex]-t.Values[prev]) > t.Z*se
+}
+
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
@@ -0,0 +1,221 @@
+package ta
+
+import (
+	"fmt"
+)
+
+// 随机指标的计算方式
+const (
+	// StochFast 快速随机指标：%K 为原始 RSV，不做平滑，%D 为 %K 的 dPeriod 简单均线
+	StochFast = iota
+	// StochSlow 慢速随机指标：%K 为原始 RSV 的 3 周期简单均线，%D 为 %K 的 dPeriod 简单均线
+	StochSlow
+	// StochFull 完全随机指标：%K 为原始 RSV 的 slowing 周期简单均线，%D 为 %K 的 dPeriod 简单均线
+	StochFull
+)
+
+// TaStoch 随机指标（Stochastic Oscillator）的计算结果
+// 字段：
+//   - K: %K 线，取值 0 到 100，前 KPeriod+Slowing-2 个位置为 0
+//   - D: %D 线，前 KPeriod+Slowing+DPeriod-3 个位置为 0
+//   - KPeriod: 最高价/最低价的回看周期
+//   - Slowing: %K 的平滑周期，快速模式为 1，慢速模式为 3
+//   - DPeriod: %D 的平滑周期
+//   - Mode: 计算方式，StochFast、StochSlow 或 StochFull
+type TaStoch struct {
+	K       []float64 `json:"k"`
+	D       []float64 `json:"d"`
+	KPeriod int       `json:"k_period"`
+	Slowing int       `json:"slowing"`
+	DPeriod int       `json:"d_period"`
+	Mode    int       `json:"mode"`
+}
+
+// CalculateStoch 计算随机指标
+// 参数：
+//   - high: 最高价数组
+//   - low: 最低价数组
+//   - close: 收盘价数组
+//   - kPeriod: 最高价/最低价的回看周期，如 14
+//   - slowing: %K 的平滑周期，仅 StochFull 使用，如 3
+//   - dPeriod: %D 的平滑周期，如 3
+//   - mode: 计算方式，StochFast、StochSlow 或 StochFull
+//
+// 返回值：
+//   - *TaStoch: 随机指标结果，区间最高价等于最低价时原始 RSV 取 50
+//   - error: 参数无效或数据不足时返回错误
+//
+// 说明/注意事项：
+//
+//	与 KDJ 不同，%K 与 %D 均使用简单均线平滑，结果与常见行情软件的 Stochastic(14, 3, 3) 一致。
+//
+// 示例：
+//
+//	stoch, err := CalculateStoch(high, low, close, 14, 3, 3, StochFull)
+//	if err != nil {
+//	    // 处理错误
+//	}
+//	if stoch.IsBullishCross() && stoch.IsOversold(20) {
+//	    // 超卖区金叉
+//	}
+func CalculateStoch(high, low, close []float64, kPeriod, slowing, dPeriod, mode int) (*TaStoch, error) {
+	switch mode {
+	case StochFast:
+		slowing = 1
+	case StochSlow:
+		slowing = 3
+	case StochFull:
+	default:
+		return nil, fmt.Errorf("无效的计算方式: %d", mode)
+	}
+	if kPeriod <= 0 || slowing <= 0 || dPeriod <= 0 {
+		return nil, fmt.Errorf("周期必须大于0")
+	}
+	length := len(close)
+	if len(high) != length || len(low) != length {
+		return nil, fmt.Errorf("输入数据长度不一致")
+	}
+	if length < kPeriod+slowing+dPeriod-2 {
+		return nil, fmt.Errorf("计算数据不足")
+	}
+
+	slices := preallocateSlices(length, 3)
+	raw, k, d := slices[0], slices[1], slices[2]
+
+	for i := kPeriod - 1; i < length; i++ {
+		highestHigh, lowestLow := high[i], low[i]
+		for j := i - kPeriod + 1; j < i; j++ {
+			if high[j] > highestHigh {
+				highestHigh = high[j]
+			}
+			if low[j] < lowestLow {
+				lowestLow = low[j]
+			}
+		}
+		if highestHigh != lowestLow {
+			raw[i] = (close[i] - lowestLow) / (highestHigh - lowestLow) * 100
+		} else {
+			raw[i] = 50
+		}
+	}
+
+	kStart := kPeriod + slowing - 2
+	var sumK float64
+	for i := kPeriod - 1; i < length; i++ {
+		sumK += raw[i]
+		if i-slowing >= kPeriod-1 {
+			sumK -= raw[i-slowing]
+		}
+		if i >= kStart {
+			k[i] = sumK / float64(slowing)
+		}
+	}
+
+	dStart := kStart + dPeriod - 1
+	var sumD float64
+	for i := kStart; i < length; i++ {
+		sumD += k[i]
+		if i-dPeriod >= kStart {
+			sumD -= k[i-dPeriod]
+		}
+		if i >= dStart {
+			d[i] = sumD / float64(dPeriod)
+		}
+	}
+
+	return &TaStoch{
+		K:       k,
+		D:       d,
+		KPeriod: kPeriod,
+		Slowing: slowing,
+		DPeriod: dPeriod,
+		Mode:    mode,
+	}, nil
+}
+
+// Stoch 从 KlineDatas 中提取数据并计算完全随机指标（StochFull）
+// 参数：
+//   - kPeriod: 最高价/最低价的回看周期
+//   - slowing: %K 的平滑周期，传 1 即为快速随机指标
+//   - dPeriod: %D 的平滑周期
+//
+// 返回值：
+//   - *TaStoch: 随机指标结果
+//   - error: 提取数据或计算过程中的错误
+//
+// 示例：
+//
+//	stoch, err := klineData.Stoch(14, 3, 3)
+func (k *KlineDatas) Stoch(kPeriod, slowing, dPeriod int) (*TaStoch, error) {
+	high, err := k.ExtractSlice("high")
+	if err != nil {
+		return nil, err
+	}
+	low, err := k.ExtractSlice("low")
+	if err != nil {
+		return nil, err
+	}
+	close, err := k.ExtractSlice("close")
+	if err != nil {
+		return nil, err
+	}
+	return CalculateStoch(high, low, close, kPeriod, slowing, dPeriod, StochFull)
+}
+
+// Stoch_ 计算并返回最新的 %K 和 %D 值，数据不足时返回 0
+func (k *KlineDatas) Stoch_(kPeriod, slowing, dPeriod int) (kValue, dValue float64) {
+	_k, err := k.Keep(quickKeep("stoch", kPeriod, slowing, dPeriod))
+	if err != nil {
+		_k = *k
+	}
+	stoch, err := _k.Stoch(kPeriod, slowing, dPeriod)
+	if err != nil {
+		return 0, 0
+	}
+	return stoch.Value()
+}
+
+// Value 返回最新的 %K 和 %D 值
+func (t *TaStoch) Value() (kValue, dValue float64) {
+	lastIndex := len(t.K) - 1
+	return t.K[lastIndex], t.D[lastIndex]
+}
+
+// IsBullishCross 判断最新一根K线 %K 是否上穿 %D
+func (t *TaStoch) IsBullishCross() bool {
+	lastIndex := len(t.K) - 1
+	if lastIndex-1 < t.KPeriod+t.Slowing+t.DPeriod-3 {
+		return false
+	}
+	return CrossOver(t.K, t.D, lastIndex)
+}
+
+// IsBearishCross 判断最新一根K线 %K 是否下穿 %D
+func (t *TaStoch) IsBearishCross() bool {
+	lastIndex := len(t.K) - 1
+	if lastIndex-1 < t.KPeriod+t.Slowing+t.DPeriod-3 {
+		return false
+	}
+	return CrossUnder(t.K, t.D, lastIndex)
+}
+
+// IsOverbought 判断最新的 %K 和 %D 是否都高于 level，常用 80
+func (t *TaStoch) IsOverbought(level float64) bool {
+	kValue, dValue := t.Value()
+	return kValue > level && dValue > level
+}
+
+// IsOversold 判断最新的 %K 和 %D 是否都低于 level，常用 20；预热期内返回 false
+func (t *TaStoch) IsOversold(level float64) bool {
+	if len(t.K)-1 < t.KPeriod+t.Slowing+t.DPeriod-3 {
+		return false
+	}
+	kValue, dValue := t.Value()
+	return kValue < level && dValue < level
+}
+
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
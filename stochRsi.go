@@ -14,7 +14,7 @@ type TaStochRSI struct {
 }
 
 func CalculateStochRSI(prices []float64, rsiPeriod, stochPeriod, kPeriod, dPeriod int) (*TaStochRSI, error) {
-	if len(prices) < rsiPeriod+stochPeriod {
+	if len(prices) < rsiPeriod+stochPeriod || len(prices) < kPeriod || len(prices) < dPeriod {
 		return nil, fmt.Errorf("计算数据不足")
 	}
 
@@ -88,6 +88,19 @@ func (k *KlineDatas) StochRSI(rsiPeriod, stochPeriod, kPeriod, dPeriod int, sour
 	return CalculateStochRSI(prices, rsiPeriod, stochPeriod, kPeriod, dPeriod)
 }
 
+// StochRSI_ 计算并返回最新的 K 值和 D 值，数据不足时返回 0
+func (k *KlineDatas) StochRSI_(rsiPeriod, stochPeriod, kPeriod, dPeriod int, source string) (kValue, dValue float64) {
+	_k, err := k.Keep(quickKeep("stochrsi", rsiPeriod, stochPeriod, kPeriod, dPeriod))
+	if err != nil {
+		_k = *k
+	}
+	stochRsi, err := _k.StochRSI(rsiPeriod, stochPeriod, kPeriod, dPeriod, source)
+	if err != nil {
+		return 0, 0
+	}
+	return stochRsi.Value()
+}
+
 func (t *TaStochRSI) Value() (kValue, dValue float64) {
 	lastIndex := len(t.K) - 1
 	return t.K[lastIndex], t.D[lastIndex]
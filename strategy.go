@@ -0,0 +1,344 @@
+package ta
+
+import (
+	"fmt"
+	"math"
+)
+
+// Strategy 可在回测、实时运行和参数寻优之间复用的策略
+// 说明：
+//
+//	运行器在开始时调用一次 OnInit，之后每根已收盘 K 线调用一次 OnBar，
+//	持仓变化成交后调用 OnTrade，结束时调用 OnStop。策略通过 StrategyContext 读取 K 线与指标、设置目标持仓，
+//	不直接接触回测账户或券商接口，因此同一个策略可以交给 Backtest、StrategyBacktester、StrategyObjective 或实时运行器。
+//	任一回调返回错误时运行器停止并返回该错误。不需要的回调可通过嵌入 BaseStrategy 省略。
+type Strategy interface {
+	OnInit(ctx *StrategyContext) error
+	OnBar(ctx *StrategyContext) error
+	OnTrade(ctx *StrategyContext, fill Fill) error
+	OnStop(ctx *StrategyContext) error
+}
+
+// BaseStrategy 所有回调均为空操作的策略，嵌入后只需实现关心的回调
+type BaseStrategy struct{}
+
+// OnInit 空操作
+func (BaseStrategy) OnInit(*StrategyContext) error { return nil }
+
+// OnBar 空操作
+func (BaseStrategy) OnBar(*StrategyContext) error { return nil }
+
+// OnTrade 空操作
+func (BaseStrategy) OnTrade(*StrategyContext, Fill) error { return nil }
+
+// OnStop 空操作
+func (BaseStrategy) OnStop(*StrategyContext) error { return nil }
+
+// Fill 一笔成交
+// 字段：
+//   - Symbol: 交易对
+//   - Time: 成交时间（毫秒），回测中为成交 K 线的开始时间
+//   - Quantity: 成交数量，正数为买入，负数为卖出
+//   - Price: 成交均价
+//   - Fee: 手续费
+type Fill struct {
+	Symbol   string  `json:"symbol"`
+	Time     int64   `json:"time"`
+	Quantity float64 `json:"quantity"`
+	Price    float64 `json:"price"`
+	Fee      float64 `json:"fee"`
+}
+
+// StrategyContext 策略回调的上下文
+// 说明：
+//
+//	每根 K 线创建一个新的上下文，同一根 K 线内多次读取同一指标只计算一次。
+//	指标在 Klines 上计算，Klines 只包含截至当前 K 线的数据，不含未来数据。
+//
+// 字段：
+//   - Symbol: 交易对
+//   - Klines: 截至当前 K 线的数据，长度受运行器的窗口限制
+//   - Values: 实时运行时引擎的指标快照，回测中为空
+//   - Position: 当前持仓数量，正数为多头，负数为空头
+//   - Equity: 当前权益
+type StrategyContext struct {
+	Symbol   string
+	Klines   KlineDatas
+	Values   map[string]float64
+	Position float64
+	Equity   float64
+
+	cache  map[string]any
+	target *float64
+}
+
+// newStrategyContext 创建一根 K 线的上下文
+func newStrategyContext(symbol string, klines KlineDatas, position, equity float64) *StrategyContext {
+	return &StrategyContext{
+		Symbol:   symbol,
+		Klines:   klines,
+		Position: position,
+		Equity:   equity,
+		cache:    make(map[string]any),
+	}
+}
+
+// Close 返回当前 K 线的收盘价，没有数据时返回 0
+func (c *StrategyContext) Close() float64 {
+	if len(c.Klines) == 0 {
+		return 0
+	}
+	return c.Klines[len(c.Klines)-1].Close
+}
+
+// Indicator 按键缓存指标，同一根 K 线内只在第一次读取时计算
+// 参数：
+//   - key: 缓存键，应包含指标名称和参数，如 "ema:20:close"
+//   - compute: 在 Klines 上计算指标的函数
+//
+// 返回值：
+//   - any: compute 的结果
+//   - error: compute 返回的错误，错误同样会被缓存
+//
+// 示例：
+//
+//	v, err := ctx.Indicator("st:10:3", func(k KlineDatas) (any, error) { return k.SuperTrend(10, 3) })
+//	st := v.(*TaSuperTrend)
+func (c *StrategyContext) Indicator(key string, compute func(klines KlineDatas) (any, error)) (any, error) {
+	if c.cache == nil {
+		c.cache = make(map[string]any)
+	}
+	if v, ok := c.cache[key]; ok {
+		if err, isErr := v.(error); isErr {
+			return nil, err
+		}
+		return v, nil
+	}
+	v, err := compute(c.Klines)
+	if err != nil {
+		c.cache[key] = err
+		return nil, err
+	}
+	c.cache[key] = v
+	return v, nil
+}
+
+// EMA 返回缓存的 EMA，参数含义同 KlineDatas.EMA
+func (c *StrategyContext) EMA(period int, source string) (*TaEMA, error) {
+	v, err := c.Indicator(fmt.Sprintf("ema:%d:%s", period, source), func(k KlineDatas) (any, error) {
+		return k.EMA(period, source)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return v.(*TaEMA), nil
+}
+
+// RSI 返回缓存的 RSI，参数含义同 KlineDatas.RSI
+func (c *StrategyContext) RSI(period int, source string) (*TaRSI, error) {
+	v, err := c.Indicator(fmt.Sprintf("rsi:%d:%s", period, source), func(k KlineDatas) (any, error) {
+		return k.RSI(period, source)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return v.(*TaRSI), nil
+}
+
+// ATR 返回缓存的 ATR，参数含义同 KlineDatas.ATR
+func (c *StrategyContext) ATR(period int) (*TaATR, error) {
+	v, err := c.Indicator(fmt.Sprintf("atr:%d", period), func(k KlineDatas) (any, error) {
+		return k.ATR(period)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return v.(*TaATR), nil
+}
+
+// Target 设置目标持仓数量，正数为多头，负数为空头，0 为平仓；同一根 K 线内以最后一次设置为准
+func (c *StrategyContext) Target(qty float64) {
+	c.target = &qty
+}
+
+// Signal 按方向和权益比例设置目标持仓，参数含义同 PaperTrader.Signal，以当前收盘价换算数量
+func (c *StrategyContext) Signal(side int, fraction float64) {
+	price := c.Close()
+	if price <= 0 {
+		return
+	}
+	c.Target(float64(side) * fraction * c.Equity / price)
+}
+
+// pendingTarget 返回本根 K 线设置的目标持仓，没有设置时返回 false
+func (c *StrategyContext) pendingTarget() (float64, bool) {
+	if c.target == nil {
+		return 0, false
+	}
+	return *c.target, true
+}
+
+// TaBacktest 策略回测结果
+// 字段：
+//   - Result: 收益率、最大回撤和成交次数，可直接用于 StressTest
+//   - Equity: 每根 K 线收盘时的权益，预热期为初始权益
+//   - Fills: 所有成交
+type TaBacktest struct {
+	Result BacktestResult `json:"result"`
+	Equity []float64      `json:"equity"`
+	Fills  []Fill         `json:"fills"`
+}
+
+// Backtest 在历史 K 线上逐根运行策略
+// 参数：
+//   - strategy: 策略
+//   - symbol: 交易对
+//   - klineData: 已收盘的历史 K 线
+//   - trader: 模拟盘账户，提供初始权益与交易成本，回测过程中会被修改
+//   - window: 传给策略的最大 K 线数量，0 表示使用截至当前的全部 K 线
+//
+// 返回值：
+//   - *TaBacktest: 回测结果
+//   - error: 参数无效或策略回调返回错误时返回错误
+//
+// 说明/注意事项：
+//
+//	第 i 根 K 线收盘后调用 OnBar，设置的目标持仓在第 i+1 根 K 线的开盘价成交，避免以信号 K 线的收盘价成交；
+//	最后一根 K 线上设置的目标持仓不会成交。每根 K 线的上下文重新计算指标，window 越大回测越慢，
+//	建议按 DefaultKeepPolicy 设置为指标所需长度。
+//
+// 示例：
+//
+//	paper, _ := NewPaperTrader(10000, TradingCosts{FeeRate: 0.0004})
+//	bt, err := Backtest(&myStrategy{}, "BTCUSDT", klineData, paper, 500)
+//	fmt.Println(bt.Result.PnL, bt.Result.MaxDrawdown)
+func Backtest(strategy Strategy, symbol string, klineData KlineDatas, trader *PaperTrader, window int) (*TaBacktest, error) {
+	if strategy == nil || trader == nil {
+		return nil, fmt.Errorf("策略和模拟盘账户不能为空")
+	}
+	if window < 0 {
+		return nil, fmt.Errorf("窗口长度不能为负数")
+	}
+	if len(klineData) == 0 {
+		return nil, fmt.Errorf("没有K线数据")
+	}
+
+	slice := func(i int) KlineDatas {
+		start := 0
+		if window > 0 && i+1 > window {
+			start = i + 1 - window
+		}
+		return klineData[start : i+1]
+	}
+
+	result := &TaBacktest{Equity: make([]float64, len(klineData))}
+	first := newStrategyContext(symbol, slice(0), trader.Position, trader.Equity())
+	if err := strategy.OnInit(first); err != nil {
+		return nil, err
+	}
+
+	var pending *float64
+	for i, kline := range klineData {
+		if pending != nil {
+			ctx := newStrategyContext(symbol, slice(i-1), trader.Position, trader.Equity())
+			fill, err := paperFill(trader, symbol, kline.StartTime, *pending, kline.Open)
+			if err != nil {
+				return nil, err
+			}
+			pending = nil
+			if fill != nil {
+				result.Fills = append(result.Fills, *fill)
+				if err := strategy.OnTrade(ctx, *fill); err != nil {
+					return nil, err
+				}
+			}
+		}
+
+		trader.Mark(kline.Close)
+		result.Equity[i] = trader.Equity()
+		ctx := newStrategyContext(symbol, slice(i), trader.Position, result.Equity[i])
+		if err := strategy.OnBar(ctx); err != nil {
+			return nil, err
+		}
+		if qty, ok := ctx.pendingTarget(); ok {
+			pending = &qty
+		}
+	}
+
+	last := newStrategyContext(symbol, slice(len(klineData)-1), trader.Position, trader.Equity())
+	if err := strategy.OnStop(last); err != nil {
+		return nil, err
+	}
+
+	result.Result = BacktestResult{
+		PnL:         result.Equity[len(result.Equity)-1]/trader.InitialEquity - 1,
+		MaxDrawdown: maxDrawdown(result.Equity),
+		Trades:      len(result.Fills),
+	}
+	return result, nil
+}
+
+// paperFill 在模拟盘上将持仓调整到目标数量，持仓不变时返回 nil
+func paperFill(trader *PaperTrader, symbol string, at int64, target, price float64) (*Fill, error) {
+	delta := target - trader.Position
+	if delta == 0 || math.IsNaN(delta) {
+		return nil, nil
+	}
+	fees := trader.Fees
+	if err := trader.Target(target, price); err != nil {
+		return nil, err
+	}
+	return &Fill{Symbol: symbol, Time: at, Quantity: delta, Price: trader.fillPrice(delta, price), Fee: trader.Fees - fees}, nil
+}
+
+// StrategyBacktester 返回可传给 StressTest 的回测函数
+// 参数：
+//   - factory: 每次回测创建新策略实例的函数，避免不同情景之间共享策略状态
+//   - symbol/window: 同 Backtest
+//   - equity: 初始权益
+//   - costs: 交易成本
+//
+// 示例：
+//
+//	backtest := StrategyBacktester(func() Strategy { return &myStrategy{} }, "BTCUSDT", 500, 10000, costs)
+//	base, reports, err := klineData.StressTest(DefaultStressScenarios(-100, 50), backtest)
+func StrategyBacktester(factory func() Strategy, symbol string, window int, equity float64, costs TradingCosts) func(klines KlineDatas) (BacktestResult, error) {
+	return func(klines KlineDatas) (BacktestResult, error) {
+		trader, err := NewPaperTrader(equity, costs)
+		if err != nil {
+			return BacktestResult{}, err
+		}
+		bt, err := Backtest(factory(), symbol, klines, trader, window)
+		if err != nil {
+			return BacktestResult{}, err
+		}
+		return bt.Result, nil
+	}
+}
+
+// StrategyObjective 返回以策略回测收益率为得分的寻优目标函数，可传给 AutoTune
+// 参数：
+//   - factory: 按周期创建策略的函数
+//   - window/equity/costs: 同 StrategyBacktester
+//
+// 示例：
+//
+//	tune, err := AutoTune(klineData, 10, 50, StrategyObjective(func(period int) Strategy {
+//	    return &emaCross{period: period}
+//	}, 200, 10000, costs))
+func StrategyObjective(factory func(period int) Strategy, window int, equity float64, costs TradingCosts) TuneObjective {
+	return func(klineData KlineDatas, period int) (float64, error) {
+		backtest := StrategyBacktester(func() Strategy { return factory(period) }, "", window, equity, costs)
+		result, err := backtest(klineData)
+		if err != nil {
+			return 0, err
+		}
+		return result.PnL, nil
+	}
+}
+
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
@@ -0,0 +1,179 @@
+package ta
+
+import (
+	"math"
+	"testing"
+)
+
+func strategyTestKlines(prices []float64) KlineDatas {
+	klineData := make(KlineDatas, len(prices))
+	for i, c := range prices {
+		klineData[i] = &KlineData{StartTime: int64(i) * 60000, Open: c, High: c, Low: c, Close: c, Volume: 1}
+	}
+	return klineData
+}
+
+// targetStrategy 在指定 K 线设置目标持仓，并记录回调
+type targetStrategy struct {
+	BaseStrategy
+	targets map[int]float64
+	bars    int
+	fills   []Fill
+	stopped bool
+}
+
+func (s *targetStrategy) OnBar(ctx *StrategyContext) error {
+	if qty, ok := s.targets[len(ctx.Klines)-1]; ok {
+		ctx.Target(qty)
+	}
+	s.bars++
+	return nil
+}
+
+func (s *targetStrategy) OnTrade(_ *StrategyContext, fill Fill) error {
+	s.fills = append(s.fills, fill)
+	return nil
+}
+
+func (s *targetStrategy) OnStop(*StrategyContext) error {
+	s.stopped = true
+	return nil
+}
+
+func TestBacktestNextOpenFill(t *testing.T) {
+	klineData := strategyTestKlines([]float64{100, 110, 120, 90, 100})
+	// 下一根开盘价与收盘价不同，用于确认按开盘价成交
+	for i, k := range klineData {
+		k.Open = k.Close - 5
+		if i == 0 {
+			k.Open = k.Close
+		}
+	}
+	trader, _ := NewPaperTrader(1000, TradingCosts{})
+	strategy := &targetStrategy{targets: map[int]float64{0: 2, 2: 0}}
+	bt, err := Backtest(strategy, "BTCUSDT", klineData, trader, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// 第 0 根设置的 2 个在第 1 根开盘 105 成交，第 2 根设置的平仓在第 3 根开盘 85 成交
+	want := []Fill{
+		{Symbol: "BTCUSDT", Time: 60000, Quantity: 2, Price: 105},
+		{Symbol: "BTCUSDT", Time: 180000, Quantity: -2, Price: 85},
+	}
+	if len(strategy.fills) != len(want) || len(bt.Fills) != len(want) {
+		t.Fatalf("成交数量 = %d, want %d", len(strategy.fills), len(want))
+	}
+	for i, w := range want {
+		if strategy.fills[i] != w {
+			t.Errorf("成交 %d = %+v, want %+v", i, strategy.fills[i], w)
+		}
+	}
+	if strategy.bars != len(klineData) || !strategy.stopped {
+		t.Errorf("OnBar 调用 %d 次, OnStop = %v", strategy.bars, strategy.stopped)
+	}
+
+	equity := []float64{1000, 1010, 1030, 960, 960}
+	for i, w := range equity {
+		if math.Abs(bt.Equity[i]-w) > 1e-9 {
+			t.Errorf("Equity[%d] = %v, want %v", i, bt.Equity[i], w)
+		}
+	}
+	if math.Abs(bt.Result.PnL+0.04) > 1e-9 || bt.Result.Trades != 2 {
+		t.Errorf("Result = %+v", bt.Result)
+	}
+	if math.Abs(bt.Result.MaxDrawdown-70.0/1030) > 1e-9 {
+		t.Errorf("MaxDrawdown = %v, want %v", bt.Result.MaxDrawdown, 70.0/1030)
+	}
+}
+
+func TestBacktestWindow(t *testing.T) {
+	klineData := strategyTestKlines([]float64{1, 2, 3, 4, 5, 6})
+	var lengths []int
+	strategy := &funcStrategy{onBar: func(ctx *StrategyContext) error {
+		lengths = append(lengths, len(ctx.Klines))
+		return nil
+	}}
+	trader, _ := NewPaperTrader(1000, TradingCosts{})
+	if _, err := Backtest(strategy, "", klineData, trader, 3); err != nil {
+		t.Fatal(err)
+	}
+	want := []int{1, 2, 3, 3, 3, 3}
+	for i, w := range want {
+		if lengths[i] != w {
+			t.Errorf("第 %d 根 K 线窗口长度 = %d, want %d", i, lengths[i], w)
+		}
+	}
+}
+
+type funcStrategy struct {
+	BaseStrategy
+	onBar func(ctx *StrategyContext) error
+}
+
+func (s *funcStrategy) OnBar(ctx *StrategyContext) error { return s.onBar(ctx) }
+
+func TestStrategyContextIndicatorCache(t *testing.T) {
+	ctx := newStrategyContext("", strategyTestKlines([]float64{1, 2, 3, 4, 5}), 0, 1000)
+	calls := 0
+	compute := func(k KlineDatas) (any, error) {
+		calls++
+		return k.EMA(3, "close")
+	}
+	for i := 0; i < 3; i++ {
+		if _, err := ctx.Indicator("ema:3", compute); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if calls != 1 {
+		t.Errorf("同一根 K 线内计算了 %d 次, want 1", calls)
+	}
+
+	// 错误同样缓存
+	if _, err := ctx.EMA(10, "close"); err == nil {
+		t.Error("数据不足时应返回错误")
+	}
+	if _, err := ctx.EMA(10, "close"); err == nil {
+		t.Error("缓存的错误应再次返回")
+	}
+}
+
+func TestStrategyBacktesterStressTest(t *testing.T) {
+	prices := make([]float64, 60)
+	for i := range prices {
+		prices[i] = 100 + 10*math.Sin(float64(i)/5)
+	}
+	klineData := strategyTestKlines(prices)
+	backtest := StrategyBacktester(func() Strategy {
+		return &funcStrategy{onBar: func(ctx *StrategyContext) error {
+			ema, err := ctx.EMA(5, "close")
+			if err != nil {
+				return nil
+			}
+			if ctx.Close() > ema.Value() {
+				ctx.Signal(1, 1)
+			} else {
+				ctx.Signal(0, 0)
+			}
+			return nil
+		}}
+	}, "BTCUSDT", 20, 1000, TradingCosts{FeeRate: 0.001})
+
+	base, reports, err := klineData.StressTest(DefaultStressScenarios(-10, 5), backtest)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if base.Trades == 0 || len(reports) == 0 {
+		t.Errorf("base = %+v, reports = %d", base, len(reports))
+	}
+
+	objective := StrategyObjective(func(period int) Strategy {
+		return &funcStrategy{onBar: func(ctx *StrategyContext) error {
+			ctx.Target(float64(period))
+			return nil
+		}}
+	}, 0, 1000, TradingCosts{})
+	if _, err := objective(klineData, 3); err != nil {
+		t.Fatal(err)
+	}
+}
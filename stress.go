@@ -0,0 +1,225 @@
+package ta
+
+import (
+	"fmt"
+	"math"
+)
+
+// 压力情景的冲击类型
+const (
+	// ShockFlashCrash 闪崩：指定 K 线的最低价相对开盘价下探 Size（如 -0.1），收盘价不变
+	ShockFlashCrash = iota
+	// ShockGap 跳空：从指定 K 线起所有价格乘以 1+Size（如 -0.05）
+	ShockGap
+	// ShockVolatility 波动放大：指定区间内每根 K 线相对前收盘的对数涨跌幅乘以 Size（如 3）
+	ShockVolatility
+	// ShockLiquidity 流动性下降：指定区间内成交量乘以 Size（如 0.5）
+	ShockLiquidity
+)
+
+// StressScenario 一个压力情景
+// 字段：
+//   - Name: 情景名称
+//   - Shock: 冲击类型，ShockFlashCrash、ShockGap、ShockVolatility 或 ShockLiquidity
+//   - Index: 冲击开始的 K 线下标，负数表示从末尾倒数（-1 为最后一根）
+//   - Size: 冲击幅度，含义由 Shock 决定
+//   - Bars: 冲击持续的 K 线数量，仅 ShockVolatility 和 ShockLiquidity 使用，0 表示持续到末尾
+type StressScenario struct {
+	Name  string  `json:"name"`
+	Shock int     `json:"shock"`
+	Index int     `json:"index"`
+	Size  float64 `json:"size"`
+	Bars  int     `json:"bars"`
+}
+
+// BacktestResult 压力测试比较的回测结果
+// 字段：
+//   - PnL: 收益率，如 0.12 表示 12%
+//   - MaxDrawdown: 最大回撤比例，≥ 0
+//   - Trades: 交易次数
+type BacktestResult struct {
+	PnL         float64 `json:"pnl"`
+	MaxDrawdown float64 `json:"max_drawdown"`
+	Trades      int     `json:"trades"`
+}
+
+// StressReport 一个情景相对基准回测的变化
+// 字段：
+//   - Scenario: 压力情景
+//   - Result: 注入冲击后的回测结果
+//   - PnLDelta: 收益率变化，Result.PnL − 基准 PnL
+//   - DrawdownDelta: 最大回撤变化，Result.MaxDrawdown − 基准 MaxDrawdown
+type StressReport struct {
+	Scenario      StressScenario `json:"scenario"`
+	Result        BacktestResult `json:"result"`
+	PnLDelta      float64        `json:"pnl_delta"`
+	DrawdownDelta float64        `json:"drawdown_delta"`
+}
+
+// DefaultStressScenarios 返回一组常用的压力情景：闪崩 10%、跳空 5%、波动放大 3 倍、流动性减半
+// 参数：
+//   - index: 冲击开始的 K 线下标，负数表示从末尾倒数
+//   - bars: 波动放大和流动性下降持续的 K 线数量
+func DefaultStressScenarios(index, bars int) []StressScenario {
+	return []StressScenario{
+		{Name: "flash_crash", Shock: ShockFlashCrash, Index: index, Size: -0.1},
+		{Name: "gap_down", Shock: ShockGap, Index: index, Size: -0.05},
+		{Name: "volatility_x3", Shock: ShockVolatility, Index: index, Size: 3, Bars: bars},
+		{Name: "liquidity_half", Shock: ShockLiquidity, Index: index, Size: 0.5, Bars: bars},
+	}
+}
+
+// ApplyScenario 返回注入压力情景后的 K 线副本，原数据不变
+// 参数：
+//   - scenario: 压力情景
+//
+// 返回值：
+//   - KlineDatas: 注入冲击后的新 K 线
+//   - error: 情景参数无效或下标越界时返回错误
+func (k *KlineDatas) ApplyScenario(scenario StressScenario) (KlineDatas, error) {
+	length := len(*k)
+	start := scenario.Index
+	if start < 0 {
+		start += length
+	}
+	if start < 0 || start >= length {
+		return nil, fmt.Errorf("冲击下标越界: %d", scenario.Index)
+	}
+	end := length
+	if scenario.Bars > 0 && start+scenario.Bars < length {
+		end = start + scenario.Bars
+	}
+
+	out := make(KlineDatas, length)
+	for i, kline := range *k {
+		copied := *kline
+		out[i] = &copied
+	}
+
+	switch scenario.Shock {
+	case ShockFlashCrash:
+		if scenario.Size <= -1 || scenario.Size >= 0 {
+			return nil, fmt.Errorf("闪崩幅度必须在(-1, 0)之间")
+		}
+		bar := out[start]
+		bar.Low = math.Min(bar.Low, bar.Open*(1+scenario.Size))
+	case ShockGap:
+		if scenario.Size <= -1 {
+			return nil, fmt.Errorf("跳空幅度必须大于-1")
+		}
+		for _, bar := range out[start:] {
+			bar.Open *= 1 + scenario.Size
+			bar.High *= 1 + scenario.Size
+			bar.Low *= 1 + scenario.Size
+			bar.Close *= 1 + scenario.Size
+		}
+	case ShockVolatility:
+		if scenario.Size <= 0 {
+			return nil, fmt.Errorf("波动放大倍数必须大于0")
+		}
+		if start == 0 {
+			start = 1
+		}
+		// 按相对前收盘的对数涨跌幅重建价格链，区间之后的 K 线保持原有涨跌幅
+		prevOrig, prevNew := (*k)[start-1].Close, out[start-1].Close
+		for i := start; i < length; i++ {
+			bar, orig := out[i], (*k)[i]
+			mult := 1.0
+			if i < end {
+				mult = scenario.Size
+			}
+			rebuild := func(price float64) float64 {
+				if price <= 0 || prevOrig <= 0 {
+					return price
+				}
+				return prevNew * math.Exp(mult*math.Log(price/prevOrig))
+			}
+			bar.Open, bar.High, bar.Low, bar.Close = rebuild(orig.Open), rebuild(orig.High), rebuild(orig.Low), rebuild(orig.Close)
+			bar.High = math.Max(bar.High, math.Max(bar.Open, bar.Close))
+			bar.Low = math.Min(bar.Low, math.Min(bar.Open, bar.Close))
+			prevOrig, prevNew = orig.Close, bar.Close
+		}
+	case ShockLiquidity:
+		if scenario.Size < 0 {
+			return nil, fmt.Errorf("成交量倍数不能为负数")
+		}
+		for _, bar := range out[start:end] {
+			bar.Volume *= scenario.Size
+		}
+	default:
+		return nil, fmt.Errorf("无效的冲击类型: %d", scenario.Shock)
+	}
+	return out, nil
+}
+
+// StressTest 依次注入压力情景并重新运行回测，报告每个情景相对基准的收益和风险变化
+// 参数：
+//   - scenarios: 压力情景列表，可使用 DefaultStressScenarios
+//   - backtest: 回测函数，输入 K 线返回回测结果
+//
+// 返回值：
+//   - BacktestResult: 原始数据的基准回测结果
+//   - []StressReport: 每个情景的回测结果与变化
+//   - error: 情景无效或回测失败时返回错误
+//
+// 示例：
+//
+//	backtest := func(klines KlineDatas) (BacktestResult, error) {
+//	    st, err := klines.SuperTrend(10, 3)
+//	    if err != nil {
+//	        return BacktestResult{}, err
+//	    }
+//	    sar, err := klines.StopAndReverse(st.Direction(), true)
+//	    if err != nil {
+//	        return BacktestResult{}, err
+//	    }
+//	    return sar.Result(), nil
+//	}
+//	base, reports, err := klineData.StressTest(DefaultStressScenarios(-100, 50), backtest)
+func (k *KlineDatas) StressTest(scenarios []StressScenario, backtest func(klines KlineDatas) (BacktestResult, error)) (BacktestResult, []StressReport, error) {
+	base, err := backtest(*k)
+	if err != nil {
+		return BacktestResult{}, nil, fmt.Errorf("基准回测失败: %v", err)
+	}
+	reports := make([]StressReport, 0, len(scenarios))
+	for _, scenario := range scenarios {
+		shocked, err := k.ApplyScenario(scenario)
+		if err != nil {
+			return BacktestResult{}, nil, fmt.Errorf("情景 %s 无效: %v", scenario.Name, err)
+		}
+		result, err := backtest(shocked)
+		if err != nil {
+			return BacktestResult{}, nil, fmt.Errorf("情景 %s 回测失败: %v", scenario.Name, err)
+		}
+		reports = append(reports, StressReport{
+			Scenario:      scenario,
+			Result:        result,
+			PnLDelta:      result.PnL - base.PnL,
+			DrawdownDelta: result.MaxDrawdown - base.MaxDrawdown,
+		})
+	}
+	return base, reports, nil
+}
+
+// Result 按复利汇总已平仓交易的收益率和最大回撤，未平仓的交易不计入
+func (t *TaReversal) Result() BacktestResult {
+	equity, peak := 1.0, 1.0
+	result := BacktestResult{}
+	for _, trade := range t.Trades {
+		if trade.ExitIndex < 0 {
+			continue
+		}
+		equity *= 1 + trade.Return
+		peak = math.Max(peak, equity)
+		result.MaxDrawdown = math.Max(result.MaxDrawdown, 1-equity/peak)
+		result.Trades++
+	}
+	result.PnL = equity - 1
+	return result
+}
+
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
@@ -0,0 +1,188 @@
+package ta
+
+import (
+	"fmt"
+	"math"
+)
+
+// StructurePoint 市场结构中的一个摆动点
+// 字段：
+//   - Index: 摆动点所在 K 线索引
+//   - Confirmed: 摆动点被确认时的 K 线索引（Index + 轴点周期）
+//   - Price: 摆动点价格
+//   - IsHigh: 是否为摆动高点
+//   - Label: 结构标签，HH/LH（高点）或 HL/LL（低点），首个点为空
+type StructurePoint struct {
+	Index     int     `json:"index"`
+	Confirmed int     `json:"confirmed"`
+	Price     float64 `json:"price"`
+	IsHigh    bool    `json:"is_high"`
+	Label     string  `json:"label"`
+}
+
+// StructureEvent 结构突破事件（Break of Structure）
+// 字段：
+//   - Index: 突破发生的 K 线索引
+//   - Direction: 1 为向上突破最近摆动高点，-1 为向下突破最近摆动低点
+//   - Level: 被突破的摆动点价格
+type StructureEvent struct {
+	Index     int     `json:"index"`
+	Direction int     `json:"direction"`
+	Level     float64 `json:"level"`
+}
+
+// TaStructure 基于轴点的市场结构（HH/HL/LH/LL）跟踪结果
+// 字段：
+//   - Points: 按确认顺序排列的摆动点
+//   - Events: 结构突破事件
+//   - Trend: 每根 K 线的结构状态，1 上升结构，-1 下降结构，0 未确定
+//   - PivotPeriod: 轴点周期
+type TaStructure struct {
+	Points      []StructurePoint `json:"points"`
+	Events      []StructureEvent `json:"events"`
+	Trend       []int            `json:"trend"`
+	PivotPeriod int              `json:"pivot_period"`
+}
+
+// CalculateStructure 计算市场结构
+// 参数：
+//   - klineData: K 线数据
+//   - pivotPeriod: 轴点左右两侧的 K 线数量，与 FindPivotHighPoint 一致
+//
+// 返回值：
+//   - *TaStructure: 计算结果
+//   - error: 数据不足时返回错误
+//
+// 说明/注意事项：
+//
+//	轴点需要右侧 pivotPeriod 根 K 线确认，因此第 i 根只使用 i-pivotPeriod 处及之前已确认的摆动点，不含未来数据。
+//	收盘价突破最近的摆动高点（低点）时产生向上（向下）突破事件，并切换结构状态；
+//	在没有突破事件前，连续出现 HH+HL 或 LH+LL 也会确定结构状态。
+//
+// 示例：
+//
+//	ms, err := CalculateStructure(klineData, 5)
+//	label := ms.LastLabel()
+func CalculateStructure(klineData KlineDatas, pivotPeriod int) (*TaStructure, error) {
+	if pivotPeriod <= 0 {
+		return nil, fmt.Errorf("轴点周期必须大于0")
+	}
+	length := len(klineData)
+	if length < pivotPeriod*2+1 {
+		return nil, fmt.Errorf("计算数据不足")
+	}
+
+	result := &TaStructure{
+		Trend:       make([]int, length),
+		PivotPeriod: pivotPeriod,
+	}
+
+	lastHigh, lastLow := math.NaN(), math.NaN()
+	var lastHighLabel, lastLowLabel string
+	highBroken, lowBroken := true, true
+	trend := 0
+
+	for i := 0; i < length; i++ {
+		if j := i - pivotPeriod; j >= pivotPeriod {
+			if ph := FindPivotHighPoint(klineData, j, pivotPeriod); !math.IsNaN(ph) {
+				label := ""
+				if !math.IsNaN(lastHigh) {
+					if ph > lastHigh {
+						label = "HH"
+					} else {
+						label = "LH"
+					}
+				}
+				result.Points = append(result.Points, StructurePoint{Index: j, Confirmed: i, Price: ph, IsHigh: true, Label: label})
+				lastHigh, lastHighLabel, highBroken = ph, label, false
+			}
+			if pl := FindPivotLowPoint(klineData, j, pivotPeriod); !math.IsNaN(pl) {
+				label := ""
+				if !math.IsNaN(lastLow) {
+					if pl > lastLow {
+						label = "HL"
+					} else {
+						label = "LL"
+					}
+				}
+				result.Points = append(result.Points, StructurePoint{Index: j, Confirmed: i, Price: pl, IsHigh: false, Label: label})
+				lastLow, lastLowLabel, lowBroken = pl, label, false
+			}
+			if trend == 0 {
+				if lastHighLabel == "HH" && lastLowLabel == "HL" {
+					trend = 1
+				} else if lastHighLabel == "LH" && lastLowLabel == "LL" {
+					trend = -1
+				}
+			}
+		}
+
+		closePrice := klineData[i].Close
+		if !highBroken && closePrice > lastHigh {
+			highBroken = true
+			trend = 1
+			result.Events = append(result.Events, StructureEvent{Index: i, Direction: 1, Level: lastHigh})
+		}
+		if !lowBroken && closePrice < lastLow {
+			lowBroken = true
+			trend = -1
+			result.Events = append(result.Events, StructureEvent{Index: i, Direction: -1, Level: lastLow})
+		}
+		result.Trend[i] = trend
+	}
+
+	return result, nil
+}
+
+// Structure 计算 K 线数据的市场结构
+func (k *KlineDatas) Structure(pivotPeriod int) (*TaStructure, error) {
+	return CalculateStructure(*k, pivotPeriod)
+}
+
+// Value 返回最新的结构状态
+func (t *TaStructure) Value() int {
+	return t.Trend[len(t.Trend)-1]
+}
+
+// LastLabel 返回最近一个摆动点的结构标签，没有摆动点时返回空字符串
+func (t *TaStructure) LastLabel() string {
+	if len(t.Points) == 0 {
+		return ""
+	}
+	return t.Points[len(t.Points)-1].Label
+}
+
+// LastEvent 返回最近一次结构突破事件
+// 返回值：
+//   - StructureEvent: 最近的突破事件
+//   - bool: 没有突破事件时返回 false
+func (t *TaStructure) LastEvent() (StructureEvent, bool) {
+	if len(t.Events) == 0 {
+		return StructureEvent{}, false
+	}
+	return t.Events[len(t.Events)-1], true
+}
+
+// IsBreakAt 判断指定位置是否发生结构突破
+// 参数：
+//   - index: K 线索引
+//
+// 返回值：
+//   - int: 1 向上突破，-1 向下突破，0 无突破
+func (t *TaStructure) IsBreakAt(index int) int {
+	for i := len(t.Events) - 1; i >= 0; i-- {
+		if t.Events[i].Index == index {
+			return t.Events[i].Direction
+		}
+		if t.Events[i].Index < index {
+			break
+		}
+	}
+	return 0
+}
+
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
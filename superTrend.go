@@ -14,7 +14,7 @@ type TaSuperTrend struct {
 }
 
 func CalculateSuperTrend(klineData KlineDatas, period int, multiplier float64) (*TaSuperTrend, error) {
-	if len(klineData) < period {
+	if len(klineData) <= period {
 		return nil, fmt.Errorf("计算数据不足")
 	}
 
@@ -71,6 +71,19 @@ func (k *KlineDatas) SuperTrend(period int, multiplier float64) (*TaSuperTrend,
 	return CalculateSuperTrend(*k, period, multiplier)
 }
 
+// SuperTrend_ 计算并返回最新的上轨、下轨和趋势方向，数据不足时返回 0 和 false
+func (k *KlineDatas) SuperTrend_(period int, multiplier float64) (upper, lower float64, isUpTrend bool) {
+	_k, err := k.Keep(quickKeep("supertrend", period))
+	if err != nil {
+		_k = *k
+	}
+	superTrend, err := _k.SuperTrend(period, multiplier)
+	if err != nil {
+		return 0, 0, false
+	}
+	return superTrend.Value()
+}
+
 func (t *TaSuperTrend) Value() (upper, lower float64, isUpTrend bool) {
 	lastIndex := len(t.Upper) - 1
 	return t.Upper[lastIndex], t.Lower[lastIndex], t.Trend[lastIndex]
@@ -0,0 +1,128 @@
+package ta
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// SymbolInfo 交易对的精度与下单限制
+// 说明：
+//
+//	对应交易所的 PRICE_FILTER / LOT_SIZE / MIN_NOTIONAL 过滤器，
+//	用于把止损价、目标价和仓位数量调整为交易所可接受的值。
+//
+// 字段：
+//   - Symbol: 交易对名称
+//   - TickSize: 价格最小变动单位，0 表示不限制
+//   - StepSize: 数量最小变动单位，0 表示不限制
+//   - MinQty: 最小下单数量
+//   - MinNotional: 最小名义价值（价格 × 数量）
+type SymbolInfo struct {
+	Symbol      string  `json:"symbol"`
+	TickSize    float64 `json:"tick_size"`
+	StepSize    float64 `json:"step_size"`
+	MinQty      float64 `json:"min_qty"`
+	MinNotional float64 `json:"min_notional"`
+}
+
+// stepDecimals 返回步长的小数位数，用于消除浮点误差
+func stepDecimals(step float64) int {
+	s := strconv.FormatFloat(step, 'f', -1, 64)
+	if i := strings.IndexByte(s, '.'); i >= 0 {
+		return len(s) - i - 1
+	}
+	return 0
+}
+
+// roundToStep 按步长取整，mode 为 -1 向下、0 四舍五入、1 向上
+func roundToStep(value, step float64, mode int) float64 {
+	if step <= 0 {
+		return value
+	}
+	n := value / step
+	// 容忍浮点误差，避免 0.3/0.1 = 2.9999999999999996 被向下取整为 2
+	const eps = 1e-9
+	switch mode {
+	case -1:
+		n = math.Floor(n + eps)
+	case 1:
+		n = math.Ceil(n - eps)
+	default:
+		n = math.Round(n)
+	}
+	pow := math.Pow10(stepDecimals(step))
+	return math.Round(n*step*pow) / pow
+}
+
+// RoundPrice 将价格四舍五入到 TickSize 的整数倍
+func (s *SymbolInfo) RoundPrice(price float64) float64 {
+	return roundToStep(price, s.TickSize, 0)
+}
+
+// RoundStop 按保守方向调整止损价
+// 参数：
+//   - price: 原始止损价
+//   - isLong: 是否为多头仓位
+//
+// 返回值：
+//   - float64: 多头止损向上取整、空头止损向下取整，调整后的止损不会比原值更远
+func (s *SymbolInfo) RoundStop(price float64, isLong bool) float64 {
+	if isLong {
+		return roundToStep(price, s.TickSize, 1)
+	}
+	return roundToStep(price, s.TickSize, -1)
+}
+
+// RoundTarget 按保守方向调整目标价
+// 参数：
+//   - price: 原始目标价
+//   - isLong: 是否为多头仓位
+//
+// 返回值：
+//   - float64: 多头目标向下取整、空头目标向上取整，调整后的目标不会比原值更远
+func (s *SymbolInfo) RoundTarget(price float64, isLong bool) float64 {
+	if isLong {
+		return roundToStep(price, s.TickSize, -1)
+	}
+	return roundToStep(price, s.TickSize, 1)
+}
+
+// RoundQuantity 将数量向下取整到 StepSize 的整数倍，保证不超过原始仓位
+func (s *SymbolInfo) RoundQuantity(qty float64) float64 {
+	return roundToStep(qty, s.StepSize, -1)
+}
+
+// Order 将价格和数量调整为符合交易所限制的下单参数
+// 参数：
+//   - price: 下单价格
+//   - qty: 下单数量
+//
+// 返回值：
+//   - float64: 调整后的价格
+//   - float64: 调整后的数量
+//   - error: 调整后的数量低于 MinQty 或名义价值低于 MinNotional 时返回错误
+//
+// 示例：
+//
+//	info := &SymbolInfo{Symbol: "BTCUSDT", TickSize: 0.1, StepSize: 0.001, MinQty: 0.001, MinNotional: 5}
+//	price, qty, err := info.Order(entry, equity*fraction/entry)
+//	stop := info.RoundStop(entry-2*atr, true)
+func (s *SymbolInfo) Order(price, qty float64) (float64, float64, error) {
+	price = s.RoundPrice(price)
+	qty = s.RoundQuantity(qty)
+	if qty <= 0 || qty < s.MinQty {
+		return price, qty, fmt.Errorf("%s 下单数量(%v)低于最小数量(%v)", s.Symbol, qty, s.MinQty)
+	}
+	if notional := price * qty; notional < s.MinNotional {
+		return price, qty, fmt.Errorf("%s 名义价值(%v)低于最小名义价值(%v)", s.Symbol, notional, s.MinNotional)
+	}
+	return price, qty, nil
+}
+
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
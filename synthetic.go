@@ -0,0 +1,262 @@
+package ta
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+)
+
+// syntheticSubSteps 每根合成 K 线内部模拟的价格步数，用于生成最高价和最低价
+const syntheticSubSteps = 8
+
+// SyntheticConfig 合成 K 线的公共参数
+// 字段：
+//   - Bars: 生成的 K 线数量
+//   - StartPrice: 初始价格
+//   - StartTime: 第一根 K 线的开始时间（毫秒）
+//   - Interval: K 线周期的毫秒数，可由 ParseInterval 得到
+//   - Drift: 每根 K 线对数收益的期望，如 0.0001
+//   - Volatility: 每根 K 线对数收益的标准差，如 0.01
+//   - Volume: 平均成交量，每根 K 线在其附近随机波动
+//   - Seed: 随机数种子，相同种子生成相同的数据
+type SyntheticConfig struct {
+	Bars       int     `json:"bars"`
+	StartPrice float64 `json:"start_price"`
+	StartTime  int64   `json:"start_time"`
+	Interval   int64   `json:"interval"`
+	Drift      float64 `json:"drift"`
+	Volatility float64 `json:"volatility"`
+	Volume     float64 `json:"volume"`
+	Seed       int64   `json:"seed"`
+}
+
+// SyntheticRegime 状态切换模型中的一个市场状态
+// 字段：
+//   - Drift: 该状态下每根 K 线对数收益的期望
+//   - Volatility: 该状态下每根 K 线对数收益的标准差
+type SyntheticRegime struct {
+	Drift      float64 `json:"drift"`
+	Volatility float64 `json:"volatility"`
+}
+
+func (c SyntheticConfig) check() error {
+	if c.Bars <= 0 {
+		return fmt.Errorf("K线数量必须大于0")
+	}
+	if c.StartPrice <= 0 {
+		return fmt.Errorf("初始价格必须大于0")
+	}
+	if c.Interval <= 0 {
+		return fmt.Errorf("周期必须大于0")
+	}
+	if c.Volatility < 0 || c.Volume < 0 {
+		return fmt.Errorf("波动率和成交量不能为负数")
+	}
+	return nil
+}
+
+// syntheticBuilder 按对数价格路径逐根生成 K 线
+type syntheticBuilder struct {
+	config SyntheticConfig
+	rng    *rand.Rand
+	out    KlineDatas
+	logP   float64
+}
+
+func newSyntheticBuilder(config SyntheticConfig) *syntheticBuilder {
+	return &syntheticBuilder{
+		config: config,
+		rng:    rand.New(rand.NewSource(config.Seed)),
+		out:    make(KlineDatas, 0, config.Bars),
+		logP:   math.Log(config.StartPrice),
+	}
+}
+
+// bar 生成下一根 K 线，step 返回一个子步内对数价格的变化
+func (b *syntheticBuilder) bar(step func(logP float64) float64) {
+	open := math.Exp(b.logP)
+	high, low := open, open
+	for s := 0; s < syntheticSubSteps; s++ {
+		b.logP += step(b.logP)
+		price := math.Exp(b.logP)
+		high, low = math.Max(high, price), math.Min(low, price)
+	}
+	b.out = append(b.out, &KlineData{
+		StartTime: b.config.StartTime + int64(len(b.out))*b.config.Interval,
+		Open:      open,
+		High:      high,
+		Low:       low,
+		Close:     math.Exp(b.logP),
+		Volume:    b.config.Volume * math.Exp(0.5*b.rng.NormFloat64()-0.125),
+	})
+}
+
+// GenerateGBM 生成几何布朗运动的合成 K 线
+// 参数：
+//   - config: 公共参数，Drift 与 Volatility 为每根 K 线的对数收益参数
+//
+// 返回值：
+//   - KlineDatas: 合成 K 线，开盘价等于前一根收盘价
+//   - error: 参数无效时返回错误
+//
+// 说明/注意事项：
+//
+//	每根 K 线内部模拟多个子步以得到最高价和最低价，成交量为对数正态分布。
+//
+// 示例：
+//
+//	klines, err := GenerateGBM(SyntheticConfig{
+//	    Bars: 5000, StartPrice: 100, Interval: 60000,
+//	    Drift: 0, Volatility: 0.002, Volume: 1000, Seed: 1,
+//	})
+func GenerateGBM(config SyntheticConfig) (KlineDatas, error) {
+	if err := config.check(); err != nil {
+		return nil, err
+	}
+	b := newSyntheticBuilder(config)
+	subDrift := config.Drift / syntheticSubSteps
+	subVol := config.Volatility / math.Sqrt(syntheticSubSteps)
+	for i := 0; i < config.Bars; i++ {
+		b.bar(func(float64) float64 {
+			return subDrift + subVol*b.rng.NormFloat64()
+		})
+	}
+	return b.out, nil
+}
+
+// GenerateOU 生成对数价格服从 Ornstein-Uhlenbeck 过程（均值回归）的合成 K 线
+// 参数：
+//   - config: 公共参数，Volatility 为每根 K 线的噪声标准差，Drift 不使用
+//   - theta: 每根 K 线的回归速度，取值 (0, 1]，半衰期约为 ln2/theta 根 K 线
+//   - mean: 长期均值价格
+//
+// 返回值：
+//   - KlineDatas: 合成 K 线
+//   - error: 参数无效时返回错误
+func GenerateOU(config SyntheticConfig, theta, mean float64) (KlineDatas, error) {
+	if err := config.check(); err != nil {
+		return nil, err
+	}
+	if theta <= 0 || theta > 1 {
+		return nil, fmt.Errorf("回归速度必须在(0, 1]之间")
+	}
+	if mean <= 0 {
+		return nil, fmt.Errorf("均值价格必须大于0")
+	}
+	b := newSyntheticBuilder(config)
+	logMean := math.Log(mean)
+	subTheta := theta / syntheticSubSteps
+	subVol := config.Volatility / math.Sqrt(syntheticSubSteps)
+	for i := 0; i < config.Bars; i++ {
+		b.bar(func(logP float64) float64 {
+			return subTheta*(logMean-logP) + subVol*b.rng.NormFloat64()
+		})
+	}
+	return b.out, nil
+}
+
+// GenerateRegimeSwitching 生成在多个市场状态之间随机切换的合成 K 线
+// 参数：
+//   - config: 公共参数，Drift 与 Volatility 不使用
+//   - regimes: 市场状态列表，从第一个状态开始
+//   - switchProb: 每根 K 线切换到其他状态的概率，如 0.01
+//
+// 返回值：
+//   - KlineDatas: 合成 K 线
+//   - []int: 每根 K 线所处的状态下标，可作为检验指标状态识别能力的标签
+//   - error: 参数无效时返回错误
+//
+// 示例：
+//
+//	klines, states, err := GenerateRegimeSwitching(config, []SyntheticRegime{
+//	    {Drift: 0.001, Volatility: 0.005}, // 低波动上涨
+//	    {Drift: -0.002, Volatility: 0.02}, // 高波动下跌
+//	}, 0.01)
+func GenerateRegimeSwitching(config SyntheticConfig, regimes []SyntheticRegime, switchProb float64) (KlineDatas, []int, error) {
+	if err := config.check(); err != nil {
+		return nil, nil, err
+	}
+	if len(regimes) == 0 {
+		return nil, nil, fmt.Errorf("至少需要一个市场状态")
+	}
+	if switchProb < 0 || switchProb > 1 {
+		return nil, nil, fmt.Errorf("切换概率必须在[0, 1]之间")
+	}
+	for _, r := range regimes {
+		if r.Volatility < 0 {
+			return nil, nil, fmt.Errorf("波动率不能为负数")
+		}
+	}
+
+	b := newSyntheticBuilder(config)
+	states := make([]int, config.Bars)
+	state := 0
+	for i := 0; i < config.Bars; i++ {
+		if i > 0 && len(regimes) > 1 && b.rng.Float64() < switchProb {
+			next := b.rng.Intn(len(regimes) - 1)
+			if next >= state {
+				next++
+			}
+			state = next
+		}
+		states[i] = state
+		subDrift := regimes[state].Drift / syntheticSubSteps
+		subVol := regimes[state].Volatility / math.Sqrt(syntheticSubSteps)
+		b.bar(func(float64) float64 {
+			return subDrift + subVol*b.rng.NormFloat64()
+		})
+	}
+	return b.out, states, nil
+}
+
+// BootstrapKlines 对真实 K 线做块自助重抽样，生成统计特征相近的合成 K 线
+// 参数：
+//   - source: 真实 K 线，至少 2 根
+//   - config: 公共参数，只使用 Bars、StartPrice、StartTime、Interval 和 Seed
+//   - blockSize: 块长度，保留波动聚集等序列相关性，如 20
+//
+// 返回值：
+//   - KlineDatas: 合成 K 线，每根的形态（相对前收盘的 OHLC）和成交量来自被抽中的真实 K 线
+//   - error: 参数无效或数据不足时返回错误
+func BootstrapKlines(source KlineDatas, config SyntheticConfig, blockSize int) (KlineDatas, error) {
+	if err := config.check(); err != nil {
+		return nil, err
+	}
+	if blockSize <= 0 {
+		return nil, fmt.Errorf("块长度必须大于0")
+	}
+	candles, err := source.ReturnCandles(true)
+	if err != nil {
+		return nil, err
+	}
+	if len(candles) == 0 {
+		return nil, fmt.Errorf("计算数据不足")
+	}
+
+	rng := rand.New(rand.NewSource(config.Seed))
+	out := make(KlineDatas, 0, config.Bars)
+	prevClose := config.StartPrice
+	for len(out) < config.Bars {
+		start := rng.Intn(len(candles))
+		for j := 0; j < blockSize && len(out) < config.Bars; j++ {
+			c := candles[(start+j)%len(candles)]
+			kline := &KlineData{
+				StartTime: config.StartTime + int64(len(out))*config.Interval,
+				Open:      prevClose * math.Exp(c.Open),
+				High:      prevClose * math.Exp(c.High),
+				Low:       prevClose * math.Exp(c.Low),
+				Close:     prevClose * math.Exp(c.Close),
+				Volume:    c.Volume,
+			}
+			out = append(out, kline)
+			prevClose = kline.Close
+		}
+	}
+	return out, nil
+}
+
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
@@ -59,10 +59,71 @@ func (k *KlineDatas) T3(period int, vfact float64, source string) (*TaT3, error)
 	return CalculateT3(prices, period, vfact)
 }
 
+// T3_ 计算并返回最新的 T3 值，数据不足时返回 0
+func (k *KlineDatas) T3_(period int, vfact float64, source string) float64 {
+	_k, err := k.Keep(quickKeep("t3", period))
+	if err != nil {
+		_k = *k
+	}
+	t3, err := _k.T3(period, vfact, source)
+	if err != nil {
+		return 0
+	}
+	return t3.Value()
+}
+
 func (t *TaT3) Value() float64 {
 	return t.Values[len(t.Values)-1]
 }
 
+// GetDeviation 返回价格相对最新 T3 的偏离百分比，正数表示价格在 T3 之上，T3 无效时返回 0
+func (t *TaT3) GetDeviation(price float64) float64 {
+	value := t.Value()
+	if value == 0 {
+		return 0
+	}
+	return (price - value) / value * 100
+}
+
+// Slope 返回最近 n 根 K 线 T3 的平均每根变化量
+// 参数：
+//   - n: 回看的 K 线数量
+//
+// 返回值：
+//   - float64: (最新值 − n 根前的值) / n，数据不足或落在预热期时返回 0
+func (t *TaT3) Slope(n int) float64 {
+	return seriesSlope(t.Values, n)
+}
+
+// Acceleration 返回最近 n 根 K 线 T3 斜率相对再之前 n 根的变化，正数表示加速上行或减速下行
+func (t *TaT3) Acceleration(n int) float64 {
+	return seriesAcceleration(t.Values, n)
+}
+
+// GetTrend 按最近 n 根 K 线 T3 的斜率返回 TrendUp、TrendDown 或 TrendFlat
+func (t *TaT3) GetTrend(n int) int {
+	return seriesTrend(t.Values, n)
+}
+
+// SlopePercent 返回最近 n 根 K 线 T3 的平均每根变化百分比，便于跨品种比较
+func (t *TaT3) SlopePercent(n int) float64 {
+	value := t.Value()
+	if value == 0 {
+		return 0
+	}
+	return t.Slope(n) / value * 100
+}
+
+// IsTrendUp 判断最近 n 根 K 线 T3 是否上升
+func (t *TaT3) IsTrendUp(n int) bool {
+	return t.Slope(n) > 0
+}
+
+// IsTrendDown 判断最近 n 根 K 线 T3 是否下降
+func (t *TaT3) IsTrendDown(n int) bool {
+	return t.Slope(n) < 0
+}
+
 // ----------------------------------------------------------------------------
 // ----------------------------------------------------------------------------
 // ----------------------------------------------------------------------------
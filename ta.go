@@ -292,6 +292,12 @@ func (k *KlineDatas) ExtractSlice(priceType string) ([]float64, error) {
 			prices = append(prices, kline.Close)
 		case "volume":
 			prices = append(prices, kline.Volume)
+		case "hl2":
+			prices = append(prices, (kline.High+kline.Low)/2)
+		case "hlc3":
+			prices = append(prices, (kline.High+kline.Low+kline.Close)/3)
+		case "ohlc4":
+			prices = append(prices, (kline.Open+kline.High+kline.Low+kline.Close)/4)
 		}
 	}
 	return prices, nil
@@ -0,0 +1,236 @@
+package ta
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// TaggedTrade 带上下文标签的一笔已平仓交易
+// 字段：
+//   - Index: 开仓 K 线下标
+//   - Return: 按方向计算的收益率
+//   - Tags: 开仓时的上下文标签，如 {"regime": "trend", "session": "asia", "vol": "q3", "signal": "supertrend"}
+type TaggedTrade struct {
+	Index  int               `json:"index"`
+	Return float64           `json:"return"`
+	Tags   map[string]string `json:"tags"`
+}
+
+// TradeCluster 一组标签相同的交易的统计
+// 字段：
+//   - Key: 分组键，如 "regime=trend|session=asia"
+//   - Tags: 分组使用的标签
+//   - Trades: 交易笔数
+//   - WinRate: 胜率，收益率 > 0 的比例
+//   - AvgReturn: 平均收益率
+//   - TotalReturn: 收益率之和
+//   - ProfitFactor: 盈利总和 / 亏损总和的绝对值，没有亏损时为 0
+//   - ProfitShare: 本组盈利占全部交易盈利的比例
+//   - LossShare: 本组亏损占全部交易亏损的比例
+type TradeCluster struct {
+	Key          string            `json:"key"`
+	Tags         map[string]string `json:"tags"`
+	Trades       int               `json:"trades"`
+	WinRate      float64           `json:"win_rate"`
+	AvgReturn    float64           `json:"avg_return"`
+	TotalReturn  float64           `json:"total_return"`
+	ProfitFactor float64           `json:"profit_factor"`
+	ProfitShare  float64           `json:"profit_share"`
+	LossShare    float64           `json:"loss_share"`
+}
+
+// TagTrades 为已平仓交易附加开仓 K 线上的上下文标签
+// 参数：
+//   - trades: 交易记录，如 TaReversal.Trades，未平仓的交易会被跳过
+//   - tags: 标签名到逐根 K 线标签序列的映射，序列长度须覆盖所有开仓下标
+//
+// 返回值：
+//   - []TaggedTrade: 带标签的交易
+//   - error: 标签序列长度不足时返回错误
+//
+// 示例：
+//
+//	tagged, err := TagTrades(sar.Trades, map[string][]string{
+//	    "session": SessionTags(klineData),
+//	    "vol":     QuantileTags(atr.Values, 4),
+//	    "signal":  signalNames,
+//	})
+func TagTrades(trades []ReversalTrade, tags map[string][]string) ([]TaggedTrade, error) {
+	out := make([]TaggedTrade, 0, len(trades))
+	for _, trade := range trades {
+		if trade.ExitIndex < 0 {
+			continue
+		}
+		tagged := TaggedTrade{Index: trade.EntryIndex, Return: trade.Return, Tags: make(map[string]string, len(tags))}
+		for name, series := range tags {
+			if trade.EntryIndex >= len(series) {
+				return nil, fmt.Errorf("标签 %s 的长度 %d 不足，开仓下标为 %d", name, len(series), trade.EntryIndex)
+			}
+			tagged.Tags[name] = series[trade.EntryIndex]
+		}
+		out = append(out, tagged)
+	}
+	return out, nil
+}
+
+// SessionTags 按 K 线开始时间的 UTC 小时返回交易时段标签："asia"（0-8 时）、"europe"（8-13 时）、"us"（13-21 时）、"late"（21-24 时）
+func SessionTags(klines KlineDatas) []string {
+	out := make([]string, len(klines))
+	for i, kline := range klines {
+		switch hour := time.UnixMilli(kline.StartTime).UTC().Hour(); {
+		case hour < 8:
+			out[i] = "asia"
+		case hour < 13:
+			out[i] = "europe"
+		case hour < 21:
+			out[i] = "us"
+		default:
+			out[i] = "late"
+		}
+	}
+	return out
+}
+
+// QuantileTags 按全样本分位数将序列分为 buckets 组，返回 "q1"（最低）到 "qN" 的标签
+// 参数：
+//   - values: 序列，如 ATR 或波动率；值为 0 的位置（通常为预热期）标签为空且不参与分位数计算
+//   - buckets: 分组数量，如 4
+//
+// 说明/注意事项：
+//
+//	分位数使用全部样本计算，仅用于事后归因分析，不能作为交易信号（含未来数据）。
+func QuantileTags(values []float64, buckets int) []string {
+	out := make([]string, len(values))
+	if buckets <= 0 {
+		return out
+	}
+	sorted := make([]float64, 0, len(values))
+	for _, v := range values {
+		if v != 0 {
+			sorted = append(sorted, v)
+		}
+	}
+	sort.Float64s(sorted)
+	for i, v := range values {
+		if v == 0 {
+			continue
+		}
+		bucket := int(percentileRank(sorted, v) / 100 * float64(buckets))
+		if bucket >= buckets {
+			bucket = buckets - 1
+		}
+		out[i] = fmt.Sprintf("q%d", bucket+1)
+	}
+	return out
+}
+
+// ClusterTrades 按指定标签对交易分组并统计各组表现
+// 参数：
+//   - trades: 带标签的交易
+//   - by: 用于分组的标签名，为空时使用所有交易共有的标签名
+//
+// 返回值：
+//   - []TradeCluster: 各组统计，按 TotalReturn 从高到低排序
+//   - error: 没有交易时返回错误
+//
+// 说明/注意事项：
+//
+//	ProfitShare 与 LossShare 显示策略的盈利和亏损集中在哪些情景中，
+//	例如 regime=range 的组 LossShare 为 0.7 说明七成亏损来自震荡行情。
+//
+// 示例：
+//
+//	clusters, err := ClusterTrades(tagged, "regime", "session")
+//	for _, c := range clusters {
+//	    fmt.Printf("%s 笔数=%d 胜率=%.2f 盈利占比=%.2f 亏损占比=%.2f\n", c.Key, c.Trades, c.WinRate, c.ProfitShare, c.LossShare)
+//	}
+func ClusterTrades(trades []TaggedTrade, by ...string) ([]TradeCluster, error) {
+	if len(trades) == 0 {
+		return nil, fmt.Errorf("没有交易数据")
+	}
+	if len(by) == 0 {
+		for name := range trades[0].Tags {
+			shared := true
+			for _, trade := range trades[1:] {
+				if _, ok := trade.Tags[name]; !ok {
+					shared = false
+					break
+				}
+			}
+			if shared {
+				by = append(by, name)
+			}
+		}
+		sort.Strings(by)
+	}
+
+	type accumulator struct {
+		cluster     *TradeCluster
+		wins        int
+		grossProfit float64
+		grossLoss   float64
+	}
+	groups := make(map[string]*accumulator)
+	var order []string
+	var totalProfit, totalLoss float64
+	for _, trade := range trades {
+		parts := make([]string, len(by))
+		tags := make(map[string]string, len(by))
+		for i, name := range by {
+			parts[i] = name + "=" + trade.Tags[name]
+			tags[name] = trade.Tags[name]
+		}
+		key := strings.Join(parts, "|")
+		acc, ok := groups[key]
+		if !ok {
+			acc = &accumulator{cluster: &TradeCluster{Key: key, Tags: tags}}
+			groups[key] = acc
+			order = append(order, key)
+		}
+		acc.cluster.Trades++
+		acc.cluster.TotalReturn += trade.Return
+		if trade.Return > 0 {
+			acc.wins++
+			acc.grossProfit += trade.Return
+			totalProfit += trade.Return
+		} else {
+			acc.grossLoss -= trade.Return
+			totalLoss -= trade.Return
+		}
+	}
+
+	clusters := make([]TradeCluster, 0, len(order))
+	for _, key := range order {
+		acc := groups[key]
+		c := acc.cluster
+		c.WinRate = float64(acc.wins) / float64(c.Trades)
+		c.AvgReturn = c.TotalReturn / float64(c.Trades)
+		if acc.grossLoss > 0 {
+			c.ProfitFactor = acc.grossProfit / acc.grossLoss
+		}
+		if totalProfit > 0 {
+			c.ProfitShare = acc.grossProfit / totalProfit
+		}
+		if totalLoss > 0 {
+			c.LossShare = acc.grossLoss / totalLoss
+		}
+		clusters = append(clusters, *c)
+	}
+	sort.SliceStable(clusters, func(i, j int) bool {
+		return clusters[i].TotalReturn > clusters[j].TotalReturn
+	})
+	return clusters, nil
+}
+
+// Tagged 为已平仓交易附加上下文标签，含义同 TagTrades
+func (t *TaReversal) Tagged(tags map[string][]string) ([]TaggedTrade, error) {
+	return TagTrades(t.Trades, tags)
+}
+
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
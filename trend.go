@@ -0,0 +1,59 @@
+package ta
+
+// 均线类指标的趋势方向
+const (
+	TrendDown = -1
+	TrendFlat = 0
+	TrendUp   = 1
+)
+
+// TrendIndicator 均线类指标统一的趋势接口
+// 说明：
+//
+//	TaSMA、TaEMA、TaRMA、TaT3 均实现该接口，策略代码可以不区分均线类型：
+//
+//	var ma TrendIndicator = ema
+//	if ma.GetTrend(5) == TrendUp && ma.Acceleration(5) > 0 {
+//	    // 趋势向上且在加速
+//	}
+type TrendIndicator interface {
+	Value() float64
+	Slope(n int) float64
+	Acceleration(n int) float64
+	GetTrend(n int) int
+}
+
+// seriesSlope 返回最近 n 根 K 线的平均每根变化量，数据不足或落在预热期（值为 0）时返回 0
+func seriesSlope(values []float64, n int) float64 {
+	lastIndex := len(values) - 1
+	if n <= 0 || lastIndex-n < 0 || values[lastIndex-n] == 0 {
+		return 0
+	}
+	return (values[lastIndex] - values[lastIndex-n]) / float64(n)
+}
+
+// seriesAcceleration 返回最近 n 根的斜率与再之前 n 根的斜率之差，数据不足时返回 0
+func seriesAcceleration(values []float64, n int) float64 {
+	lastIndex := len(values) - 1
+	if n <= 0 || lastIndex-2*n < 0 || values[lastIndex-2*n] == 0 {
+		return 0
+	}
+	return seriesSlope(values, n) - seriesSlope(values[:lastIndex-n+1], n)
+}
+
+// seriesTrend 按最近 n 根 K 线的斜率返回 TrendUp、TrendDown 或 TrendFlat
+func seriesTrend(values []float64, n int) int {
+	switch slope := seriesSlope(values, n); {
+	case slope > 0:
+		return TrendUp
+	case slope < 0:
+		return TrendDown
+	}
+	return TrendFlat
+}
+
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
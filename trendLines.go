@@ -0,0 +1,314 @@
+package ta
+
+import (
+	"fmt"
+	"math"
+)
+
+// TrendLine 一条穿过两个摆动点的趋势线
+// 字段：
+//   - Direction: 1 为上升支撑线（连接摆动低点），-1 为下降阻力线（连接摆动高点）
+//   - StartIndex/StartPrice: 第一个锚点的 K 线索引与价格
+//   - EndIndex/EndPrice: 第二个锚点的 K 线索引与价格
+//   - Slope: 每根 K 线的价格变化
+//   - Touches: 落在趋势线容差内的摆动点数量，包含两个锚点
+//   - LastTouch: 最近一次触及的摆动点索引
+//   - Confirmed: 触及次数达到要求、趋势线生效时的 K 线索引
+//   - BrokenIndex: 被收盘价突破时的 K 线索引，未被突破（包括被新线替换）时为 -1
+type TrendLine struct {
+	Direction   int     `json:"direction"`
+	StartIndex  int     `json:"start_index"`
+	StartPrice  float64 `json:"start_price"`
+	EndIndex    int     `json:"end_index"`
+	EndPrice    float64 `json:"end_price"`
+	Slope       float64 `json:"slope"`
+	Touches     int     `json:"touches"`
+	LastTouch   int     `json:"last_touch"`
+	Confirmed   int     `json:"confirmed"`
+	BrokenIndex int     `json:"broken_index"`
+}
+
+// PriceAt 返回趋势线在指定 K 线索引处的价格
+func (l TrendLine) PriceAt(index int) float64 {
+	return l.StartPrice + l.Slope*float64(index-l.StartIndex)
+}
+
+// TrendLineEvent 趋势线突破事件
+// 字段：
+//   - Index: 突破发生的 K 线索引
+//   - Direction: 1 为向上突破阻力线，-1 为向下跌破支撑线
+//   - Price: 突破时趋势线的价格
+//   - Line: 被突破的趋势线在 Lines 中的下标
+type TrendLineEvent struct {
+	Index     int     `json:"index"`
+	Direction int     `json:"direction"`
+	Price     float64 `json:"price"`
+	Line      int     `json:"line"`
+}
+
+// TaTrendLines 自动趋势线识别结果
+// 字段：
+//   - Lines: 生效过的趋势线，按生效顺序排列
+//   - Events: 突破事件
+//   - Support: 每根 K 线上当前有效支撑线的价格，没有时为 0
+//   - Resistance: 每根 K 线上当前有效阻力线的价格，没有时为 0
+//   - PivotPeriod: 轴点周期
+//   - MinTouches: 趋势线生效所需的触及次数
+//   - Tolerance: 触及与突破的容差，为价格的比例
+type TaTrendLines struct {
+	Lines       []TrendLine      `json:"lines"`
+	Events      []TrendLineEvent `json:"events"`
+	Support     []float64        `json:"support"`
+	Resistance  []float64        `json:"resistance"`
+	PivotPeriod int              `json:"pivot_period"`
+	MinTouches  int              `json:"min_touches"`
+	Tolerance   float64          `json:"tolerance"`
+
+	support, resistance int
+}
+
+// trendCandidate 某一侧当前跟踪的趋势线，id 为其在 Lines 中的下标，尚未生效时为 -1
+type trendCandidate struct {
+	line TrendLine
+	id   int
+}
+
+// CalculateTrendLines 通过近期摆动点拟合上升支撑线与下降阻力线，并检测突破
+// 参数：
+//   - klineData: K 线数据
+//   - pivotPeriod: 轴点左右两侧的 K 线数量，与 FindPivotHighPoint 一致
+//   - lookback: 两个锚点之间允许的最大 K 线数量
+//   - minTouches: 趋势线生效所需的摆动点触及次数（含两个锚点），至少为 2，常用 3
+//   - tolerance: 容差，为价格的比例，如 0.003；摆动点距趋势线在容差内视为触及，收盘价超出容差视为突破
+//
+// 返回值：
+//   - *TaTrendLines: 计算结果
+//   - error: 参数无效或数据不足时返回错误
+//
+// 说明/注意事项：
+//
+//	每一侧同时只跟踪一条趋势线。新的摆动点被确认时，若触及当前趋势线则触及次数加一；
+//	否则以它为第二个锚点，在 lookback 范围内寻找更高的摆动高点（阻力线）或更低的摆动低点（支撑线）作为第一个锚点，
+//	要求两个锚点之间至确认时没有收盘价越过趋势线，取触及次数最多的一条，次数相同时取更早的锚点。
+//	当前趋势线已生效时，只有触及次数不少于它的新线才会替换它。
+//	轴点需要右侧 pivotPeriod 根 K 线确认，因此第 i 根只使用已确认的摆动点，不含未来数据。
+//	只有生效的趋势线被突破时才产生事件，未生效的候选线被突破时直接丢弃。
+//
+// 示例：
+//
+//	tl, err := CalculateTrendLines(klineData, 5, 100, 3, 0.003)
+//	if err != nil {
+//	    // 处理错误
+//	}
+//	if tl.IsBreakAt(len(klineData)-1) == 1 {
+//	    // 最新一根 K 线向上突破阻力线
+//	}
+func CalculateTrendLines(klineData KlineDatas, pivotPeriod, lookback, minTouches int, tolerance float64) (*TaTrendLines, error) {
+	if pivotPeriod <= 0 || lookback <= 0 {
+		return nil, fmt.Errorf("周期必须大于0")
+	}
+	if minTouches < 2 {
+		return nil, fmt.Errorf("触及次数至少为2")
+	}
+	if tolerance < 0 {
+		return nil, fmt.Errorf("容差不能为负数")
+	}
+	length := len(klineData)
+	if length < pivotPeriod*2+1 {
+		return nil, fmt.Errorf("计算数据不足")
+	}
+
+	slices := preallocateSlices(length, 2)
+	result := &TaTrendLines{
+		Support:     slices[0],
+		Resistance:  slices[1],
+		PivotPeriod: pivotPeriod,
+		MinTouches:  minTouches,
+		Tolerance:   tolerance,
+	}
+
+	touches := func(l TrendLine, p StructurePoint) bool {
+		v := l.PriceAt(p.Index)
+		return math.Abs(p.Price-v) <= tolerance*math.Abs(v)
+	}
+	// broken 判断收盘价是否越过趋势线，direction 为 -1（阻力线）时向上越过，为 1（支撑线）时向下越过
+	broken := func(l TrendLine, i int) bool {
+		v := l.PriceAt(i)
+		if l.Direction == -1 {
+			return klineData[i].Close > v+tolerance*math.Abs(v)
+		}
+		return klineData[i].Close < v-tolerance*math.Abs(v)
+	}
+	save := func(c *trendCandidate, i int) {
+		if c.id < 0 && c.line.Touches >= minTouches {
+			c.line.Confirmed = i
+			c.id = len(result.Lines)
+			result.Lines = append(result.Lines, c.line)
+		} else if c.id >= 0 {
+			result.Lines[c.id] = c.line
+		}
+	}
+	// onPivot 处理新确认的摆动点 p，points 为同一侧此前已确认的摆动点
+	onPivot := func(cur *trendCandidate, points []StructurePoint, p StructurePoint, direction, i int) *trendCandidate {
+		if cur != nil && touches(cur.line, p) {
+			cur.line.Touches++
+			cur.line.LastTouch = p.Index
+			save(cur, i)
+			return cur
+		}
+
+		var best *trendCandidate
+		for a, anchor := range points {
+			if p.Index-anchor.Index > lookback {
+				continue
+			}
+			if (direction == -1 && anchor.Price <= p.Price) || (direction == 1 && anchor.Price >= p.Price) {
+				continue
+			}
+			line := TrendLine{
+				Direction:   direction,
+				StartIndex:  anchor.Index,
+				StartPrice:  anchor.Price,
+				EndIndex:    p.Index,
+				EndPrice:    p.Price,
+				Slope:       (p.Price - anchor.Price) / float64(p.Index-anchor.Index),
+				LastTouch:   p.Index,
+				BrokenIndex: -1,
+			}
+			violated := false
+			for k := anchor.Index + 1; k <= i; k++ {
+				if broken(line, k) {
+					violated = true
+					break
+				}
+			}
+			if violated {
+				continue
+			}
+			line.Touches = 2
+			for _, q := range points[a+1:] {
+				if touches(line, q) {
+					line.Touches++
+				}
+			}
+			if best == nil || line.Touches > best.line.Touches {
+				best = &trendCandidate{line: line, id: -1}
+			}
+		}
+
+		if best == nil || (cur != nil && cur.id >= 0 && best.line.Touches < cur.line.Touches) {
+			return cur
+		}
+		save(best, i)
+		return best
+	}
+
+	var highs, lows []StructurePoint
+	var resistance, support *trendCandidate
+	for i := 0; i < length; i++ {
+		if j := i - pivotPeriod; j >= pivotPeriod {
+			if ph := FindPivotHighPoint(klineData, j, pivotPeriod); !math.IsNaN(ph) {
+				p := StructurePoint{Index: j, Confirmed: i, Price: ph, IsHigh: true}
+				resistance = onPivot(resistance, highs, p, -1, i)
+				highs = append(highs, p)
+			}
+			if pl := FindPivotLowPoint(klineData, j, pivotPeriod); !math.IsNaN(pl) {
+				p := StructurePoint{Index: j, Confirmed: i, Price: pl, IsHigh: false}
+				support = onPivot(support, lows, p, 1, i)
+				lows = append(lows, p)
+			}
+		}
+
+		for _, side := range []**trendCandidate{&resistance, &support} {
+			c := *side
+			if c == nil {
+				continue
+			}
+			if broken(c.line, i) {
+				if c.id >= 0 {
+					c.line.BrokenIndex = i
+					result.Lines[c.id] = c.line
+					result.Events = append(result.Events, TrendLineEvent{
+						Index: i, Direction: -c.line.Direction, Price: c.line.PriceAt(i), Line: c.id,
+					})
+				}
+				*side = nil
+				continue
+			}
+			if c.id >= 0 {
+				if c.line.Direction == 1 {
+					result.Support[i] = c.line.PriceAt(i)
+				} else {
+					result.Resistance[i] = c.line.PriceAt(i)
+				}
+			}
+		}
+	}
+
+	result.support, result.resistance = -1, -1
+	if support != nil {
+		result.support = support.id
+	}
+	if resistance != nil {
+		result.resistance = resistance.id
+	}
+	return result, nil
+}
+
+// TrendLines 识别 K 线数据的趋势线，参数含义同 CalculateTrendLines
+func (k *KlineDatas) TrendLines(pivotPeriod, lookback, minTouches int, tolerance float64) (*TaTrendLines, error) {
+	return CalculateTrendLines(*k, pivotPeriod, lookback, minTouches, tolerance)
+}
+
+// Value 返回最新一根 K 线上有效支撑线与阻力线的价格，没有时为 0
+func (t *TaTrendLines) Value() (support, resistance float64) {
+	last := len(t.Support) - 1
+	return t.Support[last], t.Resistance[last]
+}
+
+// Active 返回当前有效的支撑线与阻力线
+// 返回值：
+//   - support/resistance: 当前有效的趋势线
+//   - hasSupport/hasResistance: 对应一侧没有有效趋势线时为 false
+func (t *TaTrendLines) Active() (support TrendLine, hasSupport bool, resistance TrendLine, hasResistance bool) {
+	if t.support >= 0 {
+		support, hasSupport = t.Lines[t.support], true
+	}
+	if t.resistance >= 0 {
+		resistance, hasResistance = t.Lines[t.resistance], true
+	}
+	return support, hasSupport, resistance, hasResistance
+}
+
+// LastEvent 返回最近一次趋势线突破事件
+// 返回值：
+//   - TrendLineEvent: 最近的突破事件
+//   - bool: 没有突破事件时返回 false
+func (t *TaTrendLines) LastEvent() (TrendLineEvent, bool) {
+	if len(t.Events) == 0 {
+		return TrendLineEvent{}, false
+	}
+	return t.Events[len(t.Events)-1], true
+}
+
+// IsBreakAt 判断指定位置是否发生趋势线突破
+// 参数：
+//   - index: K 线索引
+//
+// 返回值：
+//   - int: 1 向上突破阻力线，-1 向下跌破支撑线，0 无突破；同一根 K 线两侧同时突破时返回 0
+func (t *TaTrendLines) IsBreakAt(index int) int {
+	direction := 0
+	for i := len(t.Events) - 1; i >= 0 && t.Events[i].Index >= index; i-- {
+		if t.Events[i].Index == index {
+			direction += t.Events[i].Direction
+		}
+	}
+	return direction
+}
+
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
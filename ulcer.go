@@ -0,0 +1,137 @@
+package ta
+
+import (
+	"fmt"
+	"math"
+)
+
+// TaUlcer 溃疡指数（Ulcer Index）的计算结果
+// 说明：
+//
+//	溃疡指数只衡量下行风险：先计算每根 K 线相对最近 period 根最高价的回撤百分比，
+//	再取最近 period 个回撤的均方根。与标准差不同，上涨不会增加风险读数。
+//
+// 字段：
+//   - Values: 溃疡指数，单位为百分比，前 2×(period-1) 个位置为 0
+//   - Drawdown: 相对最近 period 根最高价的回撤百分比（≤ 0），前 period-1 个位置为 0
+//   - Period: 计算周期
+type TaUlcer struct {
+	Values   []float64 `json:"values"`
+	Drawdown []float64 `json:"drawdown"`
+	Period   int       `json:"period"`
+}
+
+// CalculateUlcer 计算溃疡指数
+// 参数：
+//   - prices: 价格序列
+//   - period: 计算周期，常用 14
+//
+// 返回值：
+//   - *TaUlcer: 溃疡指数结果
+//   - error: 周期无效或数据不足时返回错误
+//
+// 示例：
+//
+//	ulcer, err := CalculateUlcer(closes, 14)
+//	if err != nil {
+//	    // 处理错误
+//	}
+//	ui := ulcer.Value()
+func CalculateUlcer(prices []float64, period int) (*TaUlcer, error) {
+	if period <= 0 {
+		return nil, fmt.Errorf("周期必须大于0")
+	}
+	if len(prices) < 2*period-1 {
+		return nil, fmt.Errorf("计算数据不足")
+	}
+
+	length := len(prices)
+	slices := preallocateSlices(length, 2)
+	values, drawdown := slices[0], slices[1]
+
+	for i := period - 1; i < length; i++ {
+		highest := prices[i]
+		for j := i - period + 1; j < i; j++ {
+			highest = math.Max(highest, prices[j])
+		}
+		if highest != 0 {
+			drawdown[i] = (prices[i] - highest) / highest * 100
+		}
+	}
+
+	var sumSq float64
+	for i := period - 1; i < length; i++ {
+		sumSq += drawdown[i] * drawdown[i]
+		if i >= 2*period-1 {
+			sumSq -= drawdown[i-period] * drawdown[i-period]
+		}
+		if i >= 2*period-2 {
+			values[i] = math.Sqrt(math.Max(sumSq, 0) / float64(period))
+		}
+	}
+
+	return &TaUlcer{
+		Values:   values,
+		Drawdown: drawdown,
+		Period:   period,
+	}, nil
+}
+
+// Ulcer 从 KlineDatas 中提取数据并计算溃疡指数
+// 参数：
+//   - period: 计算周期
+//   - source: 数据源，如 "close"
+//
+// 返回值：
+//   - *TaUlcer: 溃疡指数结果
+//   - error: 提取数据或计算过程中的错误
+func (k *KlineDatas) Ulcer(period int, source string) (*TaUlcer, error) {
+	prices, err := k.ExtractSlice(source)
+	if err != nil {
+		return nil, err
+	}
+	return CalculateUlcer(prices, period)
+}
+
+// Ulcer_ 计算并返回最新的溃疡指数，数据不足时返回 0
+func (k *KlineDatas) Ulcer_(period int, source string) float64 {
+	_k, err := k.Keep(quickKeep("ulcer", period))
+	if err != nil {
+		_k = *k
+	}
+	ulcer, err := _k.Ulcer(period, source)
+	if err != nil {
+		return 0
+	}
+	return ulcer.Value()
+}
+
+// Value 返回最新的溃疡指数
+func (t *TaUlcer) Value() float64 {
+	return t.Values[len(t.Values)-1]
+}
+
+// PerformanceIndex 计算溃疡绩效指数（UPI，又称 Martin 比率）
+// 参数：
+//   - returnPct: 同一区间的收益率百分比，如年化收益 25 表示 25%
+//   - benchmarkPct: 基准收益率百分比，如无风险利率 4
+//
+// 返回值：
+//   - float64: (returnPct − benchmarkPct) / 最新溃疡指数；溃疡指数为 0 时返回 0
+//
+// 说明/注意事项：
+//
+//	可用作风险调整后的仓位权重：UPI 越高，单位回撤风险获得的超额收益越多。
+func (t *TaUlcer) PerformanceIndex(returnPct, benchmarkPct float64) float64 {
+	ui := t.Value()
+	if ui == 0 {
+		return 0
+	}
+	return (returnPct - benchmarkPct) / ui
+}
+
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
@@ -0,0 +1,122 @@
+package ta
+
+import (
+	"fmt"
+)
+
+// 绝对单位指标的换算方式
+const (
+	// UnitRaw 原始单位（价格差），不换算
+	UnitRaw = iota
+	// UnitPercent 相对同一根 K 线价格的百分比
+	UnitPercent
+	// UnitATR 相对同一根 K 线 ATR 的倍数
+	UnitATR
+)
+
+// ScaleUnits 将价格差单位的序列换算为相对价格的百分比或 ATR 倍数
+// 参数：
+//   - values: 价格差单位的序列，如 MACD、动量
+//   - prices: 与 values 等长的价格序列，UnitPercent 时使用
+//   - atr: 与 values 等长的 ATR 序列，UnitATR 时使用
+//   - unit: 换算方式，UnitRaw、UnitPercent 或 UnitATR
+//
+// 返回值：
+//   - []float64: 换算后的新序列，除数为 0 的位置（如预热期）为 0
+//   - error: 换算方式无效或长度不一致时返回错误
+//
+// 说明/注意事项：
+//
+//	MACD、动量等指标的数值随价格水平变化，BTC 与低价币的阈值无法共用；
+//	换算为百分比或 ATR 倍数后，同一阈值可用于不同价格水平和波动率的品种。
+func ScaleUnits(values, prices, atr []float64, unit int) ([]float64, error) {
+	var divisor []float64
+	scale := 1.0
+	switch unit {
+	case UnitRaw:
+		return append([]float64(nil), values...), nil
+	case UnitPercent:
+		divisor, scale = prices, 100
+	case UnitATR:
+		divisor = atr
+	default:
+		return nil, fmt.Errorf("无效的换算方式: %d", unit)
+	}
+	if len(divisor) != len(values) {
+		return nil, fmt.Errorf("输入数据长度不一致")
+	}
+
+	out := make([]float64, len(values))
+	for i, v := range values {
+		if divisor[i] != 0 {
+			out[i] = v / divisor[i] * scale
+		}
+	}
+	return out, nil
+}
+
+// Scaled 返回换算单位后的 MACD 副本，参数含义同 ScaleUnits
+// 示例：
+//
+//	atr, _ := klineData.ATR(14)
+//	closes, _ := klineData.ExtractSlice("close")
+//	macd, _ := klineData.MACD("close", 12, 26, 9)
+//	scaled, err := macd.Scaled(UnitATR, closes, atr.Values)
+func (t *TaMacd) Scaled(unit int, prices, atr []float64) (*TaMacd, error) {
+	scaled := *t
+	var err error
+	if scaled.Macd, err = ScaleUnits(t.Macd, prices, atr, unit); err != nil {
+		return nil, err
+	}
+	if scaled.Dif, err = ScaleUnits(t.Dif, prices, atr, unit); err != nil {
+		return nil, err
+	}
+	if scaled.Dea, err = ScaleUnits(t.Dea, prices, atr, unit); err != nil {
+		return nil, err
+	}
+	return &scaled, nil
+}
+
+// Scaled 返回换算单位后的动量副本，参数含义同 ScaleUnits
+func (t *TaMomentum) Scaled(unit int, prices, atr []float64) (*TaMomentum, error) {
+	values, err := ScaleUnits(t.Values, prices, atr, unit)
+	if err != nil {
+		return nil, err
+	}
+	scaled := *t
+	scaled.Values = values
+	return &scaled, nil
+}
+
+// SlopeScaled 返回以平均成交量为单位的 OBV 斜率
+// 参数：
+//   - n: 回看的 K 线数量
+//   - volumes: 与 OBV 等长的成交量序列
+//
+// 返回值：
+//   - float64: Slope(n) / 最近 n 根 K 线的平均成交量，取值 -1 到 1；数据不足或成交量为 0 时返回 0
+//
+// 说明/注意事项：
+//
+//	OBV 以成交量为单位，不适用价格百分比或 ATR 换算；除以平均成交量后，
+//	1 表示每根 K 线都是放量上涨，-1 表示每根都是放量下跌，可跨品种比较。
+func (t *TaOBV) SlopeScaled(n int, volumes []float64) float64 {
+	lastIndex := len(t.Values) - 1
+	if n <= 0 || lastIndex-n < 0 || len(volumes) != len(t.Values) {
+		return 0
+	}
+	var sum float64
+	for _, v := range volumes[lastIndex-n+1:] {
+		sum += v
+	}
+	if sum == 0 {
+		return 0
+	}
+	return t.Slope(n) / (sum / float64(n))
+}
+
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
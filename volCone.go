@@ -0,0 +1,158 @@
+package ta
+
+import (
+	"fmt"
+	"math"
+	"sort"
+)
+
+// TaVolCone 波动率锥的计算结果
+// 说明：
+//
+//	对每个观察周期（horizon），用滚动窗口计算历史已实现波动率的分布，
+//	并给出当前波动率在该分布中的位置，可用于判断波动率相对高低。
+//
+// 字段：
+//   - Horizons: 观察周期（K 线数量）
+//   - Min/P25/Median/P75/Max: 各周期历史已实现波动率的分位数
+//   - Current: 各周期最近一个窗口的已实现波动率
+//   - Percentile: 当前波动率在历史分布中的百分位（0-100）
+type TaVolCone struct {
+	Horizons   []int     `json:"horizons"`
+	Min        []float64 `json:"min"`
+	P25        []float64 `json:"p25"`
+	Median     []float64 `json:"median"`
+	P75        []float64 `json:"p75"`
+	Max        []float64 `json:"max"`
+	Current    []float64 `json:"current"`
+	Percentile []float64 `json:"percentile"`
+}
+
+// percentileSorted 对已排序数据按线性插值计算分位数，q 取值 [0, 1]
+func percentileSorted(sorted []float64, q float64) float64 {
+	if len(sorted) == 0 {
+		return math.NaN()
+	}
+	pos := q * float64(len(sorted)-1)
+	lo := int(math.Floor(pos))
+	hi := int(math.Ceil(pos))
+	if lo == hi {
+		return sorted[lo]
+	}
+	return sorted[lo] + (sorted[hi]-sorted[lo])*(pos-float64(lo))
+}
+
+// percentileRank 返回 v 在样本中的百分位排名（0-100）
+func percentileRank(sorted []float64, v float64) float64 {
+	if len(sorted) == 0 {
+		return math.NaN()
+	}
+	below := sort.SearchFloat64s(sorted, v)
+	equal := sort.SearchFloat64s(sorted, math.Nextafter(v, math.Inf(1))) - below
+	return (float64(below) + 0.5*float64(equal)) / float64(len(sorted)) * 100
+}
+
+// CalculateVolCone 计算波动率锥
+// 参数：
+//   - prices: 收盘价序列
+//   - horizons: 观察周期列表，如 []int{10, 20, 60, 120}
+//   - periodsPerYear: 年化因子，如日线 365（加密货币）或 252，小时线 8760
+//
+// 返回值：
+//   - *TaVolCone: 计算结果
+//   - error: 数据不足或参数无效时返回错误
+//
+// 说明/注意事项：
+//
+//	已实现波动率 = 窗口内对数收益的样本标准差 × √periodsPerYear。
+//
+// 示例：
+//
+//	cone, err := CalculateVolCone(closes, []int{10, 20, 60}, 365)
+func CalculateVolCone(prices []float64, horizons []int, periodsPerYear float64) (*TaVolCone, error) {
+	if len(horizons) == 0 {
+		return nil, fmt.Errorf("观察周期不能为空")
+	}
+	if periodsPerYear <= 0 {
+		return nil, fmt.Errorf("年化因子必须大于0")
+	}
+
+	returns := make([]float64, 0, len(prices))
+	for i := 1; i < len(prices); i++ {
+		if prices[i] <= 0 || prices[i-1] <= 0 {
+			return nil, fmt.Errorf("第%d个价格非正，无法计算对数收益", i+1)
+		}
+		returns = append(returns, math.Log(prices[i]/prices[i-1]))
+	}
+
+	count := len(horizons)
+	cone := &TaVolCone{
+		Horizons:   horizons,
+		Min:        make([]float64, count),
+		P25:        make([]float64, count),
+		Median:     make([]float64, count),
+		P75:        make([]float64, count),
+		Max:        make([]float64, count),
+		Current:    make([]float64, count),
+		Percentile: make([]float64, count),
+	}
+	annualize := math.Sqrt(periodsPerYear)
+
+	for h, horizon := range horizons {
+		if horizon < 2 {
+			return nil, fmt.Errorf("观察周期必须不小于2")
+		}
+		if len(returns) < horizon {
+			return nil, fmt.Errorf("计算数据不足: 观察周期%d需要至少%d个价格", horizon, horizon+1)
+		}
+
+		vols := make([]float64, 0, len(returns)-horizon+1)
+		var sum, sumSquares float64
+		for i, r := range returns {
+			sum += r
+			sumSquares += r * r
+			if i >= horizon {
+				old := returns[i-horizon]
+				sum -= old
+				sumSquares -= old * old
+			}
+			if i >= horizon-1 {
+				n := float64(horizon)
+				variance := (sumSquares - sum*sum/n) / (n - 1)
+				vols = append(vols, math.Sqrt(math.Max(variance, 0))*annualize)
+			}
+		}
+
+		current := vols[len(vols)-1]
+		sort.Float64s(vols)
+		cone.Min[h] = vols[0]
+		cone.P25[h] = percentileSorted(vols, 0.25)
+		cone.Median[h] = percentileSorted(vols, 0.5)
+		cone.P75[h] = percentileSorted(vols, 0.75)
+		cone.Max[h] = vols[len(vols)-1]
+		cone.Current[h] = current
+		cone.Percentile[h] = percentileRank(vols, current)
+	}
+
+	return cone, nil
+}
+
+// VolCone 从 KlineDatas 的收盘价计算波动率锥
+func (k *KlineDatas) VolCone(horizons []int, periodsPerYear float64) (*TaVolCone, error) {
+	prices, err := k.ExtractSlice("close")
+	if err != nil {
+		return nil, err
+	}
+	return CalculateVolCone(prices, horizons, periodsPerYear)
+}
+
+// Value 返回第一个观察周期的当前波动率及其百分位
+func (t *TaVolCone) Value() (current, percentile float64) {
+	return t.Current[0], t.Percentile[0]
+}
+
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
@@ -0,0 +1,133 @@
+package ta
+
+import (
+	"fmt"
+)
+
+// TaAnchoredVWAP 锚定成交量加权平均价
+// 字段：
+//   - Values: 与 K 线等长的 VWAP 序列，锚点之前为 0
+//   - Anchor: 锚点 K 线下标
+//   - StartTime: 锚点 K 线的开始时间
+//   - Close: 与 K 线等长的收盘价，用于判断价格相对 VWAP 的位置
+type TaAnchoredVWAP struct {
+	Values    []float64 `json:"values"`
+	Anchor    int       `json:"anchor"`
+	StartTime int64     `json:"start_time"`
+	Close     []float64 `json:"close"`
+}
+
+// CalculateAnchoredVWAP 从指定 K 线开始计算锚定 VWAP
+// 参数：
+//   - klineData: K 线数据
+//   - anchorIndex: 锚点 K 线下标，可以是波段高低点或事件发生的 K 线
+//
+// 返回值：
+//   - *TaAnchoredVWAP: 锚定 VWAP 结果
+//   - error: 锚点越界时返回错误
+//
+// 说明/注意事项：
+//
+//	价格使用典型价格 (high+low+close)/3，从锚点起累计 价格×成交量 与成交量。
+//	累计成交量为 0 时沿用典型价格。
+//
+// 示例：
+//
+//	vwap, err := CalculateAnchoredVWAP(klineData, swingLowIndex)
+//	if err != nil {
+//	    // 处理错误
+//	}
+//	above := vwap.IsAboveVWAP()
+func CalculateAnchoredVWAP(klineData KlineDatas, anchorIndex int) (*TaAnchoredVWAP, error) {
+	length := len(klineData)
+	if length == 0 {
+		return nil, fmt.Errorf("计算数据不足")
+	}
+	if anchorIndex < 0 || anchorIndex >= length {
+		return nil, fmt.Errorf("锚点下标(%d)超出范围[0, %d)", anchorIndex, length)
+	}
+
+	slices := preallocateSlices(length, 2)
+	values, closes := slices[0], slices[1]
+	var sumPV, sumV float64
+	for i, kline := range klineData {
+		closes[i] = kline.Close
+		if i < anchorIndex {
+			continue
+		}
+		typical := (kline.High + kline.Low + kline.Close) / 3
+		sumPV += typical * kline.Volume
+		sumV += kline.Volume
+		if sumV > 0 {
+			values[i] = sumPV / sumV
+		} else {
+			values[i] = typical
+		}
+	}
+
+	return &TaAnchoredVWAP{
+		Values:    values,
+		Anchor:    anchorIndex,
+		StartTime: klineData[anchorIndex].StartTime,
+		Close:     closes,
+	}, nil
+}
+
+// AnchoredVWAP 从指定下标的 K 线开始计算锚定 VWAP
+// 参数：
+//   - anchorIndex: 锚点 K 线下标
+//
+// 返回值：
+//   - *TaAnchoredVWAP: 锚定 VWAP 结果
+//   - error: 锚点越界时返回错误
+func (k *KlineDatas) AnchoredVWAP(anchorIndex int) (*TaAnchoredVWAP, error) {
+	return CalculateAnchoredVWAP(*k, anchorIndex)
+}
+
+// AnchoredVWAPAt 从指定时间的 K 线开始计算锚定 VWAP
+// 参数：
+//   - startTime: 锚点时间，使用开始时间不早于该时间的第一根 K 线
+//
+// 返回值：
+//   - *TaAnchoredVWAP: 锚定 VWAP 结果
+//   - error: 没有不早于该时间的 K 线时返回错误
+func (k *KlineDatas) AnchoredVWAPAt(startTime int64) (*TaAnchoredVWAP, error) {
+	for i, kline := range *k {
+		if kline.StartTime >= startTime {
+			return CalculateAnchoredVWAP(*k, i)
+		}
+	}
+	return nil, fmt.Errorf("没有开始时间不早于 %d 的K线", startTime)
+}
+
+// Value 返回最新的 VWAP 值
+func (t *TaAnchoredVWAP) Value() float64 {
+	return t.Values[len(t.Values)-1]
+}
+
+// IsAboveVWAP 判断最新收盘价是否高于 VWAP
+func (t *TaAnchoredVWAP) IsAboveVWAP() bool {
+	lastIndex := len(t.Values) - 1
+	return t.Close[lastIndex] > t.Values[lastIndex]
+}
+
+// IsBelowVWAP 判断最新收盘价是否低于 VWAP
+func (t *TaAnchoredVWAP) IsBelowVWAP() bool {
+	lastIndex := len(t.Values) - 1
+	return t.Close[lastIndex] < t.Values[lastIndex]
+}
+
+// Distance 返回最新收盘价相对 VWAP 的偏离百分比
+func (t *TaAnchoredVWAP) Distance() float64 {
+	lastIndex := len(t.Values) - 1
+	if t.Values[lastIndex] == 0 {
+		return 0
+	}
+	return (t.Close[lastIndex] - t.Values[lastIndex]) / t.Values[lastIndex] * 100
+}
+
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
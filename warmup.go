@@ -0,0 +1,133 @@
+package ta
+
+import (
+	"math"
+	"strings"
+)
+
+// KeepPolicy 快捷函数（如 RSI_、MACD_）保留 K 线数量的计算策略
+// 参数：
+//   - indicator: 指标名称，与 WarmupLength 一致
+//   - periods: 指标的周期参数
+//
+// 返回值：
+//   - int: 计算时保留的最近 K 线数量，数据量不足时使用全部数据
+type KeepPolicy func(indicator string, periods ...int) int
+
+// ConvergenceFactor 递归指标在预热之后额外保留的 K 线数量相对最长周期的倍数
+// 说明：
+//
+//	EMA/RMA 类指标初始值的影响约按 (1-α)^n 衰减，取 10 倍周期时 RSI/ATR 的残余误差约为 e^-10。
+var ConvergenceFactor = 10.0
+
+// QuickKeep 快捷函数使用的保留策略，默认为 DefaultKeepPolicy，可替换为自定义实现
+// 说明：
+//
+//	应在初始化时设置，不要与快捷函数的调用并发修改。
+var QuickKeep KeepPolicy = DefaultKeepPolicy
+
+// recursiveIndicators 结果依赖全部历史数据、需要额外收敛长度的指标
+var recursiveIndicators = map[string]bool{
+	"ema": true, "rma": true, "rsi": true, "atr": true, "macd": true, "adx": true,
+	"kdj": true, "supertrend": true, "stochrsi": true, "t3": true, "momentum": true,
+	"elderray": true, "forceindex": true, "atrbands": true,
+}
+
+// WarmupLength 返回指标产生第一个有效值之前的 K 线数量
+// 参数：
+//   - indicator: 指标名称，如 "rsi"、"macd"、"supertrend"，不区分大小写
+//   - periods: 指标的周期参数，顺序与对应的计算函数一致
+//
+// 返回值：
+//   - int: 预热 K 线数量，即第一个有效值的下标；未知指标或参数不足时返回 0
+//
+// 说明/注意事项：
+//
+//	EMA/RMA 等递归指标在预热结束后仍受初始值影响，需要更多数据才能收敛。
+//
+// 示例：
+//
+//	warmup := WarmupLength("macd", 12, 26, 9) // 33
+func WarmupLength(indicator string, periods ...int) int {
+	arg := func(i int) int {
+		if i < len(periods) && periods[i] > 0 {
+			return periods[i]
+		}
+		return 0
+	}
+	switch strings.ToLower(indicator) {
+	case "sma", "ema", "rma", "cci", "wr", "boll", "cmf", "kdj", "linreg", "elderray",
+		"savitzkygolay", "loess", "candlestats":
+		return max0(arg(0) - 1)
+	case "rsi", "atr", "supertrend", "aroon", "roc", "calmar", "forceindex":
+		return arg(0)
+	case "adx":
+		return 2 * arg(0)
+	case "atrbands":
+		if arg(1) > arg(0)-1 {
+			return arg(1)
+		}
+		return max0(arg(0) - 1)
+	case "macd":
+		return max0(arg(1) + arg(2) - 2)
+	case "stochrsi":
+		return arg(0) + max0(arg(1)-1) + max0(arg(2)-1) + max0(arg(3)-1)
+	case "stoch":
+		return max0(arg(0) + arg(1) + arg(2) - 3)
+	case "coppock":
+		return max0(arg(0) + arg(2) - 1)
+	case "momentum":
+		return arg(0) + max0(arg(1)-1)
+	case "t3":
+		return max0(6 * (arg(0) - 1))
+	case "ulcer":
+		return max0(2 * (arg(0) - 1))
+	case "obv":
+		return 1
+	}
+	return 0
+}
+
+// DefaultKeepPolicy 默认的保留策略
+// 说明：
+//
+//	窗口为预热长度加 1；递归指标再加上 ConvergenceFactor 倍的最长周期。
+//
+// 示例：
+//
+//	DefaultKeepPolicy("rsi", 14) // 14 + 1 + 140 = 155
+//	DefaultKeepPolicy("sma", 20) // 19 + 1 = 20
+func DefaultKeepPolicy(indicator string, periods ...int) int {
+	window := WarmupLength(indicator, periods...) + 1
+	if recursiveIndicators[strings.ToLower(indicator)] {
+		longest := 0
+		for _, p := range periods {
+			if p > longest {
+				longest = p
+			}
+		}
+		window += int(math.Ceil(ConvergenceFactor * float64(longest)))
+	}
+	return window
+}
+
+// quickKeep 按 QuickKeep 策略返回快捷函数计算时保留的 K 线数量
+func quickKeep(indicator string, periods ...int) int {
+	if QuickKeep == nil {
+		return DefaultKeepPolicy(indicator, periods...)
+	}
+	return QuickKeep(indicator, periods...)
+}
+
+func max0(n int) int {
+	if n < 0 {
+		return 0
+	}
+	return n
+}
+
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
@@ -63,7 +63,7 @@ func (k *KlineDatas) WilliamsR(period int) (*TaWilliamsR, error) {
 
 func (k *KlineDatas) WilliamsR_(period int) float64 {
 
-	_k, err := k.Keep(period * 14)
+	_k, err := k.Keep(quickKeep("wr", period))
 	if err != nil {
 		_k = *k
 	}
@@ -0,0 +1,127 @@
+package ta
+
+import (
+	"fmt"
+)
+
+// Window 滚动窗口迭代器，统一窗口指标的边界处理
+// 说明：
+//
+//	窗口以当前位置为右端（包含），向前最多取 Size 个数据：[max(0, i-Size+1), i]。
+//	第一个窗口的右端为 MinPeriods-1，此后每次前进 Step 个位置，直到超出序列末尾。
+//	MinPeriods 等于 Size、Step 为 1 时与内置指标的预热规则一致（前 Size-1 个位置没有窗口）。
+//
+// 字段：
+//   - Size: 窗口最大长度
+//   - Step: 相邻窗口右端的间隔
+//   - MinPeriods: 窗口的最小数据量，不足时不产生窗口
+//
+// 示例：
+//
+//	w, err := NewWindow(len(prices), 20, 1, 0)
+//	if err != nil {
+//	    // 处理错误
+//	}
+//	for w.Next() {
+//	    segment := prices[w.Start():w.End()]
+//	    values[w.Index()] = ...
+//	}
+type Window struct {
+	Size       int `json:"size"`
+	Step       int `json:"step"`
+	MinPeriods int `json:"min_periods"`
+
+	length int
+	index  int
+}
+
+// NewWindow 创建滚动窗口迭代器
+// 参数：
+//   - length: 序列长度
+//   - size: 窗口最大长度
+//   - step: 相邻窗口右端的间隔，必须大于 0
+//   - minPeriods: 窗口的最小数据量，取值 1 到 size，0 表示等于 size
+//
+// 返回值：
+//   - *Window: 迭代器，需调用 Next 移动到第一个窗口
+//   - error: 参数无效时返回错误
+func NewWindow(length, size, step, minPeriods int) (*Window, error) {
+	if size <= 0 {
+		return nil, fmt.Errorf("窗口长度必须大于0")
+	}
+	if step <= 0 {
+		return nil, fmt.Errorf("步长必须大于0")
+	}
+	if minPeriods == 0 {
+		minPeriods = size
+	}
+	if minPeriods < 0 || minPeriods > size {
+		return nil, fmt.Errorf("最小数据量必须在1到窗口长度之间")
+	}
+	if length < 0 {
+		return nil, fmt.Errorf("序列长度不能为负数")
+	}
+	w := &Window{Size: size, Step: step, MinPeriods: minPeriods, length: length}
+	w.Reset()
+	return w, nil
+}
+
+// Reset 将迭代器恢复到第一个窗口之前
+func (w *Window) Reset() {
+	w.index = w.MinPeriods - 1 - w.Step
+}
+
+// Next 移动到下一个窗口，没有更多窗口时返回 false
+func (w *Window) Next() bool {
+	if w.index+w.Step >= w.length {
+		w.index = w.length
+		return false
+	}
+	w.index += w.Step
+	return true
+}
+
+// Index 返回当前窗口的右端下标，即结果应写入的位置
+func (w *Window) Index() int {
+	return w.index
+}
+
+// Start 返回当前窗口的起始下标（包含）
+func (w *Window) Start() int {
+	if start := w.index - w.Size + 1; start > 0 {
+		return start
+	}
+	return 0
+}
+
+// End 返回当前窗口的结束下标（不包含），可直接用于切片
+func (w *Window) End() int {
+	return w.index + 1
+}
+
+// Count 返回窗口总数
+func (w *Window) Count() int {
+	if w.length < w.MinPeriods {
+		return 0
+	}
+	return (w.length-w.MinPeriods)/w.Step + 1
+}
+
+// Windows 返回覆盖当前 K 线的滚动窗口迭代器，参数含义同 NewWindow
+//
+// 示例：
+//
+//	w, _ := klineData.Windows(50, 10, 0)
+//	for w.Next() {
+//	    segment := klineData[w.Start():w.End()]
+//	    // 每 10 根 K 线对最近 50 根做一次分析
+//	}
+func (k *KlineDatas) Windows(size, step, minPeriods int) (*Window, error) {
+	return NewWindow(len(*k), size, step, minPeriods)
+}
+
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------
+// ----------------------------------------------------------------------------